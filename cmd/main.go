@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
+	"expvar"
 	"github.com/ShpullRequest/backend/internal/api"
+	"github.com/ShpullRequest/backend/internal/auth"
 	"github.com/ShpullRequest/backend/internal/config"
 	"github.com/ShpullRequest/backend/internal/handlers"
 	"github.com/ShpullRequest/backend/internal/middlewares"
 	"github.com/ShpullRequest/backend/internal/repository"
+	"github.com/ShpullRequest/backend/pkg/blobstore"
+	"github.com/ShpullRequest/backend/pkg/geocoder"
+	"github.com/ShpullRequest/backend/pkg/ip"
 	"github.com/ShpullRequest/backend/pkg/logger"
 	"go.uber.org/zap"
+	"net/http"
+	// Часовые пояса мест не должны зависеть от tzdata в образе
+	_ "time/tzdata"
 )
 
 // @title Prisma
@@ -33,11 +42,43 @@ func main() {
 	}
 	log.Debug("Success connection to database")
 
-	apiService := api.New(config.Config, pg, log)
+	blobs, err := blobstore.New(config.Config)
+	if err != nil {
+		log.Panic("Error initialization blob storage", zap.Error(err))
+	}
+
+	authenticator, sessions, err := auth.New(config.Config, pg)
+	if err != nil {
+		log.Panic("Error initialization authentication", zap.Error(err))
+	}
+
+	geo, err := geocoder.New(config.Config, pg)
+	if err != nil {
+		log.Panic("Error initialization geocoder", zap.Error(err))
+	}
+
+	locator, err := ip.New(config.Config)
+	if err != nil {
+		log.Panic("Error initialization ip geolocation", zap.Error(err))
+	}
+
+	if config.Config.TrendingRefresh > 0 {
+		go pg.WatchTrendingScores(context.Background(), config.Config.TrendingRefresh, config.Config.TrendingHalfLife)
+	}
+
+	apiService := api.New(config.Config, pg, log, blobs, authenticator, sessions, geo, locator)
 	middlewares.ConfigureService(apiService)
 	handlers.ConfigureService(apiService)
 	log.Debug("Services: API, middleware, handlers have been successfully configured and sent to launch")
 
+	if config.Config.MetricsAddress != "" {
+		go func() {
+			if err := http.ListenAndServe(config.Config.MetricsAddress, expvar.Handler()); err != nil {
+				log.Error("Error run metrics server", zap.Error(err))
+			}
+		}()
+	}
+
 	if err = apiService.GetRouter().Run(config.Config.Address); err != nil {
 		log.Panic("Error run api server", zap.Error(err))
 	}
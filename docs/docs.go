@@ -33,6 +33,12 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -90,7 +96,7 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Ссылка на иконку достижения (должна быть валидной URL)",
+                        "description": "Ссылка на иконку достижения (валидный URL или идентификатор загруженного изображения)",
                         "name": "icon",
                         "in": "body",
                         "required": true,
@@ -155,6 +161,12 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "Уникальный идентификатор достижения (в формате UUID)",
@@ -232,7 +244,15 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Ссылка на иконку достижения (должна быть в формате URL)",
+                        "description": "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык",
+                        "name": "translations",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translations"
+                        }
+                    },
+                    {
+                        "description": "Ссылка на иконку достижения (валидный URL или идентификатор загруженного изображения)",
                         "name": "icon",
                         "in": "body",
                         "schema": {
@@ -282,17 +302,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/companies": {
+        "/api-keys": {
             "get": {
-                "description": "Возвращает список всех компаний в системе.",
+                "description": "Возвращает все ключи партнерского API со счетчиками использования. Доступно только администраторам.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить все компании",
-                "operationId": "get-all-companies",
+                "summary": "Получить ключи API",
+                "operationId": "get-all-api-keys",
                 "parameters": [
                     {
                         "type": "string",
@@ -308,10 +328,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Company"
+                                "$ref": "#/definitions/models.APIKey"
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -321,15 +347,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создает новую компанию с указанными параметрами.",
+                "description": "Создает ключ партнерского API от имени пользователя. Открытое значение ключа возвращается только в этом ответе.\nКлюч, привязанный к компании, может изменять только объекты этой компании. Доступно только администраторам.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Создать новую компанию",
-                "operationId": "create-company",
+                "summary": "Создать ключ API",
+                "operationId": "create-api-key",
                 "parameters": [
                     {
                         "type": "string",
@@ -339,17 +365,17 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Название компании (минимум 6 символов)",
-                        "name": "name",
+                        "description": "VK ID пользователя, от имени которого работает ключ",
+                        "name": "vk_id",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     },
                     {
-                        "description": "Описание компании (минимум 12 символов)",
-                        "name": "description",
+                        "description": "Название ключа (минимум 3 символа)",
+                        "name": "name",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -357,20 +383,39 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Ссылка на фото компании (должна быть валидной URL)",
-                        "name": "photo_card",
+                        "description": "Области действия: read:places, read:events, read:routes, write:places, write:events, write:routes",
+                        "name": "scopes",
                         "in": "body",
                         "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Компания, которой ограничены изменения (в формате UUID)",
+                        "name": "company_id",
+                        "in": "body",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Дневная квота запросов, 0 - без ограничений",
+                        "name": "daily_quota",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Company"
+                            "$ref": "#/definitions/models.APIKeyCreated"
                         }
                     },
                     "400": {
@@ -379,6 +424,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -388,17 +445,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/companies/my": {
-            "get": {
-                "description": "Возвращает список компаний, связанных с текущим пользователем.",
+        "/api-keys/{keyId}": {
+            "delete": {
+                "description": "Отзывает ключ, после чего запросы с ним отклоняются. Доступно только администраторам.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить мои компании",
-                "operationId": "get-my-companies",
+                "summary": "Отозвать ключ API",
+                "operationId": "revoke-api-key",
                 "parameters": [
                     {
                         "type": "string",
@@ -406,16 +463,44 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор ключа (в формате UUID)",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Company"
-                            }
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
@@ -425,19 +510,17 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/companies/{companyId}": {
-            "get": {
-                "description": "Возвращает информацию о конкретной компании по её ID.",
+            },
+            "patch": {
+                "description": "Изменяет название, области действия или дневную квоту ключа. Доступно только администраторам.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить информацию о компании",
-                "operationId": "get-company",
+                "summary": "Редактировать ключ API",
+                "operationId": "edit-api-key",
                 "parameters": [
                     {
                         "type": "string",
@@ -448,17 +531,44 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор компании (в формате UUID)",
-                        "name": "companyId",
+                        "description": "Уникальный идентификатор ключа (в формате UUID)",
+                        "name": "keyId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Название ключа (минимум 3 символа)",
+                        "name": "name",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Области действия",
+                        "name": "scopes",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Дневная квота запросов, 0 - без ограничений",
+                        "name": "daily_quota",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Company"
+                            "$ref": "#/definitions/models.APIKey"
                         }
                     },
                     "400": {
@@ -467,6 +577,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -480,17 +596,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Подтверждает компанию администратором.",
+            }
+        },
+        "/api-keys/{keyId}/usage": {
+            "get": {
+                "description": "Возвращает количество запросов по ключу за последние дни. Доступно только администраторам.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Принять компанию",
-                "operationId": "accept-company",
+                "summary": "Получить использование ключа API",
+                "operationId": "get-api-key-usage",
                 "parameters": [
                     {
                         "type": "string",
@@ -501,17 +619,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор компании (в формате UUID)",
-                        "name": "companyId",
+                        "description": "Уникальный идентификатор ключа (в формате UUID)",
+                        "name": "keyId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество дней (по умолчанию 30, максимум 365)",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Company"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIKeyUsage"
+                            }
                         }
                     },
                     "400": {
@@ -541,41 +668,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/companies/{companyId}/events": {
-            "get": {
-                "description": "Возвращает список всех событий, принадлежащих конкретной компании.",
+        "/auth/refresh": {
+            "post": {
+                "description": "Выпускает новую пару токенов по токену обновления. Каждый токен обновления одноразовый,\nповторное использование отзывает всю сессию.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить все события компании",
-                "operationId": "get-company-events",
+                "summary": "Обновить токены сессии",
+                "operationId": "refresh-session",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Строка авторизации",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Уникальный идентификатор компании (в формате UUID)",
-                        "name": "companyId",
-                        "in": "path",
-                        "required": true
+                        "description": "Токен обновления",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Event"
-                            }
+                            "$ref": "#/definitions/models.SessionTokens"
                         }
                     },
                     "400": {
@@ -584,6 +703,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -593,17 +718,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/events": {
+        "/auth/sessions": {
             "get": {
-                "description": "Возвращает список всех событий в системе.",
+                "description": "Возвращает действующие сессии текущего пользователя.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить все события",
-                "operationId": "get-all-events",
+                "summary": "Получить активные сессии",
+                "operationId": "get-sessions",
                 "parameters": [
                     {
                         "type": "string",
@@ -619,7 +744,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Event"
+                                "$ref": "#/definitions/models.Session"
                             }
                         }
                     },
@@ -632,119 +757,134 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создает новое событие с указанными параметрами.",
+                "description": "Обменивает параметры запуска VK на пару токенов доступа и обновления для веб-клиентов.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Создать новое событие",
-                "operationId": "create-event",
+                "summary": "Создать сессию",
+                "operationId": "new-session",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Строка авторизации",
+                        "description": "Параметры запуска VK",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Уникальный идентификатор компании (в формате UUID)",
-                        "name": "company_id",
-                        "in": "body",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.SessionTokens"
                         }
                     },
-                    {
-                        "description": "Название события (минимум 6 символов)",
-                        "name": "name",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    {
-                        "description": "Описание события (минимум 10 символов)",
-                        "name": "description",
-                        "in": "body",
-                        "required": true,
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    {
-                        "description": "Массив ссылок на изображения для карусели события",
-                        "name": "carousel",
-                        "in": "body",
-                        "required": true,
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/auth/sessions/{sessionId}": {
+            "delete": {
+                "description": "Отзывает сессию текущего пользователя. Выданные в ней токены перестают действовать сразу.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Отозвать сессию",
+                "operationId": "revoke-session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Массив тегов для события",
-                        "name": "tags",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    {
-                        "description": "Ссылка на иконку события (должна быть валидной URL)",
-                        "name": "icon",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Уникальный идентификатор сессии (в формате UUID)",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "boolean"
                         }
                     },
-                    {
-                        "description": "Дата и время начала события (в формате 2006-01-02T15:04:05Z07:00)",
-                        "name": "start_time",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    {
-                        "description": "Долгота местоположения события",
-                        "name": "address_lng",
-                        "in": "body",
-                        "required": true,
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "number"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    {
-                        "description": "Широта местоположения события",
-                        "name": "address_lat",
-                        "in": "body",
-                        "required": true,
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "number"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/blobs/{key}": {
+            "get": {
+                "description": "Отдает содержимое загруженного файла или его миниатюры. Авторизация не требуется.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "summary": "Получить загруженный файл",
+                "operationId": "get-blob",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ файла",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Event"
+                            "type": "file"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -758,17 +898,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/search/{query}": {
+        "/bookmarks": {
             "get": {
-                "description": "Ищет события по заданному запросу.",
+                "description": "Возвращает закладки текущего пользователя, сначала новые.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Поиск событий",
-                "operationId": "search-events",
+                "summary": "Получить закладки",
+                "operationId": "get-bookmarks",
                 "parameters": [
                     {
                         "type": "string",
@@ -776,13 +916,6 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Поисковый запрос (минимум 2 символа)",
-                        "name": "query",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -791,12 +924,12 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Event"
+                                "$ref": "#/definitions/models.Bookmark"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -808,19 +941,17 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/events/{eventId}": {
-            "get": {
-                "description": "Возвращает информацию о конкретном событии по его ID.",
+            },
+            "post": {
+                "description": "Добавляет место, событие или маршрут в закладки текущего пользователя. Повторное добавление не создает новую закладку.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить информацию о событии",
-                "operationId": "get-event",
+                "summary": "Добавить в закладки",
+                "operationId": "create-bookmark",
                 "parameters": [
                     {
                         "type": "string",
@@ -830,18 +961,29 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Уникальный идентификатор события (в формате UUID)",
-                        "name": "eventId",
-                        "in": "path",
-                        "required": true
+                        "description": "Тип объекта (place, event, route)",
+                        "name": "entity_type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Уникальный идентификатор объекта (в формате UUID)",
+                        "name": "entity_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Event"
+                            "$ref": "#/definitions/models.Bookmark"
                         }
                     },
                     "400": {
@@ -863,17 +1005,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Редактирует информацию о существующем событии.",
+            }
+        },
+        "/bookmarks/{entityType}/{entityId}": {
+            "delete": {
+                "description": "Удаляет место, событие или маршрут из закладок текущего пользователя.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Редактировать событие",
-                "operationId": "edit-event",
+                "summary": "Удалить из закладок",
+                "operationId": "delete-bookmark",
                 "parameters": [
                     {
                         "type": "string",
@@ -884,79 +1028,125 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор события (в формате UUID)",
-                        "name": "eventId",
+                        "description": "Тип объекта (place, event, route)",
+                        "name": "entityType",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Новое название события (минимум 6 символов)",
-                        "name": "name",
-                        "in": "body",
+                        "type": "string",
+                        "description": "Уникальный идентификатор объекта (в формате UUID)",
+                        "name": "entityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "boolean"
                         }
                     },
-                    {
-                        "description": "Новое описание события (минимум 10 символов)",
-                        "name": "description",
-                        "in": "body",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    {
-                        "description": "Новый массив ссылок на изображения для карусели события",
-                        "name": "carousel",
-                        "in": "body",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkins": {
+            "get": {
+                "description": "Возвращает отметки о посещении текущего пользователя, сначала новые.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить отметки о посещении",
+                "operationId": "get-checkins",
+                "parameters": [
                     {
-                        "description": "Новый массив тегов для события",
-                        "name": "tags",
-                        "in": "body",
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/models.Checkin"
                             }
                         }
                     },
-                    {
-                        "description": "Новая ссылка на иконку события (должна быть валидной URL)",
-                        "name": "icon",
-                        "in": "body",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    {
-                        "description": "Новая дата и время начала события (в формате 2006-01-02T15:04:05Z07:00)",
-                        "name": "start_time",
-                        "in": "body",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    }
+                }
+            },
+            "post": {
+                "description": "Сохраняет отметку о посещении объекта текущим пользователем.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Отметиться в месте, на событии или маршруте",
+                "operationId": "create-checkin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Новая долгота местоположения события",
-                        "name": "address_lng",
+                        "description": "Тип объекта (place, event, route)",
+                        "name": "entity_type",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "number"
+                            "type": "string"
                         }
                     },
                     {
-                        "description": "Новая широта местоположения события",
-                        "name": "address_lat",
+                        "description": "Уникальный идентификатор объекта (в формате UUID)",
+                        "name": "entity_id",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "number"
+                            "type": "string"
                         }
                     }
                 ],
@@ -964,7 +1154,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Event"
+                            "$ref": "#/definitions/models.Checkin"
                         }
                     },
                     "400": {
@@ -973,12 +1163,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -994,17 +1178,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{eventId}/reviews": {
+        "/claims": {
             "get": {
-                "description": "Возвращает список всех отзывов к указанному событию.",
+                "description": "Возвращает заявки компаний на владение местами для модерации.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить отзывы к событию",
-                "operationId": "get-event-reviews",
+                "summary": "Заявки на владение местами",
+                "operationId": "get-place-claims",
                 "parameters": [
                     {
                         "type": "string",
@@ -1015,17 +1199,19 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор события (в формате UUID)",
-                        "name": "eventId",
-                        "in": "path",
-                        "required": true
+                        "description": "Статус заявки: pending (по умолчанию), approved, rejected",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ReviewEvent"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PlaceClaim"
+                            }
                         }
                     },
                     "400": {
@@ -1041,17 +1227,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/claims/{claimId}/approve": {
             "post": {
-                "description": "Создает новый отзыв к указанному событию.",
+                "description": "Передает место компании из заявки. Остальные ожидающие заявки на это место отклоняются.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Добавить новый отзыв к событию",
-                "operationId": "create-event-review",
+                "summary": "Одобрить заявку на владение местом",
+                "operationId": "approve-place-claim",
                 "parameters": [
                     {
                         "type": "string",
@@ -1062,35 +1250,25 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор события (в формате UUID)",
-                        "name": "eventId",
+                        "description": "Уникальный идентификатор заявки (в формате UUID)",
+                        "name": "claimId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Текст отзыва (минимум 6 символов)",
-                        "name": "review_text",
+                        "description": "Комментарий модератора",
+                        "name": "comment",
                         "in": "body",
-                        "required": true,
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Оценка события (от 1 до 5)",
-                        "name": "stars",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "number"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ReviewEvent"
+                            "$ref": "#/definitions/models.PlaceClaim"
                         }
                     },
                     "400": {
@@ -1099,14 +1277,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1124,17 +1296,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Редактирует существующий отзыв к событию.",
+            }
+        },
+        "/claims/{claimId}/reject": {
+            "post": {
+                "description": "Отклоняет заявку компании на владение местом.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Редактировать отзыв к событию",
-                "operationId": "edit-event-review",
+                "summary": "Отклонить заявку на владение местом",
+                "operationId": "reject-place-claim",
                 "parameters": [
                     {
                         "type": "string",
@@ -1145,33 +1319,25 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор события (в формате UUID)",
-                        "name": "eventId",
+                        "description": "Уникальный идентификатор заявки (в формате UUID)",
+                        "name": "claimId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Новый текст отзыва (минимум 6 символов)",
-                        "name": "review_text",
+                        "description": "Причина отказа",
+                        "name": "comment",
                         "in": "body",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    {
-                        "description": "Новая оценка события (от 1 до 5)",
-                        "name": "stars",
-                        "in": "body",
-                        "schema": {
-                            "type": "number"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ReviewEvent"
+                            "$ref": "#/definitions/models.PlaceClaim"
                         }
                     },
                     "400": {
@@ -1180,14 +1346,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1201,17 +1367,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/places": {
+        "/companies": {
             "get": {
-                "description": "Возвращает список всех мест.",
+                "description": "Возвращает список всех компаний в системе.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить все места",
-                "operationId": "get-all-places",
+                "summary": "Получить все компании",
+                "operationId": "get-all-companies",
                 "parameters": [
                     {
                         "type": "string",
@@ -1219,6 +1385,12 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1227,7 +1399,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Place"
+                                "$ref": "#/definitions/models.Company"
                             }
                         }
                     },
@@ -1240,15 +1412,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создает новое место.",
+                "description": "Создает новую компанию с указанными параметрами.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Добавить новое место",
-                "operationId": "create-place",
+                "summary": "Создать новую компанию",
+                "operationId": "create-company",
                 "parameters": [
                     {
                         "type": "string",
@@ -1258,7 +1430,7 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Название места",
+                        "description": "Название компании (минимум 6 символов)",
                         "name": "name",
                         "in": "body",
                         "required": true,
@@ -1267,7 +1439,7 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Описание места",
+                        "description": "Описание компании (минимум 12 символов)",
                         "name": "description",
                         "in": "body",
                         "required": true,
@@ -1276,33 +1448,12 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Список изображений для карусели",
-                        "name": "carousel",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    {
-                        "description": "Долгота местоположения",
-                        "name": "address_lng",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "number"
-                        }
-                    },
-                    {
-                        "description": "Широта местоположения",
-                        "name": "address_lat",
+                        "description": "Ссылка на фото компании (валидный URL или идентификатор загруженного изображения)",
+                        "name": "photo_card",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "number"
+                            "type": "string"
                         }
                     }
                 ],
@@ -1310,7 +1461,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Place"
+                            "$ref": "#/definitions/models.Company"
                         }
                     },
                     "400": {
@@ -1319,6 +1470,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1328,17 +1485,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/places/{placeID}": {
+        "/companies/my": {
             "get": {
-                "description": "Возвращает информацию о указанном месте.",
+                "description": "Возвращает список компаний, связанных с текущим пользователем.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить место",
-                "operationId": "get-place",
+                "summary": "Получить мои компании",
+                "operationId": "get-my-companies",
                 "parameters": [
                     {
                         "type": "string",
@@ -1349,23 +1506,19 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор места (в формате UUID)",
-                        "name": "placeId",
-                        "in": "path",
-                        "required": true
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Place"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Company"
+                            }
                         }
                     },
                     "500": {
@@ -1375,17 +1528,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Редактирует существующее место.",
+            }
+        },
+        "/companies/{companyId}": {
+            "get": {
+                "description": "Возвращает информацию о конкретной компании по её ID.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Редактировать место",
-                "operationId": "edit-place",
+                "summary": "Получить информацию о компании",
+                "operationId": "get-company",
                 "parameters": [
                     {
                         "type": "string",
@@ -1396,8 +1551,14 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор места (в формате UUID)",
-                        "name": "placeId",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "companyId",
                         "in": "path",
                         "required": true
                     }
@@ -1406,7 +1567,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Place"
+                            "$ref": "#/definitions/models.Company"
                         }
                     },
                     "400": {
@@ -1415,6 +1576,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1422,19 +1589,17 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/places/{placeID}/reviews": {
-            "post": {
-                "description": "Создает новый отзыв о указанном месте.",
+            },
+            "patch": {
+                "description": "Редактирует название, описание и фото компании. Правки пользователей, кроме владельца компании\nи администраторов, сохраняются как предложенное изменение, в этом случае возвращается код 202.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Добавить новый отзыв о месте",
-                "operationId": "create-place-review",
+                "summary": "Редактировать компанию",
+                "operationId": "edit-company",
                 "parameters": [
                     {
                         "type": "string",
@@ -1445,27 +1610,49 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор места (в формате UUID)",
-                        "name": "placeId",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "companyId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Текст отзыва (минимум 6 символов)",
-                        "name": "review_text",
+                        "description": "Новое название компании (минимум 6 символов)",
+                        "name": "name",
                         "in": "body",
-                        "required": true,
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Оценка места (от 1 до 5)",
-                        "name": "stars",
+                        "description": "Новое описание компании (минимум 12 символов)",
+                        "name": "description",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "type": "number"
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык",
+                        "name": "translations",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translations"
+                        }
+                    },
+                    {
+                        "description": "Новое фото компании (валидный URL или идентификатор загруженного изображения)",
+                        "name": "photo_card",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Пояснение к предложенному изменению",
+                        "name": "comment",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 ],
@@ -1473,7 +1660,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ReviewPlace"
+                            "$ref": "#/definitions/models.Company"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditSuggestion"
                         }
                     },
                     "400": {
@@ -1482,6 +1675,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1491,17 +1696,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/places/{placeId}/reviews": {
-            "get": {
-                "description": "Возвращает список всех отзывов о указанном месте.",
+        "/companies/{companyId}/accept": {
+            "post": {
+                "description": "Подтверждает компанию администратором или модератором и выдает ее владельцу роль company_member.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить отзывы о месте",
-                "operationId": "get-place-reviews",
+                "summary": "Принять компанию",
+                "operationId": "accept-company",
                 "parameters": [
                     {
                         "type": "string",
@@ -1512,8 +1717,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор места (в формате UUID)",
-                        "name": "placeId",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "companyId",
                         "in": "path",
                         "required": true
                     }
@@ -1522,10 +1727,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ReviewPlace"
-                            }
+                            "$ref": "#/definitions/models.Company"
                         }
                     },
                     "400": {
@@ -1534,6 +1736,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1543,17 +1757,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/routes": {
+        "/companies/{companyId}/claims": {
             "get": {
-                "description": "Возвращает список всех маршрутов.",
+                "description": "Возвращает заявки компании на владение местами. Доступно только владельцу компании.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить все маршруты",
-                "operationId": "get-all-routes",
+                "summary": "Заявки компании на владение местами",
+                "operationId": "get-company-place-claims",
                 "parameters": [
                     {
                         "type": "string",
@@ -1561,6 +1775,19 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "companyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус заявки: pending, approved, rejected (по умолчанию все)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1569,10 +1796,28 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.RouteWithGeo"
+                                "$ref": "#/definitions/models.PlaceClaim"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1580,17 +1825,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Создает новый маршрут с указанными параметрами.",
-                "consumes": [
+            }
+        },
+        "/companies/{companyId}/events": {
+            "get": {
+                "description": "Возвращает список всех событий, принадлежащих конкретной компании.",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Создать новый маршрут",
-                "operationId": "new-route",
+                "summary": "Получить все события компании",
+                "operationId": "get-company-events",
                 "parameters": [
                     {
                         "type": "string",
@@ -1600,53 +1847,45 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Уникальный идентификатор компании (опционально)",
-                        "name": "company_id",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
                     },
                     {
-                        "description": "Название маршрута (минимум 6 символов)",
-                        "name": "name",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "companyId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Описание маршрута (минимум 10 символов)",
-                        "name": "description",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Список мест (опционально)",
-                        "name": "places",
-                        "in": "body",
-                        "schema": {
-                            "type": "array"
-                        }
+                        "type": "string",
+                        "description": "Тег (UUID или slug), для категории учитываются и ее дочерние теги",
+                        "name": "tag",
+                        "in": "query"
                     },
                     {
-                        "description": "Список событий (опционально)",
-                        "name": "events",
-                        "in": "body",
-                        "schema": {
-                            "type": "array"
-                        }
+                        "type": "string",
+                        "description": "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RouteWithGeo"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Event"
+                            }
                         }
                     },
                     "400": {
@@ -1655,12 +1894,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1670,17 +1903,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/routes/company/{companyId}": {
+        "/companies/{companyId}/members": {
             "get": {
-                "description": "Возвращает список маршрутов для указанной компании.",
+                "description": "Возвращает сотрудников компании, которые могут отвечать на отзывы от ее имени. Доступно только владельцу компании.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить маршруты компании",
-                "operationId": "get-company-routes",
+                "summary": "Получить сотрудников компании",
+                "operationId": "get-company-members",
                 "parameters": [
                     {
                         "type": "string",
@@ -1691,7 +1924,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор компании",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
                         "name": "companyId",
                         "in": "path",
                         "required": true
@@ -1703,7 +1936,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.RouteWithGeo"
+                                "$ref": "#/definitions/models.CompanyMember"
                             }
                         }
                     },
@@ -1713,6 +1946,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1726,19 +1965,17 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/routes/search/{query}": {
-            "get": {
-                "description": "Ищет маршруты по заданному запросу.",
+            },
+            "post": {
+                "description": "Добавляет пользователя по VK ID в сотрудники компании и выдает ему роль company_member. Доступно только владельцу компании.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Поиск маршрутов",
-                "operationId": "search-routes",
+                "summary": "Добавить сотрудника компании",
+                "operationId": "add-company-member",
                 "parameters": [
                     {
                         "type": "string",
@@ -1749,20 +1986,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Запрос для поиска маршрутов (минимум 2 символа)",
-                        "name": "query",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "companyId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "VK ID пользователя",
+                        "name": "vk_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.RouteWithGeo"
-                            }
+                            "type": "boolean"
                         }
                     },
                     "400": {
@@ -1771,6 +2014,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1780,17 +2041,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/routes/{routeId}": {
-            "get": {
-                "description": "Возвращает информацию о маршруте по его уникальному идентификатору.",
+        "/companies/{companyId}/members/{vkId}": {
+            "delete": {
+                "description": "Удаляет пользователя из сотрудников компании. Роль company_member отзывается, если пользователь больше не состоит ни в одной компании. Доступно только владельцу компании.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить информацию о маршруте",
-                "operationId": "get-route",
+                "summary": "Удалить сотрудника компании",
+                "operationId": "remove-company-member",
                 "parameters": [
                     {
                         "type": "string",
@@ -1801,8 +2062,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор маршрута",
-                        "name": "routeId",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "companyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "VK ID пользователя",
+                        "name": "vkId",
                         "in": "path",
                         "required": true
                     }
@@ -1811,7 +2079,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RouteWithGeo"
+                            "type": "boolean"
                         }
                     },
                     "400": {
@@ -1820,6 +2088,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1833,17 +2107,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Редактирует существующий маршрут с указанными параметрами.",
+            }
+        },
+        "/companies/{companyId}/suggestions": {
+            "get": {
+                "description": "Возвращает изменения, предложенные для компании и принадлежащих ей мест, событий и маршрутов.\nДоступно только владельцу компании.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Редактировать маршрут",
-                "operationId": "edit-route",
+                "summary": "Предложенные изменения компании",
+                "operationId": "get-company-edit-suggestions",
                 "parameters": [
                     {
                         "type": "string",
@@ -1854,49 +2130,32 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор маршрута",
-                        "name": "routeId",
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "companyId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Название маршрута (минимум 6 символов, опционально)",
-                        "name": "name",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Описание маршрута (минимум 10 символов, опционально)",
-                        "name": "description",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Список мест (опционально)",
-                        "name": "places",
-                        "in": "body",
-                        "schema": {
-                            "type": "array"
-                        }
+                        "type": "string",
+                        "description": "Статус: pending (по умолчанию), accepted, rejected",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "description": "Список событий (опционально)",
-                        "name": "events",
-                        "in": "body",
-                        "schema": {
-                            "type": "array"
-                        }
+                        "type": "string",
+                        "description": "Тип сущности: place, event, route, company",
+                        "name": "entity_type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RouteWithGeo"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EditSuggestion"
+                            }
                         }
                     },
                     "400": {
@@ -1926,17 +2185,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/routes/{routeId}/reviews": {
+        "/events": {
             "get": {
-                "description": "Возвращает список отзывов о маршруте по его уникальному идентификатору.",
+                "description": "Возвращает список всех событий в системе.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить отзывы о маршруте",
-                "operationId": "get-reviews-route",
+                "summary": "Получить все события",
+                "operationId": "get-all-events",
                 "parameters": [
                     {
                         "type": "string",
@@ -1947,21 +2206,47 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор маршрута",
-                        "name": "routeId",
-                        "in": "path",
-                        "required": true
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удаленные записи (только для администраторов)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тег (UUID или slug), для категории учитываются и ее дочерние теги",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ReviewRoute"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Event"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1975,15 +2260,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Добавляет новый отзыв о маршруте с указанными параметрами.",
+                "description": "Создает новое событие с указанными параметрами.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Добавить отзыв о маршруте",
-                "operationId": "new-review-route",
+                "summary": "Создать новое событие",
+                "operationId": "create-event",
                 "parameters": [
                     {
                         "type": "string",
@@ -1993,15 +2278,16 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Уникальный идентификатор маршрута",
-                        "name": "routeId",
-                        "in": "path",
-                        "required": true
+                        "description": "Уникальный идентификатор компании (в формате UUID)",
+                        "name": "company_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     {
-                        "description": "Текст отзыва (минимум 6 символов)",
-                        "name": "review_text",
+                        "description": "Название события (минимум 6 символов)",
+                        "name": "name",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -2009,36 +2295,101 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Оценка (от 1 до 5)",
-                        "name": "stars",
+                        "description": "Описание события (минимум 10 символов)",
+                        "name": "description",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "number"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.ReviewRoute"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    {
+                        "description": "Массив ссылок или идентификаторов загруженных изображений для карусели события",
+                        "name": "carousel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Теги события из справочника (в формате UUID)",
+                        "name": "tag_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Ссылка на иконку события (валидный URL или идентификатор загруженного изображения)",
+                        "name": "icon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Дата и время начала события (в формате 2006-01-02T15:04:05Z07:00)",
+                        "name": "start_time",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Текст адреса события, используется, если не переданы координаты",
+                        "name": "address",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Долгота местоположения события (обязательна без address)",
+                        "name": "address_lng",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Широта местоположения события (обязательна без address)",
+                        "name": "address_lat",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -2048,19 +2399,27 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "patch": {
-                "description": "Редактирует существующий отзыв о маршруте с указанными параметрами.",
+            }
+        },
+        "/events/nearby": {
+            "get": {
+                "description": "Возвращает предстоящие события в радиусе от точки, ближайшие первыми. Без координат используется\nвыбранная пользователем точка, центр его домашнего города или местоположение по IP.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Редактировать отзыв о маршруте",
-                "operationId": "edit-review-route",
+                "summary": "События рядом",
+                "operationId": "get-events-nearby",
                 "parameters": [
                     {
                         "type": "string",
@@ -2071,33 +2430,49 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор маршрута",
-                        "name": "routeId",
-                        "in": "path",
-                        "required": true
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
                     },
                     {
-                        "description": "Текст отзыва (минимум 6 символов, опционально)",
-                        "name": "review_text",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "number",
+                        "description": "Широта центра поиска",
+                        "name": "lat",
+                        "in": "query"
                     },
                     {
-                        "description": "Оценка (от 1 до 5, опционально)",
-                        "name": "stars",
-                        "in": "body",
-                        "schema": {
-                            "type": "number"
-                        }
+                        "type": "number",
+                        "description": "Долгота центра поиска",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Радиус поиска в километрах (по умолчанию 10, максимум 200)",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество событий (по умолчанию 50, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ReviewRoute"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Event"
+                            }
                         }
                     },
                     "400": {
@@ -2106,12 +2481,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2121,17 +2490,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/events/search/{query}": {
             "get": {
-                "description": "Возвращает информацию о текущем пользователе по его VK ID.",
+                "description": "Ищет события по заданному запросу.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить информацию о текущем пользователе",
-                "operationId": "get-me",
+                "summary": "Поиск событий",
+                "operationId": "search-events",
                 "parameters": [
                     {
                         "type": "string",
@@ -2139,13 +2508,47 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос (минимум 2 символа)",
+                        "name": "query",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тег (UUID или slug), для категории учитываются и ее дочерние теги",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserGetMeResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Event"
+                            }
                         }
                     },
                     "400": {
@@ -2161,17 +2564,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Редактирует профиль текущего пользователя.",
+            }
+        },
+        "/events/{eventId}": {
+            "get": {
+                "description": "Возвращает информацию о конкретном событии по его ID.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Редактировать профиль пользователя",
-                "operationId": "edit-user",
+                "summary": "Получить информацию о событии",
+                "operationId": "get-event",
                 "parameters": [
                     {
                         "type": "string",
@@ -2181,35 +2586,30 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Пройдено обучение (опционально)",
-                        "name": "passed_onboarding",
-                        "in": "body",
-                        "schema": {
-                            "type": "boolean"
-                        }
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
                     },
                     {
-                        "description": "Широта выбранного местоположения",
-                        "name": "selected_geo_lat",
-                        "in": "body",
-                        "schema": {
-                            "type": "number"
-                        }
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Долгота выбранного местоположения",
-                        "name": "selected_geo_lot",
-                        "in": "body",
-                        "schema": {
-                            "type": "number"
-                        }
+                        "type": "boolean",
+                        "description": "Включить удаленные записи (только для администраторов)",
+                        "name": "include_deleted",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Event"
                         }
                     },
                     "400": {
@@ -2218,6 +2618,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2225,19 +2637,17 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/{vkId}": {
-            "get": {
-                "description": "Возвращает информацию о пользователе по его VK ID.",
+            },
+            "delete": {
+                "description": "Помечает событие удаленным. Доступно администраторам и владельцу компании, которой принадлежит событие.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Получить пользователя по VK ID",
-                "operationId": "get-user-by-vk-id",
+                "summary": "Удалить событие",
+                "operationId": "delete-event",
                 "parameters": [
                     {
                         "type": "string",
@@ -2248,8 +2658,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор пользователя в VK",
-                        "name": "vkId",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     }
@@ -2258,7 +2668,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "boolean"
                         }
                     },
                     "400": {
@@ -2267,6 +2677,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2274,46 +2702,6788 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "models.Achievements": {
-            "type": "object",
-            "properties": {
-                "_id": {
-                    "type": "string"
-                },
-                "coins": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "icon": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.Company": {
-            "type": "object",
-            "properties": {
-                "_id": {
-                    "type": "string"
-                },
+            },
+            "patch": {
+                "description": "Редактирует информацию о существующем событии. Правки пользователей, которые не могут редактировать\nсобытие, сохраняются как предложенное изменение, в этом случае возвращается код 202.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать событие",
+                "operationId": "edit-event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новое название события (минимум 6 символов)",
+                        "name": "name",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Новое описание события (минимум 10 символов)",
+                        "name": "description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык",
+                        "name": "translations",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translations"
+                        }
+                    },
+                    {
+                        "description": "Новый массив ссылок или идентификаторов загруженных изображений для карусели события",
+                        "name": "carousel",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Новые теги события из справочника (в формате UUID)",
+                        "name": "tag_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Новая ссылка на иконку события (валидный URL или идентификатор загруженного изображения)",
+                        "name": "icon",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Новая дата и время начала события (в формате 2006-01-02T15:04:05Z07:00)",
+                        "name": "start_time",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Новый текст адреса события, используется, если не переданы координаты",
+                        "name": "address",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Новая долгота местоположения события",
+                        "name": "address_lng",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Новая широта местоположения события",
+                        "name": "address_lat",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Пояснение к предложенному изменению",
+                        "name": "comment",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/restore": {
+            "post": {
+                "description": "Восстанавливает удаленное событие. Доступно администраторам и владельцу компании, которой принадлежит событие.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Восстановить событие",
+                "operationId": "restore-event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/reviews": {
+            "get": {
+                "description": "Возвращает список всех отзывов к указанному событию.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить отзывы к событию",
+                "operationId": "get-event-reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: recent (по умолчанию), helpful или stars",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReviewWithMeta"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает новый отзыв к указанному событию.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавить новый отзыв к событию",
+                "operationId": "create-event-review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст отзыва (минимум 6 символов)",
+                        "name": "review_text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Оценка события (от 1 до 5)",
+                        "name": "stars",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Идентификаторы загруженных фотографий (опционально)",
+                        "name": "photos",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует существующий отзыв к событию.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать отзыв к событию",
+                "operationId": "edit-event-review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый текст отзыва (минимум 6 символов)",
+                        "name": "review_text",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Новая оценка события (от 1 до 5)",
+                        "name": "stars",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/reviews/{reviewId}/helpful": {
+            "post": {
+                "description": "Добавляет отметку \"полезно\" от текущего пользователя к отзыву о событии.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Отметить отзыв о событии полезным",
+                "operationId": "vote-review-event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewVote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет отметку \"полезно\" текущего пользователя с отзыва о событии.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Снять отметку \"полезно\" с отзыва о событии",
+                "operationId": "unvote-review-event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/reviews/{reviewId}/reply": {
+            "post": {
+                "description": "Создает официальный ответ компании-владельца на отзыв о событии. На каждый отзыв возможен только один ответ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Ответить на отзыв о событии",
+                "operationId": "new-review-event-reply",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст ответа (минимум 2 символа)",
+                        "name": "reply_text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует официальный ответ компании-владельца на отзыв о событии.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать ответ на отзыв о событии",
+                "operationId": "edit-review-event-reply",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст ответа (минимум 2 символа)",
+                        "name": "reply_text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/rsvp": {
+            "post": {
+                "description": "Отмечает, что текущий пользователь пойдет на событие. Повторная отметка ничего не меняет.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Пойду на событие",
+                "operationId": "create-rsvp-event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Снимает отметку текущего пользователя о том, что он пойдет на событие.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Не пойду на событие",
+                "operationId": "delete-rsvp-event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор события (в формате UUID)",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/geo/suggest": {
+            "get": {
+                "description": "Возвращает варианты адреса для текстового запроса со структурированными компонентами и координатами.\nВыбранный вариант можно передать в address при создании места или события.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Подсказки адреса",
+                "operationId": "suggest-address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст адреса (минимум 3 символа)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество вариантов (по умолчанию 5, максимум 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GeoCandidate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/history/{entityType}/{entityId}": {
+            "get": {
+                "description": "Возвращает версии места, события, маршрута или компании, новые первыми.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "История изменений",
+                "operationId": "get-entity-history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип сущности: place, event, route, company",
+                        "name": "entityType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор сущности (в формате UUID)",
+                        "name": "entityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EntityVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/history/{entityType}/{entityId}/diff": {
+            "get": {
+                "description": "Возвращает поля, изменившиеся между двумя версиями сущности. По умолчанию последняя версия\nсравнивается с предыдущей.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Сравнение версий",
+                "operationId": "get-entity-history-diff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип сущности: place, event, route, company",
+                        "name": "entityType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор сущности (в формате UUID)",
+                        "name": "entityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер исходной версии, по умолчанию предыдущая перед to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер конечной версии, по умолчанию последняя",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VersionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/history/{entityType}/{entityId}/revert": {
+            "post": {
+                "description": "Восстанавливает поля сущности из указанной версии. Откат сохраняется в истории как новая версия,\nвладелец, статус удаления и рейтинг сущности не меняются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Откатить сущность к версии",
+                "operationId": "revert-entity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип сущности: place, event, route, company",
+                        "name": "entityType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор сущности (в формате UUID)",
+                        "name": "entityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Номер версии, к которой откатывается сущность",
+                        "name": "version",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сущность после отката",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/localities": {
+            "get": {
+                "description": "Возвращает города и регионы, к которым привязываются места и события.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить справочник городов и регионов",
+                "operationId": "get-localities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип записи: city или region (по умолчанию все)",
+                        "name": "kind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Locality"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Добавляет город или регион в справочник. Граница задается многоугольником boundary или кругом\nрадиусом radius_km вокруг center. После добавления места и события заново привязываются к городам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавить город или регион",
+                "operationId": "create-locality",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Тип записи: city или region",
+                        "name": "kind",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Название",
+                        "name": "name",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Регион",
+                        "name": "region",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Страна",
+                        "name": "country",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Регион, в который входит город (в формате UUID)",
+                        "name": "parent_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Центр",
+                        "name": "center",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    },
+                    {
+                        "description": "Радиус в километрах, если граница не задана",
+                        "name": "radius_km",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Граница (минимум 3 точки)",
+                        "name": "boundary",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GeoPoint"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Locality"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/localities/{localityId}": {
+            "get": {
+                "description": "Возвращает город или регион из справочника по его ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить город или регион",
+                "operationId": "get-locality",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор города или региона (в формате UUID)",
+                        "name": "localityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Locality"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует запись справочника. При изменении границ места и события заново привязываются к городам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать город или регион",
+                "operationId": "edit-locality",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор города или региона (в формате UUID)",
+                        "name": "localityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Название",
+                        "name": "name",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Регион",
+                        "name": "region",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Страна",
+                        "name": "country",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Регион, в который входит город (в формате UUID)",
+                        "name": "parent_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Центр",
+                        "name": "center",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    },
+                    {
+                        "description": "Радиус в километрах, если граница не задана",
+                        "name": "radius_km",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Граница (минимум 3 точки, пустой массив удаляет границу)",
+                        "name": "boundary",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GeoPoint"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Locality"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/localities/{localityId}/landing": {
+            "get": {
+                "description": "Возвращает подборку для главной страницы города или региона: лучшие места, ближайшие события\nи лучшие маршруты, проходящие через него.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Главная страница города",
+                "operationId": "get-locality-landing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор города или региона (в формате UUID)",
+                        "name": "localityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LocalityLanding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places": {
+            "get": {
+                "description": "Возвращает список всех мест.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить все места",
+                "operationId": "get-all-places",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удаленные записи (только для администраторов)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тег (UUID или slug), для категории учитываются и ее дочерние теги",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только места, открытые сейчас",
+                        "name": "open_now",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Place"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает новое место от имени компании пользователя или, для администраторов, без владельца.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавить новое место",
+                "operationId": "create-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Компания-владелец места (в формате UUID), без нее место может добавить только администратор",
+                        "name": "company_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Название места",
+                        "name": "name",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Описание места",
+                        "name": "description",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Список изображений для карусели (ссылки или идентификаторы загруженных изображений)",
+                        "name": "carousel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Теги места из справочника (в формате UUID)",
+                        "name": "tag_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Часовой пояс места (по умолчанию Europe/Moscow)",
+                        "name": "timezone",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Часы работы по дням недели",
+                        "name": "opening_hours",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.WeeklyHours"
+                        }
+                    },
+                    {
+                        "description": "Часы работы в праздники и другие особые даты",
+                        "name": "hours_exceptions",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.HoursException"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Минимальная цена в рублях",
+                        "name": "price_min",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Максимальная цена в рублях",
+                        "name": "price_max",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Доступность: wheelchair, step_free, accessible_toilet, hearing_loop, guide_dogs",
+                        "name": "accessibility",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Удобства: wifi, parking, toilet, cafe, cloakroom, card_payment, child_friendly, pet_friendly",
+                        "name": "amenities",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Текст адреса, используется, если не переданы координаты",
+                        "name": "address",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Долгота местоположения (обязательна без address)",
+                        "name": "address_lng",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Широта местоположения (обязательна без address)",
+                        "name": "address_lat",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Place"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/duplicates": {
+            "get": {
+                "description": "Возвращает пары мест с похожими названиями, расположенные рядом друг с другом. Пары, отмеченные\nкак не дубли, не возвращаются. Сначала идут пары с наибольшей оценкой.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Вероятные дубли мест",
+                "operationId": "get-place-duplicates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальное сходство названий от 0.3 до 1 (по умолчанию 0.5)",
+                        "name": "min_similarity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное расстояние между местами в метрах, до 5000 (по умолчанию 300)",
+                        "name": "max_distance_m",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID)",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество пар, до 100 (по умолчанию 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PlaceDuplicate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/duplicates/dismiss": {
+            "post": {
+                "description": "Убирает пару мест из списка вероятных дублей.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Отметить места как не дубли",
+                "operationId": "dismiss-place-duplicate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Первое место (в формате UUID)",
+                        "name": "place_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Второе место (в формате UUID)",
+                        "name": "duplicate_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/nearby": {
+            "get": {
+                "description": "Возвращает места в радиусе от точки, ближайшие первыми. Без координат используется\nвыбранная пользователем точка, центр его домашнего города или местоположение по IP.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Места рядом",
+                "operationId": "get-places-nearby",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Широта центра поиска",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Долгота центра поиска",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Радиус поиска в километрах (по умолчанию 10, максимум 200)",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество мест (по умолчанию 50, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Place"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/search/{query}": {
+            "get": {
+                "description": "Ищет места по заданному запросу.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Поиск мест",
+                "operationId": "search-places",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Запрос для поиска мест (минимум 2 символа)",
+                        "name": "query",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тег (UUID или slug), для категории учитываются и ее дочерние теги",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только места, открытые сейчас",
+                        "name": "open_now",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Place"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/{placeID}": {
+            "patch": {
+                "description": "Редактирует существующее место. Место компании может редактировать только она сама или администратор.\nПравки остальных пользователей сохраняются как предложенное изменение и применяются после одобрения\nвладельцем или модератором, в этом случае возвращается код 202.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать место",
+                "operationId": "edit-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новое название места",
+                        "name": "name",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Новое описание места",
+                        "name": "description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык",
+                        "name": "translations",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translations"
+                        }
+                    },
+                    {
+                        "description": "Новый список изображений для карусели (ссылки или идентификаторы загруженных изображений)",
+                        "name": "carousel",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Новые теги места из справочника (в формате UUID)",
+                        "name": "tag_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Часовой пояс места (по умолчанию Europe/Moscow)",
+                        "name": "timezone",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Часы работы по дням недели",
+                        "name": "opening_hours",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.WeeklyHours"
+                        }
+                    },
+                    {
+                        "description": "Часы работы в праздники и другие особые даты",
+                        "name": "hours_exceptions",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.HoursException"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Минимальная цена в рублях",
+                        "name": "price_min",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Максимальная цена в рублях",
+                        "name": "price_max",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Доступность: wheelchair, step_free, accessible_toilet, hearing_loop, guide_dogs",
+                        "name": "accessibility",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Удобства: wifi, parking, toilet, cafe, cloakroom, card_payment, child_friendly, pet_friendly",
+                        "name": "amenities",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Новый текст адреса, используется, если не переданы координаты",
+                        "name": "address",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Новая долгота местоположения",
+                        "name": "address_lng",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Новая широта местоположения",
+                        "name": "address_lat",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Пояснение к предложенному изменению",
+                        "name": "comment",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Place"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/{placeId}": {
+            "get": {
+                "description": "Возвращает информацию о указанном месте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить место",
+                "operationId": "get-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удаленные записи (только для администраторов)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Place"
+                        }
+                    },
+                    "301": {
+                        "description": "Место объединено с другим, Location указывает на него",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Помечает место удаленным. Доступно только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удалить место",
+                "operationId": "delete-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/{placeId}/claims": {
+            "post": {
+                "description": "Создает заявку компании пользователя на владение местом. После одобрения модератором\nредактировать место сможет только компания-владелец.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Заявить права на место",
+                "operationId": "new-place-claim",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Компания пользователя (в формате UUID)",
+                        "name": "company_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Подтверждение права владения",
+                        "name": "evidence",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Ссылки на подтверждающие документы",
+                        "name": "attachments",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlaceClaim"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/{placeId}/hours": {
+            "get": {
+                "description": "Возвращает расписание места по дням с учетом праздничных исключений. Даты и время указаны\nв часовом поясе места. По умолчанию возвращается неделя начиная с сегодняшнего дня.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Часы работы места",
+                "operationId": "get-place-hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Первый день (в формате 2006-01-02)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день (в формате 2006-01-02), не более 62 дней от первого",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PlaceHours"
+                        }
+                    },
+                    "301": {
+                        "description": "Место объединено с другим, Location указывает на него",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/{placeId}/merge": {
+            "post": {
+                "description": "Переносит отзывы, места в маршрутах, закладки, посещения и просмотры дубля на место из пути.\nДубль удаляется, запросы к нему перенаправляются на оставшееся место. Если у пользователя\nесть отзыв или закладка у обоих мест, сохраняется отзыв или закладка оставшегося места.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Объединить дубль с местом",
+                "operationId": "merge-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Место, которое остается (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Дубль, который объединяется с местом (в формате UUID)",
+                        "name": "duplicate_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Place"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/{placeId}/restore": {
+            "post": {
+                "description": "Восстанавливает удаленное место. Доступно только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Восстановить место",
+                "operationId": "restore-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/{placeId}/reviews": {
+            "get": {
+                "description": "Возвращает список всех отзывов о указанном месте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить отзывы о месте",
+                "operationId": "get-place-reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: recent (по умолчанию), helpful или stars",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReviewWithMeta"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает новый отзыв о указанном месте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавить новый отзыв о месте",
+                "operationId": "create-place-review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст отзыва (минимум 6 символов)",
+                        "name": "review_text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Оценка места (от 1 до 5)",
+                        "name": "stars",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Идентификаторы загруженных фотографий (опционально)",
+                        "name": "photos",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует существующий отзыв о месте с указанными параметрами.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать отзыв о месте",
+                "operationId": "edit-review-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст отзыва (минимум 6 символов, опционально)",
+                        "name": "review_text",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Оценка (от 1 до 5, опционально)",
+                        "name": "stars",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/places/{placeId}/reviews/{reviewId}/helpful": {
+            "post": {
+                "description": "Добавляет отметку \"полезно\" от текущего пользователя к отзыву о месте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Отметить отзыв о месте полезным",
+                "operationId": "vote-review-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewVote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет отметку \"полезно\" текущего пользователя с отзыва о месте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Снять отметку \"полезно\" с отзыва о месте",
+                "operationId": "unvote-review-place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор места (в формате UUID)",
+                        "name": "placeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recommendations": {
+            "get": {
+                "description": "Возвращает места, события и маршруты, подобранные по местоположению пользователя, тегам объектов из его закладок,\nпосещений и отзывов, рейтингу и актуальности. Объекты, с которыми пользователь уже взаимодействовал, не рекомендуются.\nПохожие объекты разносятся по выдаче, у каждой рекомендации есть объяснение и список причин.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить рекомендации",
+                "operationId": "get-recommendations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Рекомендовать только объекты города или региона (в формате UUID)",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество рекомендаций (по умолчанию 20, максимум 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Recommendation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{reviewId}": {
+            "delete": {
+                "description": "Скрывает отзыв о месте, событии или маршруте и пересчитывает рейтинг. Доступно модераторам и администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удалить отзыв",
+                "operationId": "delete-review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "description": "Возвращает все роли и их разрешения. Доступно только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить список ролей",
+                "operationId": "get-roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RoleInfo"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/audit": {
+            "get": {
+                "description": "Возвращает историю выдачи и отзыва ролей, начиная с последних изменений. Доступно только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить журнал изменения ролей",
+                "operationId": "get-roles-audit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "VK ID пользователя, по которому нужно отфильтровать журнал",
+                        "name": "vk_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RoleAudit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes": {
+            "get": {
+                "description": "Возвращает список всех маршрутов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить все маршруты",
+                "operationId": "get-all-routes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удаленные записи (только для администраторов)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тег (UUID или slug), для категории учитываются и ее дочерние теги",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RouteWithGeo"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает новый маршрут с указанными параметрами.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Создать новый маршрут",
+                "operationId": "new-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Уникальный идентификатор компании (опционально)",
+                        "name": "company_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Название маршрута (минимум 6 символов)",
+                        "name": "name",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Описание маршрута (минимум 10 символов)",
+                        "name": "description",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Список мест (опционально)",
+                        "name": "places",
+                        "in": "body",
+                        "schema": {
+                            "type": "array"
+                        }
+                    },
+                    {
+                        "description": "Список событий (опционально)",
+                        "name": "events",
+                        "in": "body",
+                        "schema": {
+                            "type": "array"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RouteWithGeo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes/company/{companyId}": {
+            "get": {
+                "description": "Возвращает список маршрутов для указанной компании.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить маршруты компании",
+                "operationId": "get-company-routes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор компании",
+                        "name": "companyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тег (UUID или slug), для категории учитываются и ее дочерние теги",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RouteWithGeo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes/search/{query}": {
+            "get": {
+                "description": "Ищет маршруты по заданному запросу.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Поиск маршрутов",
+                "operationId": "search-routes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Запрос для поиска маршрутов (минимум 2 символа)",
+                        "name": "query",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тег (UUID или slug), для категории учитываются и ее дочерние теги",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RouteWithGeo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes/{routeId}": {
+            "get": {
+                "description": "Возвращает информацию о маршруте по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить информацию о маршруте",
+                "operationId": "get-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить удаленные записи (только для администраторов)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RouteWithGeo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Помечает маршрут удаленным. Доступно администраторам и владельцу компании, которой принадлежит маршрут.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Удалить маршрут",
+                "operationId": "delete-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута (в формате UUID)",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует существующий маршрут с указанными параметрами. Правки пользователей, которые не могут\nредактировать маршрут, сохраняются как предложенное изменение, в этом случае возвращается код 202.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать маршрут",
+                "operationId": "edit-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Название маршрута (минимум 6 символов, опционально)",
+                        "name": "name",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Описание маршрута (минимум 10 символов, опционально)",
+                        "name": "description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык",
+                        "name": "translations",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translations"
+                        }
+                    },
+                    {
+                        "description": "Список мест (опционально)",
+                        "name": "places",
+                        "in": "body",
+                        "schema": {
+                            "type": "array"
+                        }
+                    },
+                    {
+                        "description": "Список событий (опционально)",
+                        "name": "events",
+                        "in": "body",
+                        "schema": {
+                            "type": "array"
+                        }
+                    },
+                    {
+                        "description": "Пояснение к предложенному изменению",
+                        "name": "comment",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RouteWithGeo"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes/{routeId}/restore": {
+            "post": {
+                "description": "Восстанавливает удаленный маршрут. Доступно администраторам и владельцу компании, которой принадлежит маршрут.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Восстановить маршрут",
+                "operationId": "restore-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута (в формате UUID)",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes/{routeId}/reviews": {
+            "get": {
+                "description": "Возвращает список отзывов о маршруте по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить отзывы о маршруте",
+                "operationId": "get-reviews-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: recent (по умолчанию), helpful или stars",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReviewWithMeta"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Добавляет новый отзыв о маршруте с указанными параметрами.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавить отзыв о маршруте",
+                "operationId": "new-review-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст отзыва (минимум 6 символов)",
+                        "name": "review_text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Оценка (от 1 до 5)",
+                        "name": "stars",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "number"
+                        }
+                    },
+                    {
+                        "description": "Идентификаторы загруженных фотографий (опционально)",
+                        "name": "photos",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует существующий отзыв о маршруте с указанными параметрами.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать отзыв о маршруте",
+                "operationId": "edit-review-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст отзыва (минимум 6 символов, опционально)",
+                        "name": "review_text",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Оценка (от 1 до 5, опционально)",
+                        "name": "stars",
+                        "in": "body",
+                        "schema": {
+                            "type": "number"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes/{routeId}/reviews/{reviewId}/helpful": {
+            "post": {
+                "description": "Добавляет отметку \"полезно\" от текущего пользователя к отзыву о маршруте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Отметить отзыв о маршруте полезным",
+                "operationId": "vote-review-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewVote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет отметку \"полезно\" текущего пользователя с отзыва о маршруте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Снять отметку \"полезно\" с отзыва о маршруте",
+                "operationId": "unvote-review-route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/routes/{routeId}/reviews/{reviewId}/reply": {
+            "post": {
+                "description": "Создает официальный ответ компании-владельца на отзыв о маршруте. На каждый отзыв возможен только один ответ.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Ответить на отзыв о маршруте",
+                "operationId": "new-review-route-reply",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст ответа (минимум 2 символа)",
+                        "name": "reply_text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует официальный ответ компании-владельца на отзыв о маршруте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать ответ на отзыв о маршруте",
+                "operationId": "edit-review-route-reply",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор маршрута",
+                        "name": "routeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор отзыва (в формате UUID)",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст ответа (минимум 2 символа)",
+                        "name": "reply_text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewReply"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions": {
+            "get": {
+                "description": "Возвращает изменения мест, событий, маршрутов и компаний, предложенные пользователями.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Предложенные изменения",
+                "operationId": "get-edit-suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус: pending (по умолчанию), accepted, rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип сущности: place, event, route, company",
+                        "name": "entity_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EditSuggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{suggestionId}/accept": {
+            "post": {
+                "description": "Применяет предложенное изменение к сущности и отмечает его принятым. Доступно владельцу сущности\nи модераторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Принять предложенное изменение",
+                "operationId": "accept-edit-suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор изменения (в формате UUID)",
+                        "name": "suggestionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "comment",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{suggestionId}/reject": {
+            "post": {
+                "description": "Отклоняет предложенное изменение, сущность не меняется. Доступно владельцу сущности и модераторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Отклонить предложенное изменение",
+                "operationId": "reject-edit-suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор изменения (в формате UUID)",
+                        "name": "suggestionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отказа",
+                        "name": "comment",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Возвращает все теги мест и событий: сначала категории верхнего уровня, затем дочерние теги.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить справочник тегов",
+                "operationId": "get-tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tag"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Добавляет тег в справочник. Тег с parent_id входит в категорию верхнего уровня.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Добавить тег",
+                "operationId": "create-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Slug: строчные латинские буквы, цифры и дефисы",
+                        "name": "slug",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Названия по кодам языков, например {\\",
+                        "name": "labels",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "description": "Иконка (ссылка или идентификатор загруженного изображения)",
+                        "name": "icon",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Категория верхнего уровня (в формате UUID)",
+                        "name": "parent_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tagId}": {
+            "get": {
+                "description": "Возвращает тег по ID, slug или прежнему slug тега.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить тег",
+                "operationId": "get-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор (в формате UUID) или slug тега",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Переименовывает тег или меняет его названия, иконку и категорию. Прежний slug остается синонимом тега,\nпоэтому старые ссылки и фильтры по нему продолжают работать.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать тег",
+                "operationId": "edit-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор тега (в формате UUID)",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новый slug",
+                        "name": "slug",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Названия по кодам языков, переданные языки заменяют текущие",
+                        "name": "labels",
+                        "in": "body",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "description": "Иконка (ссылка или идентификатор загруженного изображения)",
+                        "name": "icon",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Категория верхнего уровня (в формате UUID)",
+                        "name": "parent_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tagId}/merge": {
+            "post": {
+                "description": "Переносит места, события, дочерние теги и синонимы тега в тег target_id и удаляет исходный тег.\nSlug исходного тега становится синонимом target_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Объединить теги",
+                "operationId": "merge-tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор объединяемого тега (в формате UUID)",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Тег, в который объединяется тег (в формате UUID)",
+                        "name": "target_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trending": {
+            "get": {
+                "description": "Возвращает места, события и маршруты, популярные в последнее время: учитываются просмотры, закладки,\nотметки \"пойду\", посещения и отзывы, причем недавние весят больше. Популярность пересчитывается периодически.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Популярное сейчас",
+                "operationId": "get-trending",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип объектов (place, event, route), по умолчанию все",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город или регион (в формате UUID), для региона учитываются и его города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество объектов (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TrendingItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads": {
+            "post": {
+                "description": "Загружает изображение (JPEG или PNG), удаляет из него метаданные EXIF и создает миниатюру.\nИдентификатор загруженного изображения можно передавать в поля photos отзывов, а также вместо ссылок в carousel, icon и photo_card.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Загрузить изображение",
+                "operationId": "upload-image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл изображения",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Blob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Возвращает информацию о текущем пользователе по его VK ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить информацию о текущем пользователе",
+                "operationId": "get-me",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserGetMeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Редактирует профиль текущего пользователя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Редактировать профиль пользователя",
+                "operationId": "edit-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Пройдено обучение (опционально)",
+                        "name": "passed_onboarding",
+                        "in": "body",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    {
+                        "description": "Выбранная на карте точка, по ней определяется город или регион",
+                        "name": "selected_geo",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    },
+                    {
+                        "description": "Название домашнего города (минимум 2 символа)",
+                        "name": "home_city",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{vkId}": {
+            "get": {
+                "description": "Возвращает информацию о пользователе по его VK ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить пользователя по VK ID",
+                "operationId": "get-user-by-vk-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор пользователя в VK",
+                        "name": "vkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{vkId}/roles": {
+            "get": {
+                "description": "Возвращает роли пользователя по его VK ID. Доступно только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Получить роли пользователя",
+                "operationId": "get-user-roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "VK ID пользователя",
+                        "name": "vkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Выдает роль пользователю по его VK ID. Изменение записывается в журнал. Доступно только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Выдать роль пользователю",
+                "operationId": "grant-role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "VK ID пользователя",
+                        "name": "vkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Роль: admin, moderator, content_editor или company_member",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{vkId}/roles/{role}": {
+            "delete": {
+                "description": "Отзывает роль у пользователя по его VK ID. Изменение записывается в журнал. Доступно только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Отозвать роль у пользователя",
+                "operationId": "revoke-role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Строка авторизации",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "VK ID пользователя",
+                        "name": "vkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Роль: admin, moderator, content_editor или company_member",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "errs.Code": {
+            "type": "string",
+            "enum": [
+                "internal",
+                "authorization_error",
+                "authorization_unavailable",
+                "vk_maps_unavailable",
+                "upstream_failed",
+                "not_found",
+                "conflict",
+                "invalid_request",
+                "validation_failed",
+                "request_body_missing",
+                "invalid_json",
+                "invalid_query",
+                "invalid_uri",
+                "unauthorized",
+                "credentials_expired",
+                "credentials_revoked",
+                "refresh_token_invalid",
+                "session_revoked",
+                "access_denied",
+                "deleted_access_denied",
+                "api_key_scope_denied",
+                "api_key_company_denied",
+                "session_launch_params_required",
+                "company_action_forbidden",
+                "event_company_forbidden",
+                "admin_role_self_revoke",
+                "too_many_requests",
+                "api_key_quota_exceeded",
+                "method_not_found",
+                "user_not_found",
+                "role_not_assigned",
+                "role_already_assigned",
+                "session_not_found",
+                "api_key_not_found",
+                "api_key_already_revoked",
+                "place_not_found",
+                "event_not_found",
+                "route_not_found",
+                "entity_already_deleted",
+                "entity_not_deleted",
+                "place_merge_self",
+                "place_already_owned",
+                "price_range_invalid",
+                "hours_exceptions_duplicate",
+                "date_range_invalid",
+                "rsvp_not_found",
+                "company_not_found",
+                "company_not_owned",
+                "company_member_not_found",
+                "company_member_already_exists",
+                "claim_not_found",
+                "claim_already_pending",
+                "claim_already_reviewed",
+                "suggestion_not_found",
+                "suggestion_already_reviewed",
+                "version_not_found",
+                "achievement_not_found",
+                "review_not_found",
+                "review_already_exists",
+                "review_self_vote",
+                "review_vote_already_exists",
+                "vote_not_found",
+                "review_reply_forbidden",
+                "review_reply_already_exists",
+                "review_reply_not_found",
+                "bookmark_not_found",
+                "tag_not_found",
+                "tag_unknown",
+                "tag_already_exists",
+                "tag_slug_invalid",
+                "tag_parent_invalid",
+                "tag_category_with_parent",
+                "tag_merge_self",
+                "tag_merge_target_not_top_level",
+                "locality_not_found",
+                "locality_already_exists",
+                "locality_parent_invalid",
+                "city_not_found",
+                "boundary_too_short",
+                "address_not_found",
+                "location_incomplete",
+                "location_unknown",
+                "file_not_provided",
+                "file_too_large",
+                "file_not_found",
+                "upload_not_found",
+                "image_format_unsupported",
+                "image_dimensions_invalid"
+            ],
+            "x-enum-varnames": [
+                "CodeInternal",
+                "CodeAuthorizationError",
+                "CodeAuthorizationUnavailable",
+                "CodeVKMapsUnavailable",
+                "CodeUpstreamFailed",
+                "CodeNotFound",
+                "CodeConflict",
+                "CodeInvalidRequest",
+                "CodeValidationFailed",
+                "CodeRequestBodyMissing",
+                "CodeInvalidJSON",
+                "CodeInvalidQuery",
+                "CodeInvalidURI",
+                "CodeUnauthorized",
+                "CodeCredentialsExpired",
+                "CodeCredentialsRevoked",
+                "CodeRefreshTokenInvalid",
+                "CodeSessionRevoked",
+                "CodeAccessDenied",
+                "CodeDeletedAccessDenied",
+                "CodeAPIKeyScopeDenied",
+                "CodeAPIKeyCompanyDenied",
+                "CodeSessionLaunchParamsRequired",
+                "CodeCompanyActionForbidden",
+                "CodeEventCompanyForbidden",
+                "CodeAdminRoleSelfRevoke",
+                "CodeTooManyRequests",
+                "CodeAPIKeyQuotaExceeded",
+                "CodeMethodNotFound",
+                "CodeUserNotFound",
+                "CodeRoleNotAssigned",
+                "CodeRoleAlreadyAssigned",
+                "CodeSessionNotFound",
+                "CodeAPIKeyNotFound",
+                "CodeAPIKeyRevoked",
+                "CodePlaceNotFound",
+                "CodeEventNotFound",
+                "CodeRouteNotFound",
+                "CodeEntityDeleted",
+                "CodeEntityNotDeleted",
+                "CodePlaceMergeSelf",
+                "CodePlaceAlreadyOwned",
+                "CodePriceRangeInvalid",
+                "CodeHoursExceptionsDup",
+                "CodeDateRangeInvalid",
+                "CodeRSVPNotFound",
+                "CodeCompanyNotFound",
+                "CodeCompanyNotOwned",
+                "CodeCompanyMemberNotFound",
+                "CodeCompanyMemberExists",
+                "CodeClaimNotFound",
+                "CodeClaimAlreadyPending",
+                "CodeClaimAlreadyReviewed",
+                "CodeSuggestionNotFound",
+                "CodeSuggestionReviewed",
+                "CodeVersionNotFound",
+                "CodeAchievementNotFound",
+                "CodeReviewNotFound",
+                "CodeReviewAlreadyExists",
+                "CodeReviewSelfVote",
+                "CodeReviewVoteExists",
+                "CodeVoteNotFound",
+                "CodeReviewReplyForbidden",
+                "CodeReviewReplyExists",
+                "CodeReviewReplyNotFound",
+                "CodeBookmarkNotFound",
+                "CodeTagNotFound",
+                "CodeTagUnknown",
+                "CodeTagAlreadyExists",
+                "CodeTagSlugInvalid",
+                "CodeTagParentInvalid",
+                "CodeTagCategoryWithParent",
+                "CodeTagMergeSelf",
+                "CodeTagMergeNotTopLevel",
+                "CodeLocalityNotFound",
+                "CodeLocalityAlreadyExists",
+                "CodeLocalityParentInvalid",
+                "CodeCityNotFound",
+                "CodeBoundaryTooShort",
+                "CodeAddressNotFound",
+                "CodeLocationIncomplete",
+                "CodeLocationUnknown",
+                "CodeFileNotProvided",
+                "CodeFileTooLarge",
+                "CodeFileNotFound",
+                "CodeUploadNotFound",
+                "CodeImageFormatUnsupported",
+                "CodeImageDimensionsInvalid"
+            ]
+        },
+        "errs.Error": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/errs.Code"
+                        }
+                    ],
+                    "example": "place_not_found"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errs.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Place not found"
+                },
+                "params": {
+                    "type": "object"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f0c6f4e-8d7a-4c1e-9a43-2b1d7c9e0f11"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                }
+            }
+        },
+        "errs.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "name"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "name must be at least 6"
+                },
+                "param": {
+                    "type": "string",
+                    "example": "6"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "min"
+                }
+            }
+        },
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "daily_quota": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "requests_count": {
+                    "type": "integer"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "vk_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.APIKeyCreated": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "daily_quota": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "requests_count": {
+                    "type": "integer"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "vk_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.APIKeyUsage": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Achievements": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "coins": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "object"
+                }
+            }
+        },
+        "models.Address": {
+            "type": "object",
+            "properties": {
+                "building": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "locality": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Blob": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Bookmark": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "place",
+                        "event",
+                        "route"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Checkin": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "place",
+                        "event",
+                        "route"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Company": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_released": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "photo_card": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "object"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompanyMember": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "vk_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DaySchedule": {
+            "type": "object",
+            "properties": {
+                "closed": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "exception": {
+                    "type": "boolean"
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EditSuggestion": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "place",
+                        "event",
+                        "route",
+                        "company"
+                    ]
+                },
+                "review_comment": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "accepted",
+                        "rejected"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EntityVersion": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "initial",
+                        "create",
+                        "edit",
+                        "suggestion",
+                        "revert",
+                        "merge"
+                    ]
+                },
+                "changed_fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "place",
+                        "event",
+                        "route",
+                        "company"
+                    ]
+                },
+                "snapshot": {
+                    "type": "object"
+                },
+                "suggestion_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/errs.Error"
+                }
+            }
+        },
+        "models.Event": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "address": {
+                    "$ref": "#/definitions/models.Address"
+                },
+                "address_lat": {
+                    "type": "number"
+                },
+                "address_lng": {
+                    "type": "number"
+                },
+                "address_text": {
+                    "type": "string"
+                },
+                "carousel": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "city_id": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rating": {
+                    "$ref": "#/definitions/models.RatingSummary"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "tag_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translations": {
+                    "type": "object"
+                },
+                "trending_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "models.GeoCandidate": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/models.Address"
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "lng": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "number"
+                },
+                "lng": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.HoursException": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "closed": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.Locality": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "boundary": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GeoPoint"
+                    }
+                },
+                "center": {
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "city",
+                        "region"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "radius_km": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LocalityLanding": {
+            "type": "object",
+            "properties": {
+                "featured_routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RouteWithGeo"
+                    }
+                },
+                "locality": {
+                    "$ref": "#/definitions/models.Locality"
+                },
+                "top_places": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Place"
+                    }
+                },
+                "upcoming_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Event"
+                    }
+                }
+            }
+        },
+        "models.Place": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "accessibility": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "address": {
+                    "$ref": "#/definitions/models.Address"
+                },
+                "address_lat": {
+                    "type": "number"
+                },
+                "address_lng": {
+                    "type": "number"
+                },
+                "address_text": {
+                    "type": "string"
+                },
+                "amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "carousel": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "city_id": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
-                "is_released": {
-                    "type": "boolean"
-                },
-                "name": {
+                "hours_exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HoursException"
+                    }
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "merged_into": {
+                    "description": "MergedInto место, в которое объединен этот дубль",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_now": {
+                    "type": "boolean"
+                },
+                "opening_hours": {
+                    "$ref": "#/definitions/models.WeeklyHours"
+                },
+                "price_max": {
+                    "type": "integer"
+                },
+                "price_min": {
+                    "description": "Диапазон цен в рублях, nil - неизвестно",
+                    "type": "integer"
+                },
+                "rating": {
+                    "$ref": "#/definitions/models.RatingSummary"
+                },
+                "tag_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "description": "OpeningHours - nil, если часы работы неизвестны. OpenNow вычисляется при чтении из базы",
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "object"
+                },
+                "trending_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PlaceClaim": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "evidence": {
+                    "type": "string"
+                },
+                "place_id": {
+                    "type": "string"
+                },
+                "review_comment": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlaceDuplicate": {
+            "type": "object",
+            "properties": {
+                "distance_m": {
+                    "type": "number"
+                },
+                "duplicate": {
+                    "$ref": "#/definitions/models.Place"
+                },
+                "name_similarity": {
+                    "type": "number"
+                },
+                "place": {
+                    "$ref": "#/definitions/models.Place"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PlaceHours": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DaySchedule"
+                    }
+                },
+                "open_now": {
+                    "type": "boolean"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RatingSummary": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "histogram": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.Recommendation": {
+            "type": "object",
+            "properties": {
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "place",
+                        "event",
+                        "route"
+                    ]
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "lng": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rating_avg": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecommendationReason"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RecommendationReason": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "liked",
+                        "nearby",
+                        "highly_rated",
+                        "starts_soon"
+                    ]
+                },
+                "ref_id": {
+                    "description": "RefID объект, на который похожа рекомендация (для kind = liked)",
+                    "type": "string"
+                },
+                "ref_type": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Review": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "review_text": {
+                    "type": "string"
+                },
+                "stars": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ReviewReply": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "reply_text": {
+                    "type": "string"
+                },
+                "review_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReviewVote": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "photo_card": {
+                "review_id": {
                     "type": "string"
                 },
                 "user_id": {
@@ -2321,89 +9491,129 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {}
-            }
-        },
-        "models.Event": {
+        "models.ReviewWithMeta": {
             "type": "object",
             "properties": {
                 "_id": {
                     "type": "string"
                 },
-                "address_lat": {
-                    "type": "number"
+                "author": {
+                    "$ref": "#/definitions/models.UserSummary"
                 },
-                "address_lng": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "address_text": {
+                "entity_id": {
                     "type": "string"
                 },
-                "carousel": {
+                "entity_type": {
+                    "type": "string"
+                },
+                "helpful_count": {
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "photos": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/models.Blob"
                     }
                 },
-                "company_id": {
+                "reply": {
+                    "$ref": "#/definitions/models.ReviewReply"
+                },
+                "review_text": {
                     "type": "string"
                 },
-                "description": {
+                "stars": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.RoleAudit": {
+            "type": "object",
+            "properties": {
+                "_id": {
                     "type": "string"
                 },
-                "icon": {
+                "action": {
                     "type": "string"
                 },
-                "name": {
+                "actor_id": {
                     "type": "string"
                 },
-                "start_time": {
+                "created_at": {
                     "type": "string"
                 },
-                "tags": {
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RoleInfo": {
+            "type": "object",
+            "properties": {
+                "permissions": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                },
+                "role": {
+                    "type": "string"
                 }
             }
         },
-        "models.Place": {
+        "models.RouteGeo": {
+            "type": "object",
+            "properties": {
+                "object": {},
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RouteWithGeo": {
             "type": "object",
             "properties": {
                 "_id": {
                     "type": "string"
                 },
-                "address_lat": {
-                    "type": "number"
-                },
-                "address_lng": {
-                    "type": "number"
+                "company_id": {
+                    "type": "string"
                 },
-                "address_text": {
+                "description": {
                     "type": "string"
                 },
-                "carousel": {
+                "geo": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/models.RouteGeo"
                     }
                 },
-                "description": {
-                    "type": "string"
-                },
                 "is_deleted": {
                     "type": "boolean"
                 },
                 "name": {
                     "type": "string"
+                },
+                "rating": {
+                    "$ref": "#/definitions/models.RatingSummary"
+                },
+                "translations": {
+                    "type": "object"
+                },
+                "trending_score": {
+                    "type": "number"
                 }
             }
         },
-        "models.ReviewEvent": {
+        "models.Session": {
             "type": "object",
             "properties": {
                 "_id": {
@@ -2412,101 +9622,144 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
-                "event_id": {
+                "expires_at": {
                     "type": "string"
                 },
-                "owner_id": {
+                "ip": {
                     "type": "string"
                 },
-                "review_text": {
+                "is_current": {
+                    "type": "boolean"
+                },
+                "refreshed_at": {
                     "type": "string"
                 },
-                "stars": {
-                    "type": "number"
+                "revoked_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "vk_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.ReviewPlace": {
+        "models.SessionTokens": {
             "type": "object",
             "properties": {
-                "_id": {
+                "access_expires_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "access_token": {
                     "type": "string"
                 },
-                "is_deleted": {
-                    "type": "boolean"
-                },
-                "owner_id": {
+                "refresh_expires_at": {
                     "type": "string"
                 },
-                "place_id": {
+                "refresh_token": {
                     "type": "string"
                 },
-                "review_text": {
+                "session_id": {
                     "type": "string"
                 },
-                "stars": {
-                    "type": "number"
+                "token_type": {
+                    "type": "string"
                 }
             }
         },
-        "models.ReviewRoute": {
+        "models.Tag": {
             "type": "object",
             "properties": {
                 "_id": {
                     "type": "string"
                 },
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "is_deleted": {
-                    "type": "boolean"
-                },
-                "owner_id": {
+                "icon": {
                     "type": "string"
                 },
-                "review_text": {
-                    "type": "string"
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "route_id": {
+                "parent_id": {
                     "type": "string"
                 },
-                "stars": {
-                    "type": "number"
+                "slug": {
+                    "type": "string"
                 }
             }
         },
-        "models.RouteGeo": {
+        "models.TimeRange": {
             "type": "object",
+            "required": [
+                "close",
+                "open"
+            ],
             "properties": {
-                "object": {},
-                "type": {
+                "close": {
+                    "type": "string"
+                },
+                "open": {
                     "type": "string"
                 }
             }
         },
-        "models.RouteWithGeo": {
+        "models.Translation": {
             "type": "object",
             "properties": {
-                "_id": {
+                "description": {
                     "type": "string"
                 },
-                "company_id": {
+                "name": {
                     "type": "string"
-                },
-                "description": {
+                }
+            }
+        },
+        "models.Translations": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/models.Translation"
+            }
+        },
+        "models.TrendingItem": {
+            "type": "object",
+            "properties": {
+                "entity_id": {
                     "type": "string"
                 },
-                "geo": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.RouteGeo"
-                    }
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "place",
+                        "event",
+                        "route"
+                    ]
                 },
                 "name": {
                     "type": "string"
+                },
+                "rating_avg": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "trending_score": {
+                    "type": "number"
                 }
             }
         },
@@ -2516,13 +9769,30 @@ const docTemplate = `{
                 "_id": {
                     "type": "string"
                 },
-                "is_admin": {
-                    "type": "boolean"
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "home_city_id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt обновляется не чаще раза в USER_CACHE_TTL, запросы по ключу API его не меняют",
+                    "type": "string"
                 },
                 "passed_onboarding": {
                     "type": "boolean"
                 },
+                "platform": {
+                    "description": "Platform и Language из параметров запуска последнего обращения через VK",
+                    "type": "string"
+                },
                 "selected_geo": {
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "selected_locality_id": {
                     "type": "string"
                 },
                 "vk_id": {
@@ -2537,24 +9807,151 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "current_geo": {
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "first_seen_at": {
                     "type": "string"
                 },
                 "geo_text": {
                     "type": "string"
                 },
-                "is_admin": {
-                    "type": "boolean"
+                "home_city": {
+                    "$ref": "#/definitions/models.Locality"
+                },
+                "home_city_id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt обновляется не чаще раза в USER_CACHE_TTL, запросы по ключу API его не меняют",
+                    "type": "string"
+                },
+                "map_center": {
+                    "description": "MapCenter центр карты по умолчанию: выбранная точка, центр домашнего города или местоположение по IP",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    ]
                 },
                 "passed_onboarding": {
                     "type": "boolean"
                 },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "platform": {
+                    "description": "Platform и Language из параметров запуска последнего обращения через VK",
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "selected_geo": {
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "selected_locality": {
+                    "$ref": "#/definitions/models.Locality"
+                },
+                "selected_locality_id": {
+                    "type": "string"
+                },
+                "vk_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UserSummary": {
+            "type": "object",
+            "properties": {
+                "_id": {
                     "type": "string"
                 },
+                "reviews_count": {
+                    "type": "integer"
+                },
                 "vk_id": {
                     "type": "integer"
                 }
             }
+        },
+        "models.VersionDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "from_version": {
+                    "type": "integer"
+                },
+                "to_version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WeeklyHours": {
+            "type": "object",
+            "properties": {
+                "fri": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                },
+                "mon": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                },
+                "sat": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                },
+                "sun": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                },
+                "thu": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                },
+                "tue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                },
+                "wed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeRange"
+                    }
+                }
+            }
         }
     }
 }`
@@ -1,8 +1,12 @@
 package api
 
 import (
+	"github.com/ShpullRequest/backend/internal/auth"
 	"github.com/ShpullRequest/backend/internal/config"
 	"github.com/ShpullRequest/backend/internal/repository"
+	"github.com/ShpullRequest/backend/pkg/blobstore"
+	"github.com/ShpullRequest/backend/pkg/geocoder"
+	"github.com/ShpullRequest/backend/pkg/ip"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -11,23 +15,47 @@ type Service interface {
 	GetRouter() *gin.Engine
 	GetPg() *repository.Pg
 	GetLogger() *zap.Logger
+	GetBlobStore() blobstore.Store
+	GetAuthenticator() auth.Authenticator
+	GetSessions() *auth.Sessions
+	GetGeocoder() geocoder.Geocoder
+	GetLocator() ip.Locator
 }
 
 type API struct {
-	router *gin.Engine
-	pg     *repository.Pg
-	logger *zap.Logger
+	router        *gin.Engine
+	pg            *repository.Pg
+	logger        *zap.Logger
+	blobs         blobstore.Store
+	authenticator auth.Authenticator
+	sessions      *auth.Sessions
+	geocoder      geocoder.Geocoder
+	locator       ip.Locator
 }
 
-func New(cfg config.NodeConfig, pg *repository.Pg, logger *zap.Logger) *API {
+func New(
+	cfg config.NodeConfig,
+	pg *repository.Pg,
+	logger *zap.Logger,
+	blobs blobstore.Store,
+	authenticator auth.Authenticator,
+	sessions *auth.Sessions,
+	geocoder geocoder.Geocoder,
+	locator ip.Locator,
+) *API {
 	if cfg.ProdFlag {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	return &API{
-		router: gin.New(),
-		pg:     pg,
-		logger: logger,
+		router:        gin.New(),
+		pg:            pg,
+		logger:        logger,
+		blobs:         blobs,
+		authenticator: authenticator,
+		sessions:      sessions,
+		geocoder:      geocoder,
+		locator:       locator,
 	}
 }
 
@@ -42,3 +70,23 @@ func (a *API) GetPg() *repository.Pg {
 func (a *API) GetLogger() *zap.Logger {
 	return a.logger
 }
+
+func (a *API) GetBlobStore() blobstore.Store {
+	return a.blobs
+}
+
+func (a *API) GetAuthenticator() auth.Authenticator {
+	return a.authenticator
+}
+
+func (a *API) GetSessions() *auth.Sessions {
+	return a.sessions
+}
+
+func (a *API) GetGeocoder() geocoder.Geocoder {
+	return a.geocoder
+}
+
+func (a *API) GetLocator() ip.Locator {
+	return a.locator
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	MethodAPIKey = "api_key"
+
+	apiKeyPrefix = "pk_"
+	apiKeyHeader = "X-API-Key"
+)
+
+// ErrQuotaExceeded означает, что дневная квота ключа исчерпана.
+var ErrQuotaExceeded = errors.New("api key quota exceeded")
+
+type APIKeyStore interface {
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	UseAPIKey(ctx context.Context, id uuid.UUID, dailyQuota int) (bool, error)
+}
+
+// APIKeys аутентифицирует серверные запросы партнеров по ключу из заголовка X-API-Key
+// или Authorization: Bearer pk_...
+type APIKeys struct {
+	store APIKeyStore
+}
+
+func NewAPIKeys(store APIKeyStore) *APIKeys {
+	return &APIKeys{
+		store: store,
+	}
+}
+
+// GenerateAPIKey создает новый ключ вида pk_<prefix>_<secret> и возвращает его вместе с префиксом и хэшем для хранения.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	buf := make([]byte, 36)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = hex.EncodeToString(buf[:4])
+	key = apiKeyPrefix + prefix + "_" + base64.RawURLEncoding.EncodeToString(buf[4:])
+
+	return key, prefix, HashAPIKey(key), nil
+}
+
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *APIKeys) Authenticate(r *http.Request) (*Principal, error) {
+	key := r.Header.Get(apiKeyHeader)
+	if key == "" {
+		key = BearerToken(r)
+	}
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrNoCredentials
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, apiKeyPrefix), "_", 2)
+	if len(parts) != 2 {
+		return nil, ErrUnauthorized
+	}
+
+	apiKey, err := a.store.GetAPIKeyByPrefix(r.Context(), parts[0])
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUnauthorized
+		}
+
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(apiKey.KeyHash)) != 1 {
+		return nil, ErrUnauthorized
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil, ErrRevoked
+	}
+
+	allowed, err := a.store.UseAPIKey(r.Context(), apiKey.ID, apiKey.DailyQuota)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrQuotaExceeded
+	}
+
+	return &Principal{
+		VkUserID:  apiKey.VkID,
+		Method:    MethodAPIKey,
+		APIKeyID:  &apiKey.ID,
+		Scopes:    apiKey.Scopes,
+		CompanyID: apiKey.CompanyID,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNoCredentials означает, что аутентификатор не распознал учетные данные запроса
+	// и цепочка должна перейти к следующему.
+	ErrNoCredentials = errors.New("no credentials")
+	ErrUnauthorized  = errors.New("authorization failed")
+	ErrExpired       = fmt.Errorf("%w: credentials expired", ErrUnauthorized)
+	ErrRevoked       = fmt.Errorf("%w: credentials revoked", ErrUnauthorized)
+)
+
+const (
+	MethodVKLaunchParams = "vk_launch_params"
+	MethodSession        = "session"
+)
+
+const (
+	principalKey   = "principal"
+	userKey        = "user"
+	userChangedKey = "user_changed"
+)
+
+// Principal описывает аутентифицированного пользователя независимо от способа входа.
+// Запросы по ключу API выполняются от имени владельца ключа в пределах Scopes и CompanyID.
+type Principal struct {
+	VkUserID  int64
+	Method    string
+	SessionID *uuid.UUID
+	APIKeyID  *uuid.UUID
+	Scopes    []string
+	CompanyID *uuid.UUID
+	Platform  string
+	Language  string
+}
+
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Chain по очереди опрашивает аутентификаторы, пока один из них не распознает учетные данные.
+type Chain []Authenticator
+
+func (c Chain) Authenticate(r *http.Request) (*Principal, error) {
+	for _, authenticator := range c {
+		principal, err := authenticator.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		return principal, err
+	}
+
+	return nil, ErrNoCredentials
+}
+
+func BearerToken(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+func SetPrincipal(ctx *gin.Context, principal *Principal) {
+	ctx.Set(principalKey, principal)
+}
+
+func GetPrincipal(ctx *gin.Context) *Principal {
+	principal, _ := ctx.Get(principalKey)
+	p, _ := principal.(*Principal)
+
+	return p
+}
+
+// SetUser сохраняет пользователя, от имени которого выполняется запрос.
+func SetUser(ctx *gin.Context, user *models.User) {
+	ctx.Set(userKey, user)
+}
+
+// GetUser возвращает пользователя запроса или nil, если запрос не авторизован.
+func GetUser(ctx *gin.Context) *models.User {
+	user, _ := ctx.Get(userKey)
+	u, _ := user.(*models.User)
+
+	return u
+}
+
+// UserChanged отмечает, что изменения пользователя запроса сохранены и его закэшированная копия устарела.
+func UserChanged(ctx *gin.Context) {
+	ctx.Set(userChangedKey, true)
+}
+
+// IsUserChanged сообщает, сохранял ли запрос изменения пользователя.
+func IsUserChanged(ctx *gin.Context) bool {
+	return ctx.GetBool(userChangedKey)
+}
+
+type clientIPKey struct{}
+
+// WithClientIP сохраняет в контексте адрес клиента, определенный с учетом доверенных прокси.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP возвращает адрес клиента, сохраненный WithClientIP, или пустую строку.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+
+	return ip
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Минимальная реализация JWT с подписью HS256, достаточная для собственных токенов сессий.
+
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+func signToken(claims any, secret []byte) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + signature(unsigned, secret), nil
+}
+
+// parseToken проверяет подпись и срок действия токена и заполняет claims.
+func parseToken(token string, secret []byte, claims *Claims) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return fmt.Errorf("%w: malformed token", ErrUnauthorized)
+	}
+
+	if !hmac.Equal([]byte(parts[2]), []byte(signature(parts[0]+"."+parts[1], secret))) {
+		return fmt.Errorf("%w: invalid token signature", ErrUnauthorized)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("%w: malformed token", ErrUnauthorized)
+	}
+
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return fmt.Errorf("%w: malformed token", ErrUnauthorized)
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+func signature(unsigned string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/config"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+type Claims struct {
+	Subject   string    `json:"sub"`
+	SessionID uuid.UUID `json:"sid"`
+	TokenType string    `json:"typ"`
+	ID        uuid.UUID `json:"jti"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+type SessionStore interface {
+	GetSession(ctx context.Context, id uuid.UUID) (*models.Session, error)
+}
+
+type Store interface {
+	SessionStore
+	APIKeyStore
+}
+
+// Sessions выпускает подписанные токены доступа и обновления и аутентифицирует запросы по токену доступа.
+// Токен доступа действителен, только пока его сессия не отозвана.
+type Sessions struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	store      SessionStore
+}
+
+func NewSessions(secret []byte, accessTTL, refreshTTL time.Duration, store SessionStore) *Sessions {
+	return &Sessions{
+		secret:     secret,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		store:      store,
+	}
+}
+
+// New собирает цепочку аутентификаторов из конфигурации. Без SESSION_SECRET вне production
+// используется случайный ключ, и выданные токены перестают действовать после перезапуска.
+func New(cfg config.NodeConfig, store Store) (Chain, *Sessions, error) {
+	secret := []byte(cfg.SessionSecret)
+	if len(secret) == 0 {
+		if cfg.ProdFlag {
+			return nil, nil, errors.New("session secret is required in production")
+		}
+
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	launchParams := NewVKLaunchParams(
+		cfg.AppSecretToken,
+		cfg.LaunchParamsMaxAge,
+		cfg.LaunchParamsBindFingerprint,
+		cfg.LaunchParamsCacheSize,
+	)
+	sessions := NewSessions(secret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, store)
+
+	return Chain{NewAPIKeys(store), launchParams, sessions}, sessions, nil
+}
+
+func (s *Sessions) RefreshTTL() time.Duration {
+	return s.refreshTTL
+}
+
+// Issue выпускает пару токенов для сессии. Токен обновления привязан к session.RefreshJTI.
+func (s *Sessions) Issue(session *models.Session) (*models.SessionTokens, error) {
+	now := time.Now()
+	subject := strconv.FormatInt(session.VkID, 10)
+
+	accessExpiresAt := now.Add(s.accessTTL)
+	if accessExpiresAt.After(session.ExpiresAt) {
+		accessExpiresAt = session.ExpiresAt
+	}
+
+	accessToken, err := signToken(Claims{
+		Subject:   subject,
+		SessionID: session.ID,
+		TokenType: TokenTypeAccess,
+		ID:        uuid.New(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: accessExpiresAt.Unix(),
+	}, s.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := signToken(Claims{
+		Subject:   subject,
+		SessionID: session.ID,
+		TokenType: TokenTypeRefresh,
+		ID:        session.RefreshJTI,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: session.ExpiresAt.Unix(),
+	}, s.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SessionTokens{
+		SessionID:        session.ID,
+		TokenType:        "Bearer",
+		AccessToken:      accessToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (s *Sessions) ParseRefreshToken(token string) (*Claims, error) {
+	return s.parse(token, TokenTypeRefresh)
+}
+
+func (s *Sessions) Authenticate(r *http.Request) (*Principal, error) {
+	token := BearerToken(r)
+	if strings.Count(token, ".") != 2 {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := s.parse(token, TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.store.GetSession(r.Context(), claims.SessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRevoked
+		}
+
+		return nil, err
+	}
+
+	if !session.IsActive() {
+		return nil, ErrRevoked
+	}
+
+	return &Principal{
+		VkUserID:  session.VkID,
+		Method:    MethodSession,
+		SessionID: &session.ID,
+	}, nil
+}
+
+func (s *Sessions) parse(token, tokenType string) (*Claims, error) {
+	var claims Claims
+	if err := parseToken(token, s.secret, &claims); err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, ErrUnauthorized
+	}
+
+	return &claims, nil
+}
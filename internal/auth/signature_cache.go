@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// signaturesPerUser сколько подписей одного пользователя хранит кэш. Новая подпись сверх этого вытесняет
+// самую старую подпись того же пользователя, поэтому перезапусками приложения один пользователь
+// не заполнит кэш и не заблокирует вход остальным.
+const signaturesPerUser = 8
+
+// signatureCache LRU-кэш проверенных подписей параметров запуска. Хранит только успешно проверенные
+// подписи, поэтому поток поддельных строк не вытесняет записи.
+// В режиме pinned действующие записи других пользователей не вытесняются: они хранят привязку подписи
+// к клиенту, и вытеснение снова открыло бы повтор подписи до истечения ее срока.
+type signatureCache struct {
+	mu     sync.Mutex
+	size   int
+	ttl    time.Duration
+	pinned bool
+	items  map[[32]byte]*list.Element
+	order  *list.List
+	// users подписи каждого пользователя, старые первыми
+	users map[int64][]*list.Element
+}
+
+type signatureEntry struct {
+	key         [32]byte
+	principal   Principal
+	signedAt    time.Time
+	expiresAt   time.Time
+	fingerprint string
+}
+
+func newSignatureCache(size int, ttl time.Duration, pinned bool) *signatureCache {
+	return &signatureCache{
+		size:   size,
+		ttl:    ttl,
+		pinned: pinned,
+		items:  make(map[[32]byte]*list.Element),
+		order:  list.New(),
+		users:  make(map[int64][]*list.Element),
+	}
+}
+
+func (c *signatureCache) get(key [32]byte) (signatureEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return signatureEntry{}, false
+	}
+
+	entry := element.Value.(*signatureEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(element)
+
+		return signatureEntry{}, false
+	}
+
+	c.order.MoveToFront(element)
+	return *entry, true
+}
+
+// add сохраняет проверенную подпись. Возвращает false, если в режиме pinned кэш заполнен действующими записями.
+func (c *signatureCache) add(key [32]byte, principal Principal, signedAt time.Time) bool {
+	if c.size <= 0 {
+		return !c.pinned
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.items[key]; ok {
+		return true
+	}
+
+	if elements := c.users[principal.VkUserID]; len(elements) >= signaturesPerUser {
+		c.remove(elements[0])
+	}
+
+	if c.pinned && c.order.Len() >= c.size && c.removeExpired(time.Now()) == 0 {
+		return false
+	}
+
+	element := c.order.PushFront(&signatureEntry{
+		key:       key,
+		principal: principal,
+		signedAt:  signedAt,
+		expiresAt: signedAt.Add(c.ttl),
+	})
+	c.items[key] = element
+	c.users[principal.VkUserID] = append(c.users[principal.VkUserID], element)
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+
+	return true
+}
+
+// remove удаляет запись из кэша и из подписей ее пользователя.
+func (c *signatureCache) remove(element *list.Element) {
+	entry := element.Value.(*signatureEntry)
+	c.order.Remove(element)
+	delete(c.items, entry.key)
+
+	userID := entry.principal.VkUserID
+	elements := c.users[userID]
+	for i, e := range elements {
+		if e == element {
+			elements = append(elements[:i], elements[i+1:]...)
+			break
+		}
+	}
+
+	if len(elements) == 0 {
+		delete(c.users, userID)
+	} else {
+		c.users[userID] = elements
+	}
+}
+
+// removeExpired удаляет все записи с истекшим сроком и возвращает их количество.
+func (c *signatureCache) removeExpired(now time.Time) int {
+	removed := 0
+	for element := c.order.Back(); element != nil; {
+		prev := element.Prev()
+		if entry := element.Value.(*signatureEntry); now.After(entry.expiresAt) {
+			c.remove(element)
+			removed++
+		}
+		element = prev
+	}
+
+	return removed
+}
+
+// bind привязывает подпись к отпечатку клиента, который первым ее предъявил.
+// Возвращает false, если подпись уже привязана к другому клиенту.
+func (c *signatureCache) bind(key [32]byte, fingerprint string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return true
+	}
+
+	entry := element.Value.(*signatureEntry)
+	if entry.fingerprint == "" {
+		entry.fingerprint = fingerprint
+	}
+
+	return entry.fingerprint == fingerprint
+}
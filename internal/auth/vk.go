@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SevereCloud/vksdk/v2/vkapps"
+)
+
+// Без ограничения возраста подписи проверенные подписи хранятся в кэше не дольше этого времени
+const defaultSignatureTTL = time.Hour
+
+var (
+	// ErrFingerprintMismatch означает, что подпись предъявлена не тем клиентом, который использовал ее первым.
+	ErrFingerprintMismatch = fmt.Errorf("%w: signature is bound to another client", ErrUnauthorized)
+	// ErrSignatureCacheFull означает, что новую подпись негде привязать к клиенту: кэш заполнен действующими подписями.
+	ErrSignatureCacheFull = errors.New("launch params signature cache is full")
+)
+
+var signatureCacheMetrics = expvar.NewMap("launch_params_cache")
+
+// VKLaunchParams проверяет подпись параметров запуска VK Mini App.
+type VKLaunchParams struct {
+	secret          string
+	maxAge          time.Duration
+	bindFingerprint bool
+	cache           *signatureCache
+}
+
+// NewVKLaunchParams создает аутентификатор по параметрам запуска. При maxAge = 0 возраст подписи не проверяется.
+// При bindFingerprint подпись принимается только от клиента (IP и User-Agent), который предъявил ее первым.
+// Привязка хранится в кэше проверенных подписей размером cacheSize и не вытесняется подписями других
+// пользователей, пока подпись действует, поэтому cacheSize должен вмещать подписи, предъявленные за maxAge
+// (или час без ограничения возраста). От одного пользователя хранятся только последние signaturesPerUser подписей.
+// Когда кэш заполнен, новые подписи отклоняются с ErrSignatureCacheFull.
+func NewVKLaunchParams(secret string, maxAge time.Duration, bindFingerprint bool, cacheSize int) *VKLaunchParams {
+	ttl := maxAge
+	if ttl <= 0 {
+		ttl = defaultSignatureTTL
+	}
+
+	return &VKLaunchParams{
+		secret:          secret,
+		maxAge:          maxAge,
+		bindFingerprint: bindFingerprint,
+		cache:           newSignatureCache(cacheSize, ttl, bindFingerprint),
+	}
+}
+
+func (v *VKLaunchParams) Authenticate(r *http.Request) (*Principal, error) {
+	credential := BearerToken(r)
+	if !strings.Contains(credential, "sign=") {
+		return nil, ErrNoCredentials
+	}
+
+	key := sha256.Sum256([]byte(credential))
+
+	entry, ok := v.cache.get(key)
+	if ok {
+		signatureCacheMetrics.Add("hit", 1)
+	} else {
+		signatureCacheMetrics.Add("miss", 1)
+
+		principal, signedAt, err := v.verify(credential)
+		if err != nil {
+			return nil, err
+		}
+
+		entry = signatureEntry{principal: *principal, signedAt: signedAt}
+		if !v.expired(signedAt) && !v.cache.add(key, *principal, signedAt) {
+			signatureCacheMetrics.Add("full", 1)
+			return nil, ErrSignatureCacheFull
+		}
+	}
+
+	if v.expired(entry.signedAt) {
+		return nil, ErrExpired
+	}
+
+	if v.bindFingerprint && !v.cache.bind(key, clientFingerprint(r)) {
+		return nil, ErrFingerprintMismatch
+	}
+
+	principal := entry.principal
+	return &principal, nil
+}
+
+// verify проверяет подпись и разбирает параметры запуска. Ошибки разбора строки, присланной клиентом,
+// означают неверные учетные данные, а не сбой сервера.
+func (v *VKLaunchParams) verify(credential string) (*Principal, time.Time, error) {
+	ok, err := vkapps.ParamsVerify(credential, v.secret)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: params verify: %w", ErrUnauthorized, err)
+	}
+	if !ok {
+		return nil, time.Time{}, ErrUnauthorized
+	}
+
+	u, _ := url.Parse(credential)
+	params, err := vkapps.NewParams(u)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: parse launch params: %w", ErrUnauthorized, err)
+	}
+
+	ts, err := strconv.Atoi(params.VkTs)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%w: convert vk_ts: %w", ErrUnauthorized, err)
+	}
+
+	return &Principal{
+		VkUserID: int64(params.VkUserID),
+		Method:   MethodVKLaunchParams,
+		Platform: string(params.VkPlatform),
+		Language: params.VkLanguage,
+	}, time.Unix(int64(ts), 0), nil
+}
+
+func (v *VKLaunchParams) expired(signedAt time.Time) bool {
+	return v.maxAge > 0 && time.Since(signedAt) >= v.maxAge
+}
+
+// clientFingerprint отпечаток клиента по IP-адресу и User-Agent. Адрес берется из контекста запроса
+// (WithClientIP), где его определил gin с учетом доверенных прокси; заголовкам запроса не доверяем.
+func clientFingerprint(r *http.Request) string {
+	ip := ClientIP(r.Context())
+	if ip == "" {
+		ip, _, _ = net.SplitHostPort(r.RemoteAddr)
+	}
+
+	sum := sha256.Sum256([]byte(ip + "|" + r.UserAgent()))
+	return hex.EncodeToString(sum[:8])
+}
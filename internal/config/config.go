@@ -4,6 +4,7 @@ import (
 	"flag"
 	"github.com/caarlos0/env/v6"
 	"github.com/joho/godotenv"
+	"time"
 )
 
 var Config NodeConfig
@@ -15,12 +16,60 @@ type NodeConfig struct {
 	AppSecretToken string `env:"APP_SECRET_TOKEN"`
 	VkMapsAPIKey   string `env:"VK_MAPS_API_KEY"`
 
+	Geocoder          string        `env:"GEOCODER"`
+	GeocoderTimeout   time.Duration `env:"GEOCODER_TIMEOUT"`
+	GeocoderRetries   int           `env:"GEOCODER_RETRIES"`
+	GeocoderCacheSize int           `env:"GEOCODER_CACHE_SIZE"`
+	GeocoderCacheTTL  time.Duration `env:"GEOCODER_CACHE_TTL"`
+	GeocoderPrecision int           `env:"GEOCODER_PRECISION"`
+
+	IPDatabasePath   string        `env:"IP_DATABASE_PATH"`
+	IPDatabaseReload time.Duration `env:"IP_DATABASE_RELOAD"`
+	IPRemoteFallback bool          `env:"IP_REMOTE_FALLBACK"`
+	IPRemoteURL      string        `env:"IP_REMOTE_URL"`
+	IPRemoteTimeout  time.Duration `env:"IP_REMOTE_TIMEOUT"`
+	IPCacheSize      int           `env:"IP_CACHE_SIZE"`
+	IPCacheTTL       time.Duration `env:"IP_CACHE_TTL"`
+
+	TrendingRefresh  time.Duration `env:"TRENDING_REFRESH"`
+	TrendingHalfLife time.Duration `env:"TRENDING_HALF_LIFE"`
+
 	MasterDSN      string `env:"MASTER_DSN"`
 	MasterMaxOpen  int    `env:"MASTER_MAX_OPEN"`
 	ReplicaDSN     string `env:"REPLICA_DSN"`
 	ReplicaMaxOpen int    `env:"REPLICA_MAX_OPEN"`
 	MigrationsFlag bool   `env:"MIGRATIONS_FLAG"`
 
+	BlobStorage   string `env:"BLOB_STORAGE"`
+	BlobLocalPath string `env:"BLOB_LOCAL_PATH"`
+	BlobPublicURL string `env:"BLOB_PUBLIC_URL"`
+	S3Endpoint    string `env:"S3_ENDPOINT"`
+	S3Region      string `env:"S3_REGION"`
+	S3Bucket      string `env:"S3_BUCKET"`
+	S3AccessKey   string `env:"S3_ACCESS_KEY"`
+	S3SecretKey   string `env:"S3_SECRET_KEY"`
+
+	UploadMaxSize      int64 `env:"UPLOAD_MAX_SIZE"`
+	UploadMaxDimension int   `env:"UPLOAD_MAX_DIMENSION"`
+	ThumbnailSize      int   `env:"THUMBNAIL_SIZE"`
+	ReviewMaxPhotos    int   `env:"REVIEW_MAX_PHOTOS"`
+
+	LaunchParamsMaxAge          time.Duration `env:"LAUNCH_PARAMS_MAX_AGE"`
+	LaunchParamsBindFingerprint bool          `env:"LAUNCH_PARAMS_BIND_FINGERPRINT"`
+	LaunchParamsCacheSize       int           `env:"LAUNCH_PARAMS_CACHE_SIZE"`
+
+	SessionSecret   string        `env:"SESSION_SECRET"`
+	AccessTokenTTL  time.Duration `env:"ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL"`
+
+	UserCacheSize int           `env:"USER_CACHE_SIZE"`
+	UserCacheTTL  time.Duration `env:"USER_CACHE_TTL"`
+
+	RateLimitStore string `env:"RATE_LIMIT_STORE"`
+	TrustedProxies string `env:"TRUSTED_PROXIES"`
+
+	MetricsAddress string `env:"METRICS_ADDRESS"`
+
 	ProdFlag bool `env:"PROD_FLAG"`
 }
 
@@ -31,12 +80,59 @@ func Load() {
 	flag.StringVar(&Config.AppSecretToken, "app-secret-token", "", "app secret token")
 	flag.StringVar(&Config.VkMapsAPIKey, "vk-maps-api-key", "", "vk maps api key")
 
+	flag.StringVar(&Config.Geocoder, "geocoder", "vk", "geocoding provider (vk or fake)")
+	flag.DurationVar(&Config.GeocoderTimeout, "geocoder-timeout", 5*time.Second, "timeout of a single geocoding request")
+	flag.IntVar(&Config.GeocoderRetries, "geocoder-retries", 2, "retries of a failed geocoding request")
+	flag.IntVar(&Config.GeocoderCacheSize, "geocoder-cache-size", 10000, "size of in-memory geocoding cache")
+	flag.DurationVar(&Config.GeocoderCacheTTL, "geocoder-cache-ttl", 30*24*time.Hour, "lifetime of cached addresses")
+	flag.IntVar(&Config.GeocoderPrecision, "geocoder-precision", 4, "decimal places of coordinates used as cache key")
+
+	flag.StringVar(&Config.IPDatabasePath, "ip-database-path", "", "path to csv database of ip ranges, empty disables local geolocation")
+	flag.DurationVar(&Config.IPDatabaseReload, "ip-database-reload", time.Minute, "interval of checking ip database for changes, 0 disables reload")
+	flag.BoolVar(&Config.IPRemoteFallback, "ip-remote-fallback", false, "ask remote provider for ip missing in local database (sends user ip to third party)")
+	flag.StringVar(&Config.IPRemoteURL, "ip-remote-url", "http://ip-api.com/json/", "base url of remote ip geolocation provider")
+	flag.DurationVar(&Config.IPRemoteTimeout, "ip-remote-timeout", 2*time.Second, "timeout of remote ip geolocation request")
+	flag.IntVar(&Config.IPCacheSize, "ip-cache-size", 10000, "size of ip geolocation cache in prefixes")
+	flag.DurationVar(&Config.IPCacheTTL, "ip-cache-ttl", 6*time.Hour, "lifetime of cached ip locations")
+	flag.DurationVar(&Config.TrendingRefresh, "trending-refresh", 10*time.Minute, "interval of recomputing trending scores, 0 disables recomputing")
+	flag.DurationVar(&Config.TrendingHalfLife, "trending-half-life", 72*time.Hour, "time after which views, bookmarks, rsvps, check-ins and reviews count half as much in trending scores")
+
 	flag.StringVar(&Config.MasterDSN, "master-dsn", "", "postgres master dsn")
 	flag.IntVar(&Config.MasterMaxOpen, "master-max-open", 6, "maximum opened pools for master")
 	flag.StringVar(&Config.ReplicaDSN, "replica-dsn", "", "postgres replica dsn")
 	flag.IntVar(&Config.ReplicaMaxOpen, "replica-max-open", 6, "maximum opened pools for replica")
 	flag.BoolVar(&Config.MigrationsFlag, "migrations-flag", false, "database flag migrations")
 
+	flag.StringVar(&Config.BlobStorage, "blob-storage", "local", "blob storage backend (local or s3)")
+	flag.StringVar(&Config.BlobLocalPath, "blob-local-path", "./uploads", "directory for local blob storage")
+	flag.StringVar(&Config.BlobPublicURL, "blob-public-url", "/blobs", "public base url of stored blobs")
+	flag.StringVar(&Config.S3Endpoint, "s3-endpoint", "", "s3 compatible storage endpoint")
+	flag.StringVar(&Config.S3Region, "s3-region", "us-east-1", "s3 region")
+	flag.StringVar(&Config.S3Bucket, "s3-bucket", "", "s3 bucket")
+	flag.StringVar(&Config.S3AccessKey, "s3-access-key", "", "s3 access key")
+	flag.StringVar(&Config.S3SecretKey, "s3-secret-key", "", "s3 secret key")
+
+	flag.Int64Var(&Config.UploadMaxSize, "upload-max-size", 10<<20, "maximum size of uploaded image in bytes")
+	flag.IntVar(&Config.UploadMaxDimension, "upload-max-dimension", 8000, "maximum width and height of uploaded image")
+	flag.IntVar(&Config.ThumbnailSize, "thumbnail-size", 320, "maximum side of generated thumbnails")
+	flag.IntVar(&Config.ReviewMaxPhotos, "review-max-photos", 5, "maximum photos attached to a review")
+
+	flag.DurationVar(&Config.LaunchParamsMaxAge, "launch-params-max-age", time.Hour, "maximum age of vk launch params signature, 0 disables the check")
+	flag.BoolVar(&Config.LaunchParamsBindFingerprint, "launch-params-bind-fingerprint", false, "bind launch params signature to the first client that used it")
+	flag.IntVar(&Config.LaunchParamsCacheSize, "launch-params-cache-size", 10000, "size of verified launch params signatures cache; with fingerprint binding it must hold all signatures seen within max age, new ones are rejected when full")
+
+	flag.StringVar(&Config.SessionSecret, "session-secret", "", "secret for signing session tokens")
+	flag.DurationVar(&Config.AccessTokenTTL, "access-token-ttl", 15*time.Minute, "lifetime of session access tokens")
+	flag.DurationVar(&Config.RefreshTokenTTL, "refresh-token-ttl", 30*24*time.Hour, "lifetime of session refresh tokens")
+
+	flag.IntVar(&Config.UserCacheSize, "user-cache-size", 10000, "size of provisioned users cache")
+	flag.DurationVar(&Config.UserCacheTTL, "user-cache-ttl", time.Minute, "how long a provisioned user is served from memory; also the granularity of users.last_seen_at")
+
+	flag.StringVar(&Config.RateLimitStore, "rate-limit-store", "memory", "rate limit store (memory, postgres or none)")
+	flag.StringVar(&Config.TrustedProxies, "trusted-proxies", "", "comma separated proxy ips or cidrs allowed to set X-Forwarded-For, empty trusts none")
+
+	flag.StringVar(&Config.MetricsAddress, "metrics-address", "", "address of expvar metrics server, empty disables it")
+
 	flag.BoolVar(&Config.ProdFlag, "prod-flag", false, "flag for production server")
 }
 
@@ -0,0 +1,41 @@
+package errs
+
+// SourceLanguage язык сообщений в definitions и ruleMessages
+const SourceLanguage = "en"
+
+// catalogue переводы сообщений об ошибках на один язык. {name} в переводе заменяется параметром ошибки
+type catalogue struct {
+	messages map[Code]string
+	rules    map[string]string
+}
+
+// catalogues каталоги сообщений об ошибках по кодам языков
+var catalogues = map[string]catalogue{
+	"ru": ru,
+}
+
+// Localize переводит сообщение ошибки и сообщения полей на первый язык из languages, для которого есть каталог.
+// Если перевода в каталоге нет, сообщение остается на английском.
+func (e *Error) Localize(languages []string) *Error {
+	for _, language := range languages {
+		if language == SourceLanguage {
+			return e
+		}
+
+		catalogue, ok := catalogues[language]
+		if !ok {
+			continue
+		}
+
+		if message, ok := catalogue.messages[e.Code]; ok {
+			e.Message = format(message, e.Params)
+		}
+		for i, detail := range e.Details {
+			e.Details[i].Message = format(ruleMessage(catalogue.rules, detail.Rule), detail.params())
+		}
+
+		return e
+	}
+
+	return e
+}
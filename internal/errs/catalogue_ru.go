@@ -0,0 +1,123 @@
+package errs
+
+var ru = catalogue{
+	messages: map[Code]string{
+		CodeInternal:                 "Внутренняя ошибка сервера",
+		CodeAuthorizationError:       "Неизвестная ошибка при проверке авторизации",
+		CodeAuthorizationUnavailable: "Авторизация временно недоступна, попробуйте позже",
+		CodeVKMapsUnavailable:        "Внутренняя ошибка сервиса VK Карты",
+		CodeUpstreamFailed:           "Ошибка внешнего сервиса",
+		CodeNotFound:                 "Не найдено",
+		CodeConflict:                 "Уже существует",
+
+		CodeInvalidRequest:     "Некорректный запрос: {error}",
+		CodeValidationFailed:   "Запрос не прошел проверку",
+		CodeRequestBodyMissing: "Тело запроса не передано",
+		CodeInvalidJSON:        "Некорректный JSON",
+		CodeInvalidQuery:       "Некорректный тип параметра запроса",
+		CodeInvalidURI:         "Некорректный тип параметра пути",
+
+		CodeUnauthorized:        "Ошибка авторизации",
+		CodeCredentialsExpired:  "Ошибка авторизации, срок действия подписи истек",
+		CodeCredentialsRevoked:  "Ошибка авторизации, учетные данные отозваны",
+		CodeRefreshTokenInvalid: "Некорректный токен обновления",
+		CodeSessionRevoked:      "Сессия отозвана",
+
+		CodeAccessDenied:                "У вас нет доступа к этому методу",
+		CodeDeletedAccessDenied:         "У вас нет доступа к удаленным записям",
+		CodeAPIKeyScopeDenied:           "У ключа API нет доступа к этому методу",
+		CodeAPIKeyCompanyDenied:         "Ключ API принадлежит другой компании",
+		CodeSessionLaunchParamsRequired: "Сессию можно создать только по параметрам запуска VK",
+		CodeCompanyActionForbidden:      "Вы не можете действовать от имени этой компании",
+		CodeEventCompanyForbidden:       "Вы не можете создать событие от имени этой компании",
+		CodeAdminRoleSelfRevoke:         "Нельзя снять с себя роль администратора",
+
+		CodeTooManyRequests:     "Слишком много запросов",
+		CodeAPIKeyQuotaExceeded: "Исчерпан дневной лимит запросов ключа API",
+
+		CodeMethodNotFound:      "Неизвестный метод",
+		CodeUserNotFound:        "Пользователь не найден",
+		CodeRoleNotAssigned:     "У пользователя нет этой роли",
+		CodeRoleAlreadyAssigned: "У пользователя уже есть эта роль",
+		CodeSessionNotFound:     "Сессия не найдена",
+		CodeAPIKeyNotFound:      "Ключ API не найден",
+		CodeAPIKeyRevoked:       "Ключ API уже отозван",
+
+		CodePlaceNotFound:      "Место не найдено",
+		CodeEventNotFound:      "Событие не найдено",
+		CodeRouteNotFound:      "Маршрут не найден",
+		CodeEntityDeleted:      "Объект уже удален",
+		CodeEntityNotDeleted:   "Объект не удален",
+		CodePlaceMergeSelf:     "Место нельзя объединить с самим собой",
+		CodePlaceAlreadyOwned:  "Место уже принадлежит этой компании",
+		CodePriceRangeInvalid:  "Максимальная цена не может быть меньше минимальной",
+		CodeHoursExceptionsDup: "Даты особого режима работы не должны повторяться",
+		CodeDateRangeInvalid:   "Период должен быть от 1 до 62 дней",
+		CodeRSVPNotFound:       "Отметка \"пойду\" не найдена",
+
+		CodeCompanyNotFound:       "Компания не найдена",
+		CodeCompanyNotOwned:       "Компания не принадлежит пользователю",
+		CodeCompanyMemberNotFound: "Пользователь не является сотрудником компании",
+		CodeCompanyMemberExists:   "Пользователь уже является сотрудником компании",
+		CodeClaimNotFound:         "Заявка не найдена",
+		CodeClaimAlreadyPending:   "У компании уже есть заявка на это место",
+		CodeClaimAlreadyReviewed:  "Заявка уже рассмотрена",
+		CodeSuggestionNotFound:    "Предложение не найдено",
+		CodeSuggestionReviewed:    "Предложение уже рассмотрено",
+		CodeVersionNotFound:       "Версия не найдена",
+		CodeAchievementNotFound:   "Достижение не найдено",
+		CodeReviewNotFound:        "Отзыв не найден",
+		CodeReviewAlreadyExists:   "Вы уже оставили отзыв",
+		CodeReviewSelfVote:        "Нельзя голосовать за свой отзыв",
+		CodeReviewVoteExists:      "Вы уже отметили этот отзыв как полезный",
+		CodeVoteNotFound:          "Голос не найден",
+		CodeReviewReplyForbidden:  "Ответить на отзыв может только компания-владелец",
+		CodeReviewReplyExists:     "Компания уже ответила на этот отзыв",
+		CodeReviewReplyNotFound:   "Ответ на отзыв не найден",
+		CodeBookmarkNotFound:      "Закладка не найдена",
+		CodeTagNotFound:           "Тег не найден",
+		CodeTagUnknown:            "Неизвестный тег",
+		CodeTagAlreadyExists:      "Тег уже существует",
+		CodeTagSlugInvalid:        "Slug может содержать только строчные латинские буквы, цифры и дефисы",
+		CodeTagParentInvalid:      "Родителем должен быть существующий тег верхнего уровня",
+		CodeTagCategoryWithParent: "У категории с дочерними тегами не может быть родителя",
+		CodeTagMergeSelf:          "Тег нельзя объединить с самим собой",
+		CodeTagMergeNotTopLevel:   "Категорию можно объединить только с тегом верхнего уровня",
+
+		CodeLocalityNotFound:      "Населенный пункт не найден",
+		CodeLocalityAlreadyExists: "Населенный пункт уже существует",
+		CodeLocalityParentInvalid: "Родителем должен быть существующий регион",
+		CodeCityNotFound:          "Город не найден",
+		CodeBoundaryTooShort:      "Граница должна состоять хотя бы из 3 точек",
+		CodeAddressNotFound:       "Адрес не найден",
+		CodeLocationIncomplete:    "Нужно указать и lat, и lng",
+		CodeLocationUnknown:       "Местоположение неизвестно, передайте lat и lng",
+
+		CodeFileNotProvided:        "Файл не передан",
+		CodeFileTooLarge:           "Файл слишком большой",
+		CodeFileNotFound:           "Файл не найден",
+		CodeUploadNotFound:         "Загруженное изображение не найдено",
+		CodeImageFormatUnsupported: "Поддерживаются только изображения JPEG и PNG",
+		CodeImageDimensionsInvalid: "Ширина и высота изображения должны быть от 16 до {max} пикселей",
+	},
+	rules: map[string]string{
+		"":          "Поле {field} не прошло проверку '{rule}'",
+		"required":  "Поле {field} обязательно",
+		"min":       "Поле {field} должно быть не меньше {param}",
+		"max":       "Поле {field} должно быть не больше {param}",
+		"len":       "Длина поля {field} должна быть {param}",
+		"gte":       "Поле {field} должно быть не меньше {param}",
+		"lte":       "Поле {field} должно быть не больше {param}",
+		"oneof":     "Поле {field} должно быть одним из: {param}",
+		"range":     "Поле {field} должно быть в диапазоне {param}",
+		"uuid":      "Поле {field} должно быть UUID",
+		"url":       "Поле {field} должно быть URL",
+		"latitude":  "Поле {field} должно быть широтой",
+		"longitude": "Поле {field} должно быть долготой",
+		"datetime":  "Поле {field} должно быть датой в формате {param}",
+		"timezone":  "Поле {field} должно быть временем с часовым поясом в формате {param}",
+		"type":      "Поле {field} должно иметь тип {param}",
+		"ne":        "Поле {field} не может быть {param}",
+		"nefield":   "Поле {field} должно отличаться от {param}",
+	},
+}
@@ -0,0 +1,226 @@
+package errs
+
+import "net/http"
+
+const (
+	CodeInternal                 Code = "internal"
+	CodeAuthorizationError       Code = "authorization_error"
+	CodeAuthorizationUnavailable Code = "authorization_unavailable"
+	CodeVKMapsUnavailable        Code = "vk_maps_unavailable"
+	CodeUpstreamFailed           Code = "upstream_failed"
+	CodeNotFound                 Code = "not_found"
+	CodeConflict                 Code = "conflict"
+
+	CodeInvalidRequest     Code = "invalid_request"
+	CodeValidationFailed   Code = "validation_failed"
+	CodeRequestBodyMissing Code = "request_body_missing"
+	CodeInvalidJSON        Code = "invalid_json"
+	CodeInvalidQuery       Code = "invalid_query"
+	CodeInvalidURI         Code = "invalid_uri"
+
+	CodeUnauthorized        Code = "unauthorized"
+	CodeCredentialsExpired  Code = "credentials_expired"
+	CodeCredentialsRevoked  Code = "credentials_revoked"
+	CodeRefreshTokenInvalid Code = "refresh_token_invalid"
+	CodeSessionRevoked      Code = "session_revoked"
+
+	CodeAccessDenied                Code = "access_denied"
+	CodeDeletedAccessDenied         Code = "deleted_access_denied"
+	CodeAPIKeyScopeDenied           Code = "api_key_scope_denied"
+	CodeAPIKeyCompanyDenied         Code = "api_key_company_denied"
+	CodeSessionLaunchParamsRequired Code = "session_launch_params_required"
+	CodeCompanyActionForbidden      Code = "company_action_forbidden"
+	CodeEventCompanyForbidden       Code = "event_company_forbidden"
+	CodeAdminRoleSelfRevoke         Code = "admin_role_self_revoke"
+
+	CodeTooManyRequests     Code = "too_many_requests"
+	CodeAPIKeyQuotaExceeded Code = "api_key_quota_exceeded"
+
+	CodeMethodNotFound      Code = "method_not_found"
+	CodeUserNotFound        Code = "user_not_found"
+	CodeRoleNotAssigned     Code = "role_not_assigned"
+	CodeRoleAlreadyAssigned Code = "role_already_assigned"
+	CodeSessionNotFound     Code = "session_not_found"
+	CodeAPIKeyNotFound      Code = "api_key_not_found"
+	CodeAPIKeyRevoked       Code = "api_key_already_revoked"
+
+	CodePlaceNotFound      Code = "place_not_found"
+	CodeEventNotFound      Code = "event_not_found"
+	CodeRouteNotFound      Code = "route_not_found"
+	CodeEntityDeleted      Code = "entity_already_deleted"
+	CodeEntityNotDeleted   Code = "entity_not_deleted"
+	CodePlaceMergeSelf     Code = "place_merge_self"
+	CodePlaceAlreadyOwned  Code = "place_already_owned"
+	CodePriceRangeInvalid  Code = "price_range_invalid"
+	CodeHoursExceptionsDup Code = "hours_exceptions_duplicate"
+	CodeDateRangeInvalid   Code = "date_range_invalid"
+	CodeRSVPNotFound       Code = "rsvp_not_found"
+
+	CodeCompanyNotFound       Code = "company_not_found"
+	CodeCompanyNotOwned       Code = "company_not_owned"
+	CodeCompanyMemberNotFound Code = "company_member_not_found"
+	CodeCompanyMemberExists   Code = "company_member_already_exists"
+	CodeClaimNotFound         Code = "claim_not_found"
+	CodeClaimAlreadyPending   Code = "claim_already_pending"
+	CodeClaimAlreadyReviewed  Code = "claim_already_reviewed"
+	CodeSuggestionNotFound    Code = "suggestion_not_found"
+	CodeSuggestionReviewed    Code = "suggestion_already_reviewed"
+	CodeVersionNotFound       Code = "version_not_found"
+	CodeAchievementNotFound   Code = "achievement_not_found"
+	CodeReviewNotFound        Code = "review_not_found"
+	CodeReviewAlreadyExists   Code = "review_already_exists"
+	CodeReviewSelfVote        Code = "review_self_vote"
+	CodeReviewVoteExists      Code = "review_vote_already_exists"
+	CodeVoteNotFound          Code = "vote_not_found"
+	CodeReviewReplyForbidden  Code = "review_reply_forbidden"
+	CodeReviewReplyExists     Code = "review_reply_already_exists"
+	CodeReviewReplyNotFound   Code = "review_reply_not_found"
+	CodeBookmarkNotFound      Code = "bookmark_not_found"
+	CodeTagNotFound           Code = "tag_not_found"
+	CodeTagUnknown            Code = "tag_unknown"
+	CodeTagAlreadyExists      Code = "tag_already_exists"
+	CodeTagSlugInvalid        Code = "tag_slug_invalid"
+	CodeTagParentInvalid      Code = "tag_parent_invalid"
+	CodeTagCategoryWithParent Code = "tag_category_with_parent"
+	CodeTagMergeSelf          Code = "tag_merge_self"
+	CodeTagMergeNotTopLevel   Code = "tag_merge_target_not_top_level"
+
+	CodeLocalityNotFound      Code = "locality_not_found"
+	CodeLocalityAlreadyExists Code = "locality_already_exists"
+	CodeLocalityParentInvalid Code = "locality_parent_invalid"
+	CodeCityNotFound          Code = "city_not_found"
+	CodeBoundaryTooShort      Code = "boundary_too_short"
+	CodeAddressNotFound       Code = "address_not_found"
+	CodeLocationIncomplete    Code = "location_incomplete"
+	CodeLocationUnknown       Code = "location_unknown"
+
+	CodeFileNotProvided        Code = "file_not_provided"
+	CodeFileTooLarge           Code = "file_too_large"
+	CodeFileNotFound           Code = "file_not_found"
+	CodeUploadNotFound         Code = "upload_not_found"
+	CodeImageFormatUnsupported Code = "image_format_unsupported"
+	CodeImageDimensionsInvalid Code = "image_dimensions_invalid"
+)
+
+// definitions HTTP-статусы и английские сообщения кодов ошибок. {name} в сообщении заменяется параметром ошибки
+var definitions = map[Code]definition{
+	CodeInternal:                 {http.StatusInternalServerError, "Internal server error"},
+	CodeAuthorizationError:       {http.StatusInternalServerError, "An unknown error occurred while checking authorization"},
+	CodeAuthorizationUnavailable: {http.StatusServiceUnavailable, "Authorization is temporarily unavailable, try again later"},
+	CodeVKMapsUnavailable:        {http.StatusBadGateway, "Internal server error on vk maps"},
+	CodeUpstreamFailed:           {http.StatusBadGateway, "External service error"},
+	CodeNotFound:                 {http.StatusNotFound, "Not found"},
+	CodeConflict:                 {http.StatusConflict, "Already exists"},
+
+	CodeInvalidRequest:     {http.StatusBadRequest, "Invalid request: {error}"},
+	CodeValidationFailed:   {http.StatusBadRequest, "Request validation failed"},
+	CodeRequestBodyMissing: {http.StatusBadRequest, "Request body not provided"},
+	CodeInvalidJSON:        {http.StatusBadRequest, "Invalid json object"},
+	CodeInvalidQuery:       {http.StatusBadRequest, "Invalid type query variable"},
+	CodeInvalidURI:         {http.StatusBadRequest, "Invalid type uri variable"},
+
+	CodeUnauthorized:        {http.StatusUnauthorized, "Authorization failed"},
+	CodeCredentialsExpired:  {http.StatusUnauthorized, "Authorization failed, signature expired"},
+	CodeCredentialsRevoked:  {http.StatusUnauthorized, "Authorization failed, credentials revoked"},
+	CodeRefreshTokenInvalid: {http.StatusUnauthorized, "Invalid refresh token"},
+	CodeSessionRevoked:      {http.StatusUnauthorized, "Session revoked"},
+
+	CodeAccessDenied:                {http.StatusForbidden, "You don't have access to this method"},
+	CodeDeletedAccessDenied:         {http.StatusForbidden, "You don't have access to deleted records"},
+	CodeAPIKeyScopeDenied:           {http.StatusForbidden, "API key doesn't have access to this method"},
+	CodeAPIKeyCompanyDenied:         {http.StatusForbidden, "API key is restricted to another company"},
+	CodeSessionLaunchParamsRequired: {http.StatusForbidden, "Sessions can only be created with VK launch params"},
+	CodeCompanyActionForbidden:      {http.StatusForbidden, "You can't act on behalf of this company"},
+	CodeEventCompanyForbidden:       {http.StatusForbidden, "You can't create an event on behalf of this company"},
+	CodeAdminRoleSelfRevoke:         {http.StatusForbidden, "You can't revoke your own admin role"},
+
+	CodeTooManyRequests:     {http.StatusTooManyRequests, "Too many requests"},
+	CodeAPIKeyQuotaExceeded: {http.StatusTooManyRequests, "API key daily quota exceeded"},
+
+	CodeMethodNotFound:      {http.StatusNotFound, "Invalid method path"},
+	CodeUserNotFound:        {http.StatusNotFound, "User not found"},
+	CodeRoleNotAssigned:     {http.StatusNotFound, "The user doesn't have this role"},
+	CodeRoleAlreadyAssigned: {http.StatusConflict, "The user already has this role"},
+	CodeSessionNotFound:     {http.StatusNotFound, "Session not found"},
+	CodeAPIKeyNotFound:      {http.StatusNotFound, "API key not found"},
+	CodeAPIKeyRevoked:       {http.StatusConflict, "API key is already revoked"},
+
+	CodePlaceNotFound:      {http.StatusNotFound, "Place not found"},
+	CodeEventNotFound:      {http.StatusNotFound, "Event not found"},
+	CodeRouteNotFound:      {http.StatusNotFound, "Route not found"},
+	CodeEntityDeleted:      {http.StatusConflict, "The {entity_type} is already deleted"},
+	CodeEntityNotDeleted:   {http.StatusConflict, "The {entity_type} is not deleted"},
+	CodePlaceMergeSelf:     {http.StatusBadRequest, "Place can't be merged with itself"},
+	CodePlaceAlreadyOwned:  {http.StatusConflict, "Place already belongs to this company"},
+	CodePriceRangeInvalid:  {http.StatusBadRequest, "Price max must not be less than price min"},
+	CodeHoursExceptionsDup: {http.StatusBadRequest, "Hours exception dates must be unique"},
+	CodeDateRangeInvalid:   {http.StatusBadRequest, "Date range must be from 1 to 62 days"},
+	CodeRSVPNotFound:       {http.StatusNotFound, "RSVP not found"},
+
+	CodeCompanyNotFound:       {http.StatusNotFound, "Company not found"},
+	CodeCompanyNotOwned:       {http.StatusBadRequest, "The company doesn't belong to the user"},
+	CodeCompanyMemberNotFound: {http.StatusNotFound, "The user is not a member of this company"},
+	CodeCompanyMemberExists:   {http.StatusConflict, "The user is already a member of this company"},
+	CodeClaimNotFound:         {http.StatusNotFound, "Claim not found"},
+	CodeClaimAlreadyPending:   {http.StatusConflict, "Company already has a pending claim for this place"},
+	CodeClaimAlreadyReviewed:  {http.StatusConflict, "Claim is already reviewed"},
+	CodeSuggestionNotFound:    {http.StatusNotFound, "Suggestion not found"},
+	CodeSuggestionReviewed:    {http.StatusConflict, "Suggestion is already reviewed"},
+	CodeVersionNotFound:       {http.StatusNotFound, "Version not found"},
+	CodeAchievementNotFound:   {http.StatusNotFound, "Achievement not found"},
+	CodeReviewNotFound:        {http.StatusNotFound, "Review not found"},
+	CodeReviewAlreadyExists:   {http.StatusConflict, "You have already added a review to this {entity_type}"},
+	CodeReviewSelfVote:        {http.StatusForbidden, "You can't vote for your own review"},
+	CodeReviewVoteExists:      {http.StatusConflict, "You have already marked this review as helpful"},
+	CodeVoteNotFound:          {http.StatusNotFound, "Vote not found"},
+	CodeReviewReplyForbidden:  {http.StatusForbidden, "Only members of the owning company can reply to this review"},
+	CodeReviewReplyExists:     {http.StatusConflict, "The company has already replied to this review"},
+	CodeReviewReplyNotFound:   {http.StatusNotFound, "Review reply not found"},
+	CodeBookmarkNotFound:      {http.StatusNotFound, "Bookmark not found"},
+	CodeTagNotFound:           {http.StatusNotFound, "Tag not found"},
+	CodeTagUnknown:            {http.StatusBadRequest, "Unknown tag"},
+	CodeTagAlreadyExists:      {http.StatusConflict, "Tag already exists"},
+	CodeTagSlugInvalid:        {http.StatusBadRequest, "Slug may contain only lowercase latin letters, digits and hyphens"},
+	CodeTagParentInvalid:      {http.StatusBadRequest, "Parent must be an existing top-level tag"},
+	CodeTagCategoryWithParent: {http.StatusBadRequest, "Category with child tags can't have a parent"},
+	CodeTagMergeSelf:          {http.StatusBadRequest, "Tag can't be merged into itself"},
+	CodeTagMergeNotTopLevel:   {http.StatusBadRequest, "Category can only be merged into a top-level tag"},
+
+	CodeLocalityNotFound:      {http.StatusNotFound, "Locality not found"},
+	CodeLocalityAlreadyExists: {http.StatusConflict, "Locality already exists"},
+	CodeLocalityParentInvalid: {http.StatusBadRequest, "Parent must be an existing region"},
+	CodeCityNotFound:          {http.StatusNotFound, "City not found"},
+	CodeBoundaryTooShort:      {http.StatusBadRequest, "Boundary must have at least 3 points"},
+	CodeAddressNotFound:       {http.StatusNotFound, "Address not found"},
+	CodeLocationIncomplete:    {http.StatusBadRequest, "Both lat and lng are required"},
+	CodeLocationUnknown:       {http.StatusBadRequest, "Location is unknown, pass lat and lng"},
+
+	CodeFileNotProvided:        {http.StatusBadRequest, "File not provided"},
+	CodeFileTooLarge:           {http.StatusBadRequest, "File is too large"},
+	CodeFileNotFound:           {http.StatusNotFound, "File not found"},
+	CodeUploadNotFound:         {http.StatusBadRequest, "Uploaded image not found"},
+	CodeImageFormatUnsupported: {http.StatusBadRequest, "Only JPEG and PNG images are supported"},
+	CodeImageDimensionsInvalid: {http.StatusBadRequest, "Image width and height must be between 16 and {max} pixels"},
+}
+
+// ruleMessages английские сообщения правил проверки полей, "" - для остальных правил
+var ruleMessages = map[string]string{
+	"":          "{field} failed on the '{rule}' rule",
+	"required":  "{field} is required",
+	"min":       "{field} must be at least {param}",
+	"max":       "{field} must be at most {param}",
+	"len":       "{field} must have length {param}",
+	"gte":       "{field} must be at least {param}",
+	"lte":       "{field} must be at most {param}",
+	"oneof":     "{field} must be one of: {param}",
+	"range":     "{field} must be in the range {param}",
+	"uuid":      "{field} must be a UUID",
+	"url":       "{field} must be a URL",
+	"latitude":  "{field} must be a latitude",
+	"longitude": "{field} must be a longitude",
+	"datetime":  "{field} must be a date in the {param} format",
+	"timezone":  "{field} must be a time with timezone in the {param} format",
+	"type":      "{field} must be {param}",
+	"ne":        "{field} must not be {param}",
+	"nefield":   "{field} must differ from {param}",
+}
@@ -0,0 +1,144 @@
+package errs
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type (
+	// Code стабильный код ошибки приложения. В отличие от сообщения не меняется и не переводится
+	Code string
+
+	// Error ошибка API. Message - сообщение на языке запроса, Params - значения, подставленные в сообщение,
+	// Details - поля запроса, не прошедшие проверку
+	Error struct {
+		Status    int            `json:"status" example:"404"`
+		Code      Code           `json:"code" example:"place_not_found"`
+		Message   string         `json:"message" example:"Place not found"`
+		Params    map[string]any `json:"params,omitempty" swaggertype:"object"`
+		Details   []FieldError   `json:"details,omitempty"`
+		RequestID string         `json:"request_id,omitempty" example:"5f0c6f4e-8d7a-4c1e-9a43-2b1d7c9e0f11"`
+
+		cause error
+	}
+
+	// FieldError поле запроса, не прошедшее проверку. Rule и Param - правило проверки и его параметр,
+	// например min и 6
+	FieldError struct {
+		Field   string `json:"field" example:"name"`
+		Rule    string `json:"rule" example:"min"`
+		Param   string `json:"param,omitempty" example:"6"`
+		Message string `json:"message" example:"name must be at least 6"`
+	}
+
+	definition struct {
+		status  int
+		message string
+	}
+)
+
+// ErrUpstream отмечает сбой внешнего сервиса, на такие ошибки API отвечает 502
+var ErrUpstream = errors.New("upstream service failed")
+
+// New создает ошибку с кодом code. Сообщение - английское сообщение кода, оно переводится перед ответом.
+func New(code Code) *Error {
+	d, ok := definitions[code]
+	if !ok {
+		d = definition{status: http.StatusInternalServerError, message: string(code)}
+	}
+
+	return &Error{
+		Status:  d.status,
+		Code:    code,
+		Message: d.message,
+	}
+}
+
+// Invalid создает ошибку проверки одного поля запроса.
+func Invalid(field, rule, param string) *Error {
+	return New(CodeValidationFailed).WithDetails(FieldError{Field: field, Rule: rule, Param: param})
+}
+
+// NotFound заменяет sql.ErrNoRows ошибкой с кодом code. Остальные ошибки возвращаются как есть.
+func NotFound(err error, code Code) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return New(code).Wrap(err)
+	}
+
+	return err
+}
+
+// Upstream отмечает err как сбой внешнего сервиса.
+func Upstream(err error) error {
+	return fmt.Errorf("%w: %w", ErrUpstream, err)
+}
+
+// WithParam подставляет значение value вместо {name} в сообщение.
+func (e *Error) WithParam(name string, value any) *Error {
+	if e.Params == nil {
+		e.Params = make(map[string]any, 1)
+	}
+	e.Params[name] = value
+	e.Message = format(e.Message, e.Params)
+
+	return e
+}
+
+// WithDetails добавляет поля запроса, не прошедшие проверку.
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	for _, detail := range details {
+		detail.Message = format(ruleMessage(ruleMessages, detail.Rule), detail.params())
+		e.Details = append(e.Details, detail)
+	}
+
+	return e
+}
+
+// Wrap сохраняет причину ошибки. Причина пишется в лог и не попадает в ответ.
+func (e *Error) Wrap(cause error) *Error {
+	e.cause = cause
+
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return string(e.Code) + ": " + e.cause.Error()
+	}
+
+	return string(e.Code) + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+func (d FieldError) params() map[string]any {
+	return map[string]any{"field": d.Field, "rule": d.Rule, "param": d.Param}
+}
+
+// format подставляет params вместо {name} в message.
+func format(message string, params map[string]any) string {
+	if len(params) == 0 {
+		return message
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(message)
+}
+
+// ruleMessage возвращает сообщение для правила проверки или общее сообщение, если для правила его нет.
+func ruleMessage(messages map[string]string, rule string) string {
+	if message, ok := messages[rule]; ok {
+		return message
+	}
+
+	return messages[""]
+}
@@ -1,15 +1,13 @@
 package handlers
 
 import (
-	"database/sql"
-	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/ShpullRequest/backend/internal/errs"
 	"github.com/ShpullRequest/backend/internal/models"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"go.uber.org/zap"
 )
 
 // NewAchievement
@@ -21,64 +19,44 @@ import (
 // @Param Authorization header string true "Строка авторизации"
 // @Param name body string true "Название достижения (минимум 6 символов)"
 // @Param description body string true "Описание достижения (минимум 10 символов)"
-// @Param icon body string true "Ссылка на иконку достижения (должна быть валидной URL)"
+// @Param icon body string true "Ссылка на иконку достижения (валидный URL или идентификатор загруженного изображения)"
 // @Param coins body integer true "Количество монет, присваиваемых за достижение"
 // @Success 200 {object} models.Achievements
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /achievements [post]
-func (hs *handlerService) NewAchievement(ctx *gin.Context) {
+func (hs *handlerService) NewAchievement(ctx *gin.Context) error {
 	var params struct {
 		Name        string `json:"name" binding:"required,min=6"`
 		Description string `json:"description" binding:"required,min=10"`
-		Icon        string `json:"icon" binding:"required,url"`
+		Icon        string `json:"icon" binding:"required,url|uuid"`
 		Coins       int    `json:"coins" binding:"required"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
 
-	user, err := hs.pg.GetUserByVkID(ctx, int64(hs.GetVKParams(ctx).VkUserID))
-
+	icon, err := hs.resolveImageRefs(ctx, params.Icon)
 	if err != nil {
-		hs.logger.Error("Error get user by vk id", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	if !user.IsAdmin {
-		ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You don't have access to this method")))
-		ctx.Abort()
-
-		return
+		return err
 	}
 
 	achievement, err := hs.pg.NewAchievement(ctx, models.Achievements{
 		Name:        params.Name,
 		Description: params.Description,
-		Icon:        params.Icon,
+		Icon:        icon[0],
 		Coins:       params.Coins,
 	})
 
 	if err != nil {
-		hs.logger.Error("Error new achievement", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		return fmt.Errorf("new achievement: %w", err)
 	}
 
 	ctx.JSON(http.StatusOK, models.NewResponse(achievement))
-	ctx.Abort()
+
+	return nil
 }
 
 // GetAchievement
@@ -88,38 +66,32 @@ func (hs *handlerService) NewAchievement(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param achievementId path string true "Уникальный идентификатор достижения (в формате UUID)"
 // @Success 200 {object} models.Achievements
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /achievements/{achievementId} [get]
-func (hs *handlerService) GetAchievement(ctx *gin.Context) {
+func (hs *handlerService) GetAchievement(ctx *gin.Context) error {
 	var params struct {
-		AchievementID string `uri:"achievementId" blinding:"required,uuid"`
+		AchievementID string `uri:"achievementId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
 	}
 
 	achievementID, _ := uuid.Parse(params.AchievementID)
 	achievement, err := hs.pg.GetAchievementByID(ctx, achievementID)
 
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		hs.logger.Error("Error get achievement", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+	if err != nil {
+		return errs.NotFound(err, errs.CodeAchievementNotFound)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(achievement))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, achievement)))
+
+	return nil
 }
 
 // EditAchievement
@@ -132,7 +104,8 @@ func (hs *handlerService) GetAchievement(ctx *gin.Context) {
 // @Param achievementId path string true "Уникальный идентификатор достижения (в формате UUID)"
 // @Param name body string false "Название достижения (минимум 6 символов)"
 // @Param description body string false "Описание достижения (минимум 10 символов)"
-// @Param icon body string false "Ссылка на иконку достижения (должна быть в формате URL)"
+// @Param translations body models.Translations false "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык"
+// @Param icon body string false "Ссылка на иконку достижения (валидный URL или идентификатор загруженного изображения)"
 // @Param coins body int false "Количество монет за достижение"
 // @Success 200 {object} models.Achievements
 // @Failure 400 {object} models.ErrorResponse
@@ -140,61 +113,31 @@ func (hs *handlerService) GetAchievement(ctx *gin.Context) {
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /achievements/{achievementId} [patch]
-func (hs *handlerService) EditAchievement(ctx *gin.Context) {
+func (hs *handlerService) EditAchievement(ctx *gin.Context) error {
 	var paramsURI struct {
 		AchievementID string `uri:"achievementId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
+		return err
 	}
 
 	var params struct {
-		Name        string `json:"name" binding:"omitempty,min=6"`
-		Description string `json:"description" binding:"omitempty,min=10"`
-		Icon        string `json:"icon" binding:"omitempty,url"`
-		Coins       int    `json:"coins"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	user, err := hs.pg.GetUserByVkID(ctx, int64(hs.GetVKParams(ctx).VkUserID))
-	if err != nil {
-		hs.logger.Error("Error get user by vk id", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		Name         string              `json:"name" binding:"omitempty,min=6"`
+		Description  string              `json:"description" binding:"omitempty,min=10"`
+		Translations models.Translations `json:"translations" binding:"omitempty,dive,keys,len=2,ne=ru,endkeys"`
+		Icon         string              `json:"icon" binding:"omitempty,url|uuid"`
+		Coins        int                 `json:"coins"`
 	}
 
-	if !user.IsAdmin {
-		ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You don't have access to this method")))
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
 
 	achievementID, _ := uuid.Parse(paramsURI.AchievementID)
 	achievement, err := hs.pg.GetAchievementByID(ctx, achievementID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, models.NewErrorResponse(errs.NewNotFound("Achievement not found")))
-		} else {
-			hs.logger.Error("Error get event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
+		return errs.NotFound(err, errs.CodeAchievementNotFound)
 	}
 
 	if params.Name != "" {
@@ -203,24 +146,28 @@ func (hs *handlerService) EditAchievement(ctx *gin.Context) {
 	if params.Description != "" {
 		achievement.Description = params.Description
 	}
+	if params.Translations != nil {
+		achievement.Translations = achievement.Translations.Merge(params.Translations)
+	}
 	if params.Icon != "" {
-		achievement.Icon = params.Icon
+		icon, err := hs.resolveImageRefs(ctx, params.Icon)
+		if err != nil {
+			return err
+		}
+
+		achievement.Icon = icon[0]
 	}
 	if params.Coins != 0 {
 		achievement.Coins = params.Coins
 	}
 
 	if err = hs.pg.SaveAchievement(ctx, achievement); err != nil {
-		hs.logger.Error("Error save achievement", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		return fmt.Errorf("save achievement: %w", err)
 	}
 
 	ctx.JSON(http.StatusOK, models.NewResponse(achievement))
-	ctx.Abort()
+
+	return nil
 }
 
 // GetAllAchievements
@@ -230,20 +177,17 @@ func (hs *handlerService) EditAchievement(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Success 200 {object} []models.Achievements
 // @Failure 500 {object} models.ErrorResponse
 // @Router /achievements [get]
-func (hs *handlerService) GetAllAchievements(ctx *gin.Context) {
+func (hs *handlerService) GetAllAchievements(ctx *gin.Context) error {
 	achievements, err := hs.pg.GetAllAchievements(ctx)
 	if err != nil {
-		hs.logger.Error("Error get all achievements", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		return fmt.Errorf("get all achievements: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(achievements))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, achievements)))
+
+	return nil
 }
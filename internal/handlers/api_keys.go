@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetAllAPIKeys
+// @Summary Получить ключи API
+// @Description Возвращает все ключи партнерского API со счетчиками использования. Доступно только администраторам.
+// @ID get-all-api-keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Success 200 {object} []models.APIKey
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api-keys [get]
+func (hs *handlerService) GetAllAPIKeys(ctx *gin.Context) error {
+	keys, err := hs.pg.GetAllAPIKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("get all api keys: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(keys))
+
+	return nil
+}
+
+// NewAPIKey
+// @Summary Создать ключ API
+// @Description Создает ключ партнерского API от имени пользователя. Открытое значение ключа возвращается только в этом ответе.
+// @Description Ключ, привязанный к компании, может изменять только объекты этой компании. Доступно только администраторам.
+// @ID create-api-key
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param vk_id body integer true "VK ID пользователя, от имени которого работает ключ"
+// @Param name body string true "Название ключа (минимум 3 символа)"
+// @Param scopes body []string true "Области действия: read:places, read:events, read:routes, write:places, write:events, write:routes"
+// @Param company_id body string false "Компания, которой ограничены изменения (в формате UUID)"
+// @Param daily_quota body integer false "Дневная квота запросов, 0 - без ограничений"
+// @Success 200 {object} models.APIKeyCreated
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api-keys [post]
+func (hs *handlerService) NewAPIKey(ctx *gin.Context) error {
+	var params struct {
+		VkID       int64    `json:"vk_id" binding:"required"`
+		Name       string   `json:"name" binding:"required,min=3,max=128"`
+		Scopes     []string `json:"scopes" binding:"required,min=1,dive,oneof=read:places read:events read:routes write:places write:events write:routes"`
+		CompanyID  string   `json:"company_id" binding:"omitempty,uuid"`
+		DailyQuota int      `json:"daily_quota" binding:"min=0"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	user, err := hs.pg.GetUserByVkID(ctx, params.VkID)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeUserNotFound)
+	}
+
+	var companyID *uuid.UUID
+	if params.CompanyID != "" {
+		id, _ := uuid.Parse(params.CompanyID)
+
+		company, err := hs.pg.GetCompanyByID(ctx, id)
+		if err != nil {
+			return errs.NotFound(err, errs.CodeCompanyNotFound)
+		}
+
+		if company.UserID != user.ID {
+			return errs.New(errs.CodeCompanyNotOwned)
+		}
+
+		companyID = &company.ID
+	}
+
+	actor, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return fmt.Errorf("generate api key: %w", err)
+	}
+
+	apiKey, err := hs.pg.NewAPIKey(ctx, models.APIKey{
+		UserID:     user.ID,
+		VkID:       user.VkID,
+		CreatedBy:  &actor.ID,
+		Name:       params.Name,
+		Prefix:     prefix,
+		KeyHash:    hash,
+		Scopes:     params.Scopes,
+		CompanyID:  companyID,
+		DailyQuota: params.DailyQuota,
+	})
+	if err != nil {
+		return fmt.Errorf("new api key: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(models.APIKeyCreated{APIKey: *apiKey, Key: key}))
+
+	return nil
+}
+
+// EditAPIKey
+// @Summary Редактировать ключ API
+// @Description Изменяет название, области действия или дневную квоту ключа. Доступно только администраторам.
+// @ID edit-api-key
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param keyId path string true "Уникальный идентификатор ключа (в формате UUID)"
+// @Param name body string false "Название ключа (минимум 3 символа)"
+// @Param scopes body []string false "Области действия"
+// @Param daily_quota body integer false "Дневная квота запросов, 0 - без ограничений"
+// @Success 200 {object} models.APIKey
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api-keys/{keyId} [patch]
+func (hs *handlerService) EditAPIKey(ctx *gin.Context) error {
+	var params struct {
+		Name       string   `json:"name" binding:"omitempty,min=3,max=128"`
+		Scopes     []string `json:"scopes" binding:"omitempty,min=1,dive,oneof=read:places read:events read:routes write:places write:events write:routes"`
+		DailyQuota *int     `json:"daily_quota" binding:"omitempty,min=0"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	apiKey, err := hs.apiKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	if params.Name != "" {
+		apiKey.Name = params.Name
+	}
+	if len(params.Scopes) > 0 {
+		apiKey.Scopes = params.Scopes
+	}
+	if params.DailyQuota != nil {
+		apiKey.DailyQuota = *params.DailyQuota
+	}
+
+	if err := hs.pg.SaveAPIKey(ctx, apiKey); err != nil {
+		return fmt.Errorf("save api key: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(apiKey))
+
+	return nil
+}
+
+// RevokeAPIKey
+// @Summary Отозвать ключ API
+// @Description Отзывает ключ, после чего запросы с ним отклоняются. Доступно только администраторам.
+// @ID revoke-api-key
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param keyId path string true "Уникальный идентификатор ключа (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api-keys/{keyId} [delete]
+func (hs *handlerService) RevokeAPIKey(ctx *gin.Context) error {
+	apiKey, err := hs.apiKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := hs.pg.RevokeAPIKey(ctx, apiKey.ID)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+
+	if !revoked {
+		return errs.New(errs.CodeAPIKeyRevoked)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// GetAPIKeyUsage
+// @Summary Получить использование ключа API
+// @Description Возвращает количество запросов по ключу за последние дни. Доступно только администраторам.
+// @ID get-api-key-usage
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param keyId path string true "Уникальный идентификатор ключа (в формате UUID)"
+// @Param days query integer false "Количество дней (по умолчанию 30, максимум 365)"
+// @Success 200 {object} []models.APIKeyUsage
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /api-keys/{keyId}/usage [get]
+func (hs *handlerService) GetAPIKeyUsage(ctx *gin.Context) error {
+	var params struct {
+		Days int `form:"days" binding:"omitempty,min=1,max=365"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	if params.Days == 0 {
+		params.Days = 30
+	}
+
+	apiKey, err := hs.apiKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	usage, err := hs.pg.GetAPIKeyUsage(ctx, apiKey.ID, params.Days)
+	if err != nil {
+		return fmt.Errorf("get api key usage: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(usage))
+
+	return nil
+}
+
+func (hs *handlerService) apiKey(ctx *gin.Context) (*models.APIKey, error) {
+	keyID, err := hs.uuidParam(ctx, "keyId")
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := hs.pg.GetAPIKey(ctx, keyID)
+	if err != nil {
+		return nil, errs.NotFound(err, errs.CodeAPIKeyNotFound)
+	}
+
+	return apiKey, nil
+}
+
+// requireAPIKeyScope ограничивает запросы по ключу API местами, событиями и маршрутами:
+// чтение требует области read:<ресурс>, изменение - write:<ресурс>.
+func (hs *handlerService) requireAPIKeyScope(ctx *gin.Context) error {
+	principal := hs.GetPrincipal(ctx)
+	if principal == nil || principal.Method != auth.MethodAPIKey || ctx.FullPath() == "" {
+		return nil
+	}
+
+	access := "write"
+	if ctx.Request.Method == http.MethodGet {
+		access = "read"
+	}
+	scope := access + ":" + strings.Split(strings.Trim(ctx.FullPath(), "/"), "/")[0]
+
+	if _, known := models.ScopePermissions[scope]; !known || !slices.Contains(principal.Scopes, scope) {
+		return errs.New(errs.CodeAPIKeyScopeDenied)
+	}
+
+	return nil
+}
+
+// checkAPIKeyCompany запрещает ключу, привязанному к компании, изменять объекты других компаний и объекты без компании.
+func (hs *handlerService) checkAPIKeyCompany(ctx *gin.Context, companyID *uuid.UUID) error {
+	principal := hs.GetPrincipal(ctx)
+	if principal.Method != auth.MethodAPIKey || principal.CompanyID == nil {
+		return nil
+	}
+
+	if companyID == nil || *companyID != *principal.CompanyID {
+		return errs.New(errs.CodeAPIKeyCompanyDenied)
+	}
+
+	return nil
+}
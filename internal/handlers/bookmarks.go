@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type entityRefParams struct {
+	EntityType string `json:"entity_type" binding:"required,oneof=place event route"`
+	EntityID   string `json:"entity_id" binding:"required,uuid"`
+}
+
+// GetBookmarks
+// @Summary Получить закладки
+// @Description Возвращает закладки текущего пользователя, сначала новые.
+// @ID get-bookmarks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Success 200 {object} []models.Bookmark
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /bookmarks [get]
+func (hs *handlerService) GetBookmarks(ctx *gin.Context) error {
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	bookmarks, err := hs.pg.GetUserBookmarks(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get bookmarks: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(bookmarks))
+
+	return nil
+}
+
+// NewBookmark
+// @Summary Добавить в закладки
+// @Description Добавляет место, событие или маршрут в закладки текущего пользователя. Повторное добавление не создает новую закладку.
+// @ID create-bookmark
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param entity_type body string true "Тип объекта (place, event, route)"
+// @Param entity_id body string true "Уникальный идентификатор объекта (в формате UUID)"
+// @Success 200 {object} models.Bookmark
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /bookmarks [post]
+func (hs *handlerService) NewBookmark(ctx *gin.Context) error {
+	var params entityRefParams
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	entityID, err := hs.entityExists(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	bookmark, err := hs.pg.NewBookmark(ctx, models.Bookmark{
+		UserID:     user.ID,
+		EntityType: params.EntityType,
+		EntityID:   entityID,
+	})
+	if err != nil {
+		return fmt.Errorf("new bookmark: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(bookmark))
+
+	return nil
+}
+
+// DeleteBookmark
+// @Summary Удалить из закладок
+// @Description Удаляет место, событие или маршрут из закладок текущего пользователя.
+// @ID delete-bookmark
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param entityType path string true "Тип объекта (place, event, route)"
+// @Param entityId path string true "Уникальный идентификатор объекта (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /bookmarks/{entityType}/{entityId} [delete]
+func (hs *handlerService) DeleteBookmark(ctx *gin.Context) error {
+	var params struct {
+		EntityType string `uri:"entityType" binding:"required,oneof=place event route"`
+		EntityID   string `uri:"entityId" binding:"required,uuid"`
+	}
+
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	entityID, _ := uuid.Parse(params.EntityID)
+	deleted, err := hs.pg.DeleteBookmark(ctx, user.ID, params.EntityType, entityID)
+	if err != nil {
+		return fmt.Errorf("delete bookmark: %w", err)
+	}
+
+	if !deleted {
+		return errs.New(errs.CodeBookmarkNotFound)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// GetCheckins
+// @Summary Получить отметки о посещении
+// @Description Возвращает отметки о посещении текущего пользователя, сначала новые.
+// @ID get-checkins
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Success 200 {object} []models.Checkin
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /checkins [get]
+func (hs *handlerService) GetCheckins(ctx *gin.Context) error {
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkins, err := hs.pg.GetUserCheckins(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get checkins: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(checkins))
+
+	return nil
+}
+
+// NewCheckin
+// @Summary Отметиться в месте, на событии или маршруте
+// @Description Сохраняет отметку о посещении объекта текущим пользователем.
+// @ID create-checkin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param entity_type body string true "Тип объекта (place, event, route)"
+// @Param entity_id body string true "Уникальный идентификатор объекта (в формате UUID)"
+// @Success 200 {object} models.Checkin
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /checkins [post]
+func (hs *handlerService) NewCheckin(ctx *gin.Context) error {
+	var params entityRefParams
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	entityID, err := hs.entityExists(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkin, err := hs.pg.NewCheckin(ctx, models.Checkin{
+		UserID:     user.ID,
+		EntityType: params.EntityType,
+		EntityID:   entityID,
+	})
+	if err != nil {
+		return fmt.Errorf("new checkin: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(checkin))
+
+	return nil
+}
+
+// entityExists проверяет, что объект, на который ссылается запрос, существует и не удален.
+func (hs *handlerService) entityExists(ctx *gin.Context, params entityRefParams) (uuid.UUID, error) {
+	entityID, _ := uuid.Parse(params.EntityID)
+
+	if _, err := hs.pg.GetEntityCompanyID(ctx, params.EntityType, entityID, false); err != nil {
+		return uuid.UUID{}, errs.NotFound(err, entityNotFound[params.EntityType])
+	}
+
+	return entityID, nil
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+)
+
+// NewPlaceClaim
+// @Summary Заявить права на место
+// @Description Создает заявку компании пользователя на владение местом. После одобрения модератором
+// @Description редактировать место сможет только компания-владелец.
+// @ID new-place-claim
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
+// @Param company_id body string true "Компания пользователя (в формате UUID)"
+// @Param evidence body string true "Подтверждение права владения"
+// @Param attachments body []string false "Ссылки на подтверждающие документы"
+// @Success 201 {object} models.PlaceClaim
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/{placeId}/claims [post]
+func (hs *handlerService) NewPlaceClaim(ctx *gin.Context) error {
+	placeID, err := hs.uuidParam(ctx, "placeId")
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		CompanyID   string   `json:"company_id" binding:"required,uuid"`
+		Evidence    string   `json:"evidence" binding:"required,min=10,max=2000"`
+		Attachments []string `json:"attachments" binding:"omitempty,max=10,dive,url"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	place, err := hs.pg.GetPlace(ctx, placeID, false)
+	if err != nil {
+		return errs.NotFound(err, errs.CodePlaceNotFound)
+	}
+
+	companyID, _ := uuid.Parse(params.CompanyID)
+	company, err := hs.ownCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	if place.CompanyID != nil && *place.CompanyID == company.ID {
+		return errs.New(errs.CodePlaceAlreadyOwned)
+	}
+
+	claim, err := hs.pg.NewPlaceClaim(ctx, models.PlaceClaim{
+		PlaceID:     place.ID,
+		CompanyID:   company.ID,
+		UserID:      company.UserID,
+		Evidence:    params.Evidence,
+		Attachments: uniqueStrings(params.Attachments),
+	})
+	if err != nil {
+		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
+			return errs.New(errs.CodeClaimAlreadyPending)
+		}
+
+		return fmt.Errorf("new place claim: %w", err)
+	}
+
+	ctx.JSON(http.StatusCreated, models.NewResponse(claim))
+
+	return nil
+}
+
+// GetPlaceClaims
+// @Summary Заявки на владение местами
+// @Description Возвращает заявки компаний на владение местами для модерации.
+// @ID get-place-claims
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param status query string false "Статус заявки: pending (по умолчанию), approved, rejected"
+// @Success 200 {object} []models.PlaceClaim
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /claims [get]
+func (hs *handlerService) GetPlaceClaims(ctx *gin.Context) error {
+	var params struct {
+		Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	if params.Status == "" {
+		params.Status = models.ClaimStatusPending
+	}
+
+	return hs.respondPlaceClaims(ctx, params.Status, nil)
+}
+
+// GetCompanyPlaceClaims
+// @Summary Заявки компании на владение местами
+// @Description Возвращает заявки компании на владение местами. Доступно только владельцу компании.
+// @ID get-company-place-claims
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
+// @Param status query string false "Статус заявки: pending, approved, rejected (по умолчанию все)"
+// @Success 200 {object} []models.PlaceClaim
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /companies/{companyId}/claims [get]
+func (hs *handlerService) GetCompanyPlaceClaims(ctx *gin.Context) error {
+	companyID, err := hs.uuidParam(ctx, "companyId")
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	company, err := hs.ownCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	return hs.respondPlaceClaims(ctx, params.Status, &company.ID)
+}
+
+// ApprovePlaceClaim
+// @Summary Одобрить заявку на владение местом
+// @Description Передает место компании из заявки. Остальные ожидающие заявки на это место отклоняются.
+// @ID approve-place-claim
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param claimId path string true "Уникальный идентификатор заявки (в формате UUID)"
+// @Param comment body string false "Комментарий модератора"
+// @Success 200 {object} models.PlaceClaim
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /claims/{claimId}/approve [post]
+func (hs *handlerService) ApprovePlaceClaim(ctx *gin.Context) error {
+	return hs.reviewPlaceClaim(ctx, models.ClaimStatusApproved)
+}
+
+// RejectPlaceClaim
+// @Summary Отклонить заявку на владение местом
+// @Description Отклоняет заявку компании на владение местом.
+// @ID reject-place-claim
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param claimId path string true "Уникальный идентификатор заявки (в формате UUID)"
+// @Param comment body string false "Причина отказа"
+// @Success 200 {object} models.PlaceClaim
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /claims/{claimId}/reject [post]
+func (hs *handlerService) RejectPlaceClaim(ctx *gin.Context) error {
+	return hs.reviewPlaceClaim(ctx, models.ClaimStatusRejected)
+}
+
+func (hs *handlerService) reviewPlaceClaim(ctx *gin.Context, status string) error {
+	claimID, err := hs.uuidParam(ctx, "claimId")
+	if err != nil {
+		return err
+	}
+
+	comment, err := hs.reviewComment(ctx)
+	if err != nil {
+		return err
+	}
+
+	reviewer, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	claim, err := hs.pg.GetPlaceClaim(ctx, claimID)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeClaimNotFound)
+	}
+
+	reviewed, err := hs.pg.ReviewPlaceClaim(ctx, claim, status, reviewer.ID, comment)
+	if err != nil {
+		return fmt.Errorf("review place claim: %w", err)
+	}
+
+	if !reviewed {
+		return errs.New(errs.CodeClaimAlreadyReviewed)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(claim))
+
+	return nil
+}
+
+func (hs *handlerService) respondPlaceClaims(ctx *gin.Context, status string, companyID *uuid.UUID) error {
+	claims, err := hs.pg.GetPlaceClaims(ctx, status, companyID)
+	if err != nil {
+		return fmt.Errorf("get place claims: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(claims))
+
+	return nil
+}
+
+// reviewComment разбирает необязательный комментарий модератора к решению.
+func (hs *handlerService) reviewComment(ctx *gin.Context) (string, error) {
+	var params struct {
+		Comment string `json:"comment" binding:"max=1000"`
+	}
+
+	if ctx.Request.ContentLength == 0 {
+		return "", nil
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return "", err
+	}
+
+	return params.Comment, nil
+}
@@ -1,8 +1,7 @@
 package handlers
 
 import (
-	"database/sql"
-	"errors"
+	"fmt"
 	"github.com/ShpullRequest/backend/internal/errs"
 	"github.com/ShpullRequest/backend/internal/models"
 	"github.com/gin-gonic/gin"
@@ -20,119 +19,213 @@ import (
 // @Param Authorization header string true "Строка авторизации"
 // @Param name body string true "Название компании (минимум 6 символов)"
 // @Param description body string true "Описание компании (минимум 12 символов)"
-// @Param photo_card body string true "Ссылка на фото компании (должна быть валидной URL)"
+// @Param photo_card body string true "Ссылка на фото компании (валидный URL или идентификатор загруженного изображения)"
 // @Success 200 {object} models.Company
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /companies [post]
-func (hs *handlerService) NewCompany(ctx *gin.Context) {
+func (hs *handlerService) NewCompany(ctx *gin.Context) error {
 	var params struct {
 		Name        string `json:"name" binding:"required,min=6"`
 		Description string `json:"description" binding:"required,min=12"`
-		PhotoCard   string `json:"photo_card" binding:"required,url"`
+		PhotoCard   string `json:"photo_card" binding:"required,url|uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
-	vkParams := hs.GetVKParams(ctx)
 
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
+	user, err := hs.currentUser(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		return err
+	}
 
-		return
+	photoCard, err := hs.resolveImageRefs(ctx, params.PhotoCard)
+	if err != nil {
+		return err
 	}
 
-	company, err := hs.pg.NewCompany(ctx, models.Company{
+	company := models.Company{
 		UserID:      user.ID,
 		Name:        params.Name,
 		Description: params.Description,
-		PhotoCard:   params.PhotoCard,
-	})
+		PhotoCard:   photoCard[0],
+	}
+
+	version, err := createdVersion(models.EntityVersion{
+		EntityType: models.EntityTypeCompany,
+		Action:     models.VersionActionCreate,
+		UserID:     &user.ID,
+	}, &company)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		return err
+	}
 
-		return
+	created, err := hs.pg.NewCompany(ctx, company, version)
+	if err != nil {
+		return fmt.Errorf("new company: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(company))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(created))
+
+	return nil
 }
 
-// AcceptCompany
-// @Summary Принять компанию
-// @Description Подтверждает компанию администратором.
-// @ID accept-company
+// EditCompany
+// @Summary Редактировать компанию
+// @Description Редактирует название, описание и фото компании. Правки пользователей, кроме владельца компании
+// @Description и администраторов, сохраняются как предложенное изменение, в этом случае возвращается код 202.
+// @ID edit-company
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
 // @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
+// @Param name body string false "Новое название компании (минимум 6 символов)"
+// @Param description body string false "Новое описание компании (минимум 12 символов)"
+// @Param translations body models.Translations false "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык"
+// @Param photo_card body string false "Новое фото компании (валидный URL или идентификатор загруженного изображения)"
+// @Param comment body string false "Пояснение к предложенному изменению"
 // @Success 200 {object} models.Company
+// @Success 202 {object} models.EditSuggestion
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /companies/{companyId} [patch]
-func (hs *handlerService) AcceptCompany(ctx *gin.Context) {
+func (hs *handlerService) EditCompany(ctx *gin.Context) error {
+	companyID, err := hs.uuidParam(ctx, "companyId")
+	if err != nil {
+		return err
+	}
+
 	var params struct {
-		CompanyID string `uri:"companyId" binding:"required,uuid"`
+		companyChanges
+		Comment string `json:"comment" binding:"max=1000"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
 
-		return
+	company, err := hs.pg.GetCompanyByID(ctx, companyID)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeCompanyNotFound)
 	}
-	vkParams := hs.GetVKParams(ctx)
 
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
+	user, direct, err := hs.editAccess(ctx, &company.ID, models.PermissionCompanyEdit)
 	if err != nil {
-		hs.logger.Error("Error get user", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	if !direct {
+		if params.PhotoCard != "" {
+			photoCard, err := hs.resolveImageRefs(ctx, params.PhotoCard)
+			if err != nil {
+				return err
+			}
 
-		return
+			params.PhotoCard = photoCard[0]
+		}
+
+		return hs.suggestEdit(ctx, user, models.EntityTypeCompany, company.ID, params.companyChanges, params.Comment)
+	}
+
+	entity, err := hs.updateVersioned(ctx, models.EntityTypeCompany, company.ID, models.EntityVersion{
+		EntityType: models.EntityTypeCompany,
+		EntityID:   company.ID,
+		Action:     models.VersionActionEdit,
+		UserID:     &user.ID,
+	}, func(entity *versionedEntity) error {
+		return hs.applyCompanyChanges(ctx, entity.value.(*models.Company), params.companyChanges)
+	})
+	if err != nil {
+		return fmt.Errorf("save company: %w", err)
 	}
 
-	if !user.IsAdmin {
-		ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You don't have access to this method")))
-		ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(entity.value))
 
-		return
+	return nil
+}
+
+// companyChanges поля запроса редактирования компании. В том же виде хранятся в предложенных изменениях.
+type companyChanges struct {
+	Name         string              `json:"name" binding:"omitempty,min=6"`
+	Description  string              `json:"description" binding:"omitempty,min=12"`
+	Translations models.Translations `json:"translations" binding:"omitempty,dive,keys,len=2,ne=ru,endkeys"`
+	PhotoCard    string              `json:"photo_card" binding:"omitempty,url|uuid"`
+}
+
+func (hs *handlerService) applyCompanyChanges(ctx *gin.Context, company *models.Company, changes companyChanges) error {
+	if changes.Name != "" {
+		company.Name = changes.Name
+	}
+	if changes.Description != "" {
+		company.Description = changes.Description
 	}
+	if changes.Translations != nil {
+		company.Translations = company.Translations.Merge(changes.Translations)
+	}
+	if changes.PhotoCard != "" {
+		photoCard, err := hs.resolveImageRefs(ctx, changes.PhotoCard)
+		if err != nil {
+			return err
+		}
 
-	companyID, _ := uuid.Parse(params.CompanyID)
-	company, err := hs.pg.GetCompanyByID(ctx, companyID)
+		company.PhotoCard = photoCard[0]
+	}
+
+	return nil
+}
+
+// AcceptCompany
+// @Summary Принять компанию
+// @Description Подтверждает компанию администратором или модератором и выдает ее владельцу роль company_member.
+// @ID accept-company
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
+// @Success 200 {object} models.Company
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /companies/{companyId}/accept [post]
+func (hs *handlerService) AcceptCompany(ctx *gin.Context) error {
+	var params struct {
+		CompanyID string `uri:"companyId" binding:"required,uuid"`
+	}
 
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
 	if err != nil {
-		hs.logger.Error("Error get company", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	companyID, _ := uuid.Parse(params.CompanyID)
+	company, err := hs.pg.GetCompanyByID(ctx, companyID)
 
-		return
+	if err != nil {
+		return errs.NotFound(err, errs.CodeCompanyNotFound)
 	}
 
 	company.IsReleased = true
 	if err = hs.pg.SaveCompany(ctx, company); err != nil {
-		hs.logger.Error("Error save company", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		return fmt.Errorf("save company: %w", err)
+	}
 
-		return
+	// Владелец подтвержденной компании может публиковать события и маршруты от ее имени
+	if _, err = hs.pg.AddCompanyMember(ctx, &user.ID, company.ID, company.UserID); err != nil {
+		return fmt.Errorf("add company member: %w", err)
 	}
 
 	ctx.JSON(http.StatusOK, models.NewResponse(company))
-	ctx.Abort()
+
+	return nil
 }
 
 // GetCompany
@@ -142,55 +235,45 @@ func (hs *handlerService) AcceptCompany(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
 // @Success 200 {object} models.Company
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /companies/{companyId} [get]
-func (hs *handlerService) GetCompany(ctx *gin.Context) {
+func (hs *handlerService) GetCompany(ctx *gin.Context) error {
 	var params struct {
 		CompanyID string `uri:"companyId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
 	}
 
 	companyID, _ := uuid.Parse(params.CompanyID)
 	company, err := hs.pg.GetCompanyByID(ctx, companyID)
 
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		hs.logger.Error("Error get company", zap.Error(err))
+	if err != nil {
+		return errs.NotFound(err, errs.CodeCompanyNotFound)
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	companyRating, err := hs.pg.GetCompanyAverageRating(ctx, company.ID)
+	if err != nil {
+		hs.logger.Error("Error with get rating company", zap.Error(err))
 
-		return
+		companyRating = 0
 	}
 
-	if !company.IsNil() {
-		companyRating, err := hs.pg.GetCompanyAverageRating(ctx, company.ID)
-		if err != nil {
-			hs.logger.Error("Error with get rating company", zap.Error(err))
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, struct {
+		*models.Company
+		Rating float64 `json:"rating"`
+	}{
+		Company: company,
+		Rating:  companyRating,
+	})))
 
-			companyRating = 0
-		}
-
-		ctx.JSON(http.StatusOK, models.NewResponse(struct {
-			*models.Company
-			Rating float64 `json:"rating"`
-		}{
-			Company: company,
-			Rating:  companyRating,
-		}))
-	} else {
-		ctx.JSON(http.StatusOK, models.NewResponse(nil))
-	}
-	ctx.Abort()
+	return nil
 }
 
 // GetMyCompanies
@@ -200,24 +283,21 @@ func (hs *handlerService) GetCompany(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Success 200 {object} []models.Company
 // @Failure 500 {object} models.ErrorResponse
 // @Router /companies/my [get]
-func (hs *handlerService) GetMyCompanies(ctx *gin.Context) {
-	vkParams := hs.GetVKParams(ctx)
+func (hs *handlerService) GetMyCompanies(ctx *gin.Context) error {
+	principal := hs.GetPrincipal(ctx)
 
-	companies, err := hs.pg.GetCompaniesByVkID(ctx, int64(vkParams.VkUserID))
+	companies, err := hs.pg.GetCompaniesByVkID(ctx, principal.VkUserID)
 	if err != nil {
-		hs.logger.Error("Error get companies by vk id", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		return fmt.Errorf("get companies by vk id: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(companies))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, companies)))
+
+	return nil
 }
 
 // GetAllCompanies
@@ -227,20 +307,36 @@ func (hs *handlerService) GetMyCompanies(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Success 200 {object} []models.Company
 // @Failure 500 {object} models.ErrorResponse
 // @Router /companies [get]
-func (hs *handlerService) GetAllCompanies(ctx *gin.Context) {
+func (hs *handlerService) GetAllCompanies(ctx *gin.Context) error {
 	companies, err := hs.pg.GetAllCompanies(ctx)
 	if err != nil {
-		hs.logger.Error("Error get all companies", zap.Error(err))
+		return fmt.Errorf("get all companies: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, companies)))
+
+	return nil
+}
+
+// ownCompany загружает компанию и проверяет, что она принадлежит текущему пользователю.
+func (hs *handlerService) ownCompany(ctx *gin.Context, companyID uuid.UUID) (*models.Company, error) {
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	company, err := hs.pg.GetCompanyByID(ctx, companyID)
+	if err != nil {
+		return nil, errs.NotFound(err, errs.CodeCompanyNotFound)
+	}
 
-		return
+	if company.UserID != user.ID {
+		return nil, errs.New(errs.CodeCompanyActionForbidden)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(companies))
-	ctx.Abort()
+	return company, nil
 }
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetCompanyMembers
+// @Summary Получить сотрудников компании
+// @Description Возвращает сотрудников компании, которые могут отвечать на отзывы от ее имени. Доступно только владельцу компании.
+// @ID get-company-members
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
+// @Success 200 {object} []models.CompanyMember
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /companies/{companyId}/members [get]
+func (hs *handlerService) GetCompanyMembers(ctx *gin.Context) error {
+	companyID, err := hs.uuidParam(ctx, "companyId")
+	if err != nil {
+		return err
+	}
+
+	company, err := hs.ownCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	members, err := hs.pg.GetCompanyMembers(ctx, company.ID)
+	if err != nil {
+		return fmt.Errorf("get company members: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(members))
+
+	return nil
+}
+
+// AddCompanyMember
+// @Summary Добавить сотрудника компании
+// @Description Добавляет пользователя по VK ID в сотрудники компании и выдает ему роль company_member. Доступно только владельцу компании.
+// @ID add-company-member
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
+// @Param vk_id body integer true "VK ID пользователя"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /companies/{companyId}/members [post]
+func (hs *handlerService) AddCompanyMember(ctx *gin.Context) error {
+	companyID, err := hs.uuidParam(ctx, "companyId")
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		VkID int64 `json:"vk_id" binding:"required"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	company, err := hs.ownCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	member, err := hs.pg.GetUserByVkID(ctx, params.VkID)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeUserNotFound)
+	}
+
+	added, err := hs.pg.AddCompanyMember(ctx, &company.UserID, company.ID, member.ID)
+	if err != nil {
+		return fmt.Errorf("add company member: %w", err)
+	}
+
+	if !added {
+		return errs.New(errs.CodeCompanyMemberExists)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// RemoveCompanyMember
+// @Summary Удалить сотрудника компании
+// @Description Удаляет пользователя из сотрудников компании. Роль company_member отзывается, если пользователь больше не состоит ни в одной компании. Доступно только владельцу компании.
+// @ID remove-company-member
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
+// @Param vkId path integer true "VK ID пользователя"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /companies/{companyId}/members/{vkId} [delete]
+func (hs *handlerService) RemoveCompanyMember(ctx *gin.Context) error {
+	companyID, err := hs.uuidParam(ctx, "companyId")
+	if err != nil {
+		return err
+	}
+
+	company, err := hs.ownCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	member, err := hs.roleTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Владелец отвечает от имени компании всегда, его членство не удаляется
+	if member.ID == company.UserID {
+		return errs.New(errs.CodeCompanyActionForbidden)
+	}
+
+	removed, err := hs.pg.RemoveCompanyMember(ctx, &company.UserID, company.ID, member.ID)
+	if err != nil {
+		return fmt.Errorf("remove company member: %w", err)
+	}
+
+	if !removed {
+		return errs.New(errs.CodeCompanyMemberNotFound)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
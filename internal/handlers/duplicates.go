@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	placeDuplicatesDefaultSimilarity = 0.5
+	placeDuplicatesDefaultDistanceM  = 300
+	placeDuplicatesDefaultLimit      = 50
+)
+
+// GetPlaceDuplicates
+// @Summary Вероятные дубли мест
+// @Description Возвращает пары мест с похожими названиями, расположенные рядом друг с другом. Пары, отмеченные
+// @Description как не дубли, не возвращаются. Сначала идут пары с наибольшей оценкой.
+// @ID get-place-duplicates
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param min_similarity query number false "Минимальное сходство названий от 0.3 до 1 (по умолчанию 0.5)"
+// @Param max_distance_m query integer false "Максимальное расстояние между местами в метрах, до 5000 (по умолчанию 300)"
+// @Param city_id query string false "Город или регион (в формате UUID)"
+// @Param limit query integer false "Количество пар, до 100 (по умолчанию 50)"
+// @Success 200 {object} []models.PlaceDuplicate
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/duplicates [get]
+func (hs *handlerService) GetPlaceDuplicates(ctx *gin.Context) error {
+	var params struct {
+		MinSimilarity float64 `form:"min_similarity" binding:"omitempty,min=0.3,max=1"`
+		MaxDistanceM  int     `form:"max_distance_m" binding:"omitempty,min=1,max=5000"`
+		CityID        string  `form:"city_id" binding:"omitempty,uuid"`
+		Limit         int     `form:"limit" binding:"omitempty,min=1,max=100"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	if params.MinSimilarity == 0 {
+		params.MinSimilarity = placeDuplicatesDefaultSimilarity
+	}
+	if params.MaxDistanceM == 0 {
+		params.MaxDistanceM = placeDuplicatesDefaultDistanceM
+	}
+	if params.Limit == 0 {
+		params.Limit = placeDuplicatesDefaultLimit
+	}
+
+	var cityID *uuid.UUID
+	if params.CityID != "" {
+		parsed, _ := uuid.Parse(params.CityID)
+		cityID = &parsed
+	}
+
+	duplicates, err := hs.pg.GetPlaceDuplicates(ctx, params.MinSimilarity, float64(params.MaxDistanceM)/1000, cityID, params.Limit)
+	if err != nil {
+		return fmt.Errorf("get place duplicates: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(duplicates))
+
+	return nil
+}
+
+// DismissPlaceDuplicate
+// @Summary Отметить места как не дубли
+// @Description Убирает пару мест из списка вероятных дублей.
+// @ID dismiss-place-duplicate
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param place_id body string true "Первое место (в формате UUID)"
+// @Param duplicate_id body string true "Второе место (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/duplicates/dismiss [post]
+func (hs *handlerService) DismissPlaceDuplicate(ctx *gin.Context) error {
+	var params struct {
+		PlaceID     string `json:"place_id" binding:"required,uuid"`
+		DuplicateID string `json:"duplicate_id" binding:"required,uuid,nefield=PlaceID"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	placeID, _ := uuid.Parse(params.PlaceID)
+	duplicateID, _ := uuid.Parse(params.DuplicateID)
+	if _, err := hs.mergeablePlace(ctx, placeID); err != nil {
+		return err
+	}
+	if _, err := hs.mergeablePlace(ctx, duplicateID); err != nil {
+		return err
+	}
+
+	if err := hs.pg.DismissPlaceDuplicate(ctx, placeID, duplicateID, user.ID); err != nil {
+		return fmt.Errorf("dismiss place duplicate: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// MergePlace
+// @Summary Объединить дубль с местом
+// @Description Переносит отзывы, места в маршрутах, закладки, посещения и просмотры дубля на место из пути.
+// @Description Дубль удаляется, запросы к нему перенаправляются на оставшееся место. Если у пользователя
+// @Description есть отзыв или закладка у обоих мест, сохраняется отзыв или закладка оставшегося места.
+// @ID merge-place
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param placeId path string true "Место, которое остается (в формате UUID)"
+// @Param duplicate_id body string true "Дубль, который объединяется с местом (в формате UUID)"
+// @Success 200 {object} models.Place
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/{placeId}/merge [post]
+func (hs *handlerService) MergePlace(ctx *gin.Context) error {
+	placeID, err := hs.uuidParam(ctx, "placeId")
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		DuplicateID string `json:"duplicate_id" binding:"required,uuid"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	duplicateID, _ := uuid.Parse(params.DuplicateID)
+	if duplicateID == placeID {
+		return errs.New(errs.CodePlaceMergeSelf)
+	}
+
+	if _, err := hs.mergeablePlace(ctx, placeID); err != nil {
+		return err
+	}
+	if _, err := hs.mergeablePlace(ctx, duplicateID); err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := hs.pg.MergePlaces(ctx, placeID, duplicateID, user.ID); err != nil {
+		return errs.NotFound(err, errs.CodePlaceNotFound)
+	}
+
+	place, err := hs.mergeablePlace(ctx, placeID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(place))
+
+	return nil
+}
+
+// mergeablePlace загружает неудаленное место для объединения.
+func (hs *handlerService) mergeablePlace(ctx *gin.Context, placeID uuid.UUID) (*models.Place, error) {
+	place, err := hs.pg.GetPlace(ctx, placeID, false)
+	if err != nil {
+		return nil, errs.NotFound(err, errs.CodePlaceNotFound)
+	}
+
+	return place, nil
+}
+
+// redirectMergedPlace перенаправляет запрос к объединенному дублю на оставшееся место.
+// Возвращает false, если место не объединялось.
+func (hs *handlerService) redirectMergedPlace(ctx *gin.Context, placeID uuid.UUID) bool {
+	mergedInto, err := hs.pg.GetPlaceMergedInto(ctx, placeID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			hs.logger.Error("Error get place merged into", zap.Error(err))
+		}
+
+		return false
+	}
+
+	if mergedInto == nil {
+		return false
+	}
+
+	location := *ctx.Request.URL
+	location.Path = strings.Replace(location.Path, placeID.String(), mergedInto.String(), 1)
+	ctx.Redirect(http.StatusMovedPermanently, location.RequestURI())
+
+	return true
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// includeDeleted разбирает параметр include_deleted. Просматривать удаленные записи можно только с разрешением deleted.view.
+func (hs *handlerService) includeDeleted(ctx *gin.Context) (bool, error) {
+	var params struct {
+		IncludeDeleted bool `form:"include_deleted"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return false, err
+	}
+
+	if !params.IncludeDeleted {
+		return false, nil
+	}
+
+	permissions, err := hs.getPermissions(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !permissions.Has(models.PermissionDeletedView) {
+		return false, errs.New(errs.CodeDeletedAccessDenied)
+	}
+
+	return true, nil
+}
+
+// setEntityDeleted удаляет или восстанавливает место, событие или маршрут.
+// Чужие события, маршруты и места, а также записи без компании может удалять только пользователь с разрешением content.manage.
+func (hs *handlerService) setEntityDeleted(ctx *gin.Context, entityType string, entityParam string, deleted bool) error {
+	entityID, err := hs.uuidParam(ctx, entityParam)
+	if err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	companyID, err := hs.pg.GetEntityCompanyID(ctx, entityType, entityID, true)
+	if err != nil {
+		return errs.NotFound(err, entityNotFound[entityType])
+	}
+
+	permissions, err := hs.getPermissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !permissions.Has(models.PermissionContentManage) {
+		if companyID == nil {
+			return errs.New(errs.CodeAccessDenied)
+		}
+
+		company, err := hs.pg.GetCompanyByID(ctx, *companyID)
+		if err != nil {
+			return fmt.Errorf("get company by id: %w", err)
+		}
+
+		if company.UserID != user.ID {
+			return errs.New(errs.CodeAccessDenied)
+		}
+	}
+
+	if err := hs.checkAPIKeyCompany(ctx, companyID); err != nil {
+		return err
+	}
+
+	changed, err := hs.pg.SetEntityDeleted(ctx, entityType, entityID, deleted)
+	if err != nil {
+		return fmt.Errorf("set entity deleted: %w", err)
+	}
+
+	if !changed {
+		code := errs.CodeEntityDeleted
+		if !deleted {
+			code = errs.CodeEntityNotDeleted
+		}
+
+		return errs.New(code).WithParam("entity_type", entityType)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
@@ -1,17 +1,11 @@
 package handlers
 
 import (
-	"database/sql"
-	"errors"
-	"github.com/ShpullRequest/backend/internal/config"
+	"fmt"
 	"github.com/ShpullRequest/backend/internal/errs"
 	"github.com/ShpullRequest/backend/internal/models"
-	"github.com/ShpullRequest/backend/pkg/vk/maps"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgerrcode"
-	"go.uber.org/zap"
-	"math"
 	"net/http"
 	"time"
 )
@@ -26,119 +20,127 @@ import (
 // @Param company_id body string false "Уникальный идентификатор компании (в формате UUID)"
 // @Param name body string true "Название события (минимум 6 символов)"
 // @Param description body string true "Описание события (минимум 10 символов)"
-// @Param carousel body []string true "Массив ссылок на изображения для карусели события"
-// @Param tags body []string true "Массив тегов для события"
-// @Param icon body string true "Ссылка на иконку события (должна быть валидной URL)"
+// @Param carousel body []string true "Массив ссылок или идентификаторов загруженных изображений для карусели события"
+// @Param tag_ids body []string false "Теги события из справочника (в формате UUID)"
+// @Param icon body string true "Ссылка на иконку события (валидный URL или идентификатор загруженного изображения)"
 // @Param start_time body string true "Дата и время начала события (в формате 2006-01-02T15:04:05Z07:00)"
-// @Param address_lng body float64 true "Долгота местоположения события"
-// @Param address_lat body float64 true "Широта местоположения события"
+// @Param address body string false "Текст адреса события, используется, если не переданы координаты"
+// @Param address_lng body float64 false "Долгота местоположения события (обязательна без address)"
+// @Param address_lat body float64 false "Широта местоположения события (обязательна без address)"
 // @Success 200 {object} models.Event
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 502 {object} models.ErrorResponse
 // @Router /events [post]
-func (hs *handlerService) NewEvent(ctx *gin.Context) {
+func (hs *handlerService) NewEvent(ctx *gin.Context) error {
 	var params struct {
 		// Нельзя парсить сразу в uuid.UUID, потому что это не поддерживает нормально gin
 		CompanyID   string   `json:"company_id" binding:"omitempty,uuid"`
 		Name        string   `json:"name" binding:"required,min=6"`
 		Description string   `json:"description" binding:"required,min=10"`
 		Carousel    []string `json:"carousel" binding:"required"`
-		Tags        []string `json:"tags" binding:"required"`
-		Icon        string   `json:"icon" binding:"required,url"`
+		TagIDs      []string `json:"tag_ids" binding:"omitempty,dive,uuid"`
+		Icon        string   `json:"icon" binding:"required,url|uuid"`
 		StartTime   string   `json:"start_time" binding:"required"`
-		AddressLng  float64  `json:"address_lng" binding:"required,longitude"`
-		AddressLat  float64  `json:"address_lat" binding:"required,latitude"`
+		Address     string   `json:"address" binding:"omitempty,min=3"`
+		AddressLng  float64  `json:"address_lng" binding:"required_without=Address,longitude"`
+		AddressLat  float64  `json:"address_lat" binding:"required_without=Address,latitude"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
 
 	startTime, err := time.Parse("2006-01-02T15:04:05Z07:00", params.StartTime)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"StartTime\" failed on the 'timezone=2006-01-02T15:04:05Z07:00' tag.")))
-		ctx.Abort()
-
-		return
+		return errs.Invalid("start_time", "timezone", time.RFC3339)
 	}
 
-	vkParams := hs.GetVKParams(ctx)
-
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
+	user, err := hs.currentUser(ctx)
 	if err != nil {
-		hs.logger.Error("Error get user by vk id", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		return err
 	}
 
 	var companyID *uuid.UUID = nil
 	paramCompanyID, err := uuid.Parse(params.CompanyID)
 
 	if err != nil {
-		if !user.IsAdmin {
-			ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You don't have access to this method")))
-			ctx.Abort()
+		permissions, err := hs.getPermissions(ctx)
+		if err != nil {
+			return err
+		}
 
-			return
+		if !permissions.Has(models.PermissionContentManage) {
+			return errs.New(errs.CodeAccessDenied)
 		}
 	} else {
 		company, err := hs.pg.GetCompanyByID(ctx, paramCompanyID)
 		if err != nil {
-			hs.logger.Error("Error get company by id", zap.Error(err))
-
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-			ctx.Abort()
-
-			return
+			return fmt.Errorf("get company by id: %w", err)
 		} else if company.UserID != user.ID {
-			ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You can't create an event on behalf of this company")))
-			ctx.Abort()
-
-			return
+			return errs.New(errs.CodeEventCompanyForbidden)
 		}
 
 		companyID = &company.ID
 	}
 
-	address, err := maps.New(config.Config).GetAddressByGeo(params.AddressLng, params.AddressLat)
+	if err := hs.checkAPIKeyCompany(ctx, companyID); err != nil {
+		return err
+	}
+
+	imageURLs, err := hs.resolveImageRefs(ctx, append([]string{params.Icon}, params.Carousel...)...)
 	if err != nil {
-		hs.logger.Error("Error get company by id", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusBadGateway, models.NewErrorResponse(errs.NewBadGateway("Internal server error on vk maps")))
-		ctx.Abort()
+	tagIDs, err := hs.tagIDs(ctx, params.TagIDs)
+	if err != nil {
+		return err
+	}
 
-		return
+	address, err := hs.resolveAddress(ctx, params.Address, params.AddressLng, params.AddressLat)
+	if err != nil {
+		return err
+	}
+	cityID, err := hs.cityAt(ctx, address.Lng, address.Lat)
+	if err != nil {
+		return err
 	}
 
-	event, err := hs.pg.NewEvent(ctx, models.Event{
+	event := models.Event{
 		CompanyID:   companyID,
 		Name:        params.Name,
 		Description: params.Description,
-		Carousel:    params.Carousel,
-		Tags:        params.Tags,
-		Icon:        params.Icon,
+		Carousel:    imageURLs[1:],
+		TagIDs:      tagIDs,
+		Icon:        imageURLs[0],
 		StartTime:   startTime,
-		AddressText: address,
-		AddressLng:  params.AddressLng,
-		AddressLat:  params.AddressLat,
-	})
+		AddressText: address.Text,
+		AddressLng:  address.Lng,
+		AddressLat:  address.Lat,
+		Address:     address.Address,
+		CityID:      cityID,
+	}
+
+	version, err := createdVersion(models.EntityVersion{
+		EntityType: models.EntityTypeEvent,
+		Action:     models.VersionActionCreate,
+		UserID:     &user.ID,
+	}, &event)
 	if err != nil {
-		hs.logger.Error("Error new event", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		return err
+	}
 
-		return
+	created, err := hs.pg.NewEvent(ctx, event, version)
+	if err != nil {
+		return fmt.Errorf("new event: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(event))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(created))
+
+	return nil
 }
 
 // GetEvent
@@ -148,47 +150,49 @@ func (hs *handlerService) NewEvent(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Param include_deleted query bool false "Включить удаленные записи (только для администраторов)"
 // @Success 200 {object} models.Event
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /events/{eventId} [get]
-func (hs *handlerService) GetEvent(ctx *gin.Context) {
+func (hs *handlerService) GetEvent(ctx *gin.Context) error {
 	var params struct {
 		EventID string `uri:"eventId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
+	}
 
-		return
+	includeDeleted, err := hs.includeDeleted(ctx)
+	if err != nil {
+		return err
 	}
 
 	eventID, _ := uuid.Parse(params.EventID)
-	event, err := hs.pg.GetEvent(ctx, eventID)
-
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		hs.logger.Error("Error get event", zap.Error(err))
+	event, err := hs.pg.GetEvent(ctx, eventID, includeDeleted)
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+	if err != nil {
+		return errs.NotFound(err, errs.CodeEventNotFound)
 	}
 
-	if !event.IsNil() {
-		ctx.JSON(http.StatusOK, models.NewResponse(event))
-	} else {
-		ctx.JSON(http.StatusOK, models.NewResponse(nil))
+	if !event.IsDeleted {
+		hs.recordView(ctx, models.EntityTypeEvent, event.ID)
 	}
-	ctx.Abort()
+
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, event)))
+
+	return nil
 }
 
 // EditEvent
 // @Summary Редактировать событие
-// @Description Редактирует информацию о существующем событии.
+// @Description Редактирует информацию о существующем событии. Правки пользователей, которые не могут редактировать
+// @Description событие, сохраняются как предложенное изменение, в этом случае возвращается код 202.
 // @ID edit-event
 // @Accept json
 // @Produce json
@@ -196,152 +200,180 @@ func (hs *handlerService) GetEvent(ctx *gin.Context) {
 // @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
 // @Param name body string false "Новое название события (минимум 6 символов)"
 // @Param description body string false "Новое описание события (минимум 10 символов)"
-// @Param carousel body []string false "Новый массив ссылок на изображения для карусели события"
-// @Param tags body []string false "Новый массив тегов для события"
-// @Param icon body string false "Новая ссылка на иконку события (должна быть валидной URL)"
+// @Param translations body models.Translations false "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык"
+// @Param carousel body []string false "Новый массив ссылок или идентификаторов загруженных изображений для карусели события"
+// @Param tag_ids body []string false "Новые теги события из справочника (в формате UUID)"
+// @Param icon body string false "Новая ссылка на иконку события (валидный URL или идентификатор загруженного изображения)"
 // @Param start_time body string false "Новая дата и время начала события (в формате 2006-01-02T15:04:05Z07:00)"
+// @Param address body string false "Новый текст адреса события, используется, если не переданы координаты"
 // @Param address_lng body float64 false "Новая долгота местоположения события"
 // @Param address_lat body float64 false "Новая широта местоположения события"
+// @Param comment body string false "Пояснение к предложенному изменению"
 // @Success 200 {object} models.Event
+// @Success 202 {object} models.EditSuggestion
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 502 {object} models.ErrorResponse
 // @Router /events/{eventId} [patch]
-func (hs *handlerService) EditEvent(ctx *gin.Context) {
+func (hs *handlerService) EditEvent(ctx *gin.Context) error {
 	var paramsURI struct {
 		EventID string `uri:"eventId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
+		return err
 	}
 
 	var params struct {
-		Name        string   `json:"name" binding:"omitempty,min=6"`
-		Description string   `json:"description" binding:"omitempty,min=10"`
-		Carousel    []string `json:"carousel" binding:"omitempty"`
-		Tags        []string `json:"tags" binding:"required"`
-		Icon        string   `json:"icon" binding:"omitempty,url"`
-		StartTime   string   `json:"start_time" binding:"omitempty"`
-		AddressLng  float64  `json:"address_lng" binding:"omitempty,longitude"`
-		AddressLat  float64  `json:"address_lat" binding:"omitempty,latitude"`
+		eventChanges
+		Comment string `json:"comment" binding:"max=1000"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
-	vkParams := hs.GetVKParams(ctx)
 
 	eventID, _ := uuid.Parse(paramsURI.EventID)
-	event, err := hs.pg.GetEvent(ctx, eventID)
+	event, err := hs.pg.GetEvent(ctx, eventID, false)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, models.NewErrorResponse(errs.NewNotFound("Event not found")))
-		} else {
-			hs.logger.Error("Error get event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
+		return errs.NotFound(err, errs.CodeEventNotFound)
 	}
 
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
+	user, direct, err := hs.editAccess(ctx, event.CompanyID, models.PermissionEventEdit)
 	if err != nil {
-		hs.logger.Error("Error get user by vk id", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		return err
 	}
 
-	if event.CompanyID == nil {
-		if !user.IsAdmin {
-			ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You don't have access to this method")))
-			ctx.Abort()
-
-			return
-		}
-	} else {
-		company, err := hs.pg.GetCompanyByID(ctx, *event.CompanyID)
-		if err != nil {
-			hs.logger.Error("Error get company by id", zap.Error(err))
+	if !direct {
+		return hs.suggestEventChanges(ctx, user, event, params.eventChanges, params.Comment)
+	}
 
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-			ctx.Abort()
+	entity, err := hs.updateVersioned(ctx, models.EntityTypeEvent, event.ID, models.EntityVersion{
+		EntityType: models.EntityTypeEvent,
+		EntityID:   event.ID,
+		Action:     models.VersionActionEdit,
+		UserID:     &user.ID,
+	}, func(entity *versionedEntity) error {
+		return hs.applyEventChanges(ctx, entity.value.(*models.Event), params.eventChanges)
+	})
+	if err != nil {
+		return fmt.Errorf("save event: %w", err)
+	}
 
-			return
-		}
+	ctx.JSON(http.StatusOK, models.NewResponse(entity.value))
 
-		if company.UserID != user.ID {
-			ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You can't create an event on behalf of this company")))
-			ctx.Abort()
+	return nil
+}
 
-			return
-		}
-	}
+// eventChanges поля запроса редактирования события. В том же виде хранятся в предложенных изменениях.
+type eventChanges struct {
+	Name         string              `json:"name" binding:"omitempty,min=6"`
+	Description  string              `json:"description" binding:"omitempty,min=10"`
+	Translations models.Translations `json:"translations" binding:"omitempty,dive,keys,len=2,ne=ru,endkeys"`
+	Carousel     []string            `json:"carousel" binding:"omitempty"`
+	TagIDs       []string            `json:"tag_ids" binding:"omitempty,dive,uuid"`
+	Icon         string              `json:"icon" binding:"omitempty,url|uuid"`
+	StartTime    string              `json:"start_time" binding:"omitempty"`
+	Address      string              `json:"address" binding:"omitempty,min=3"`
+	AddressLng   float64             `json:"address_lng" binding:"omitempty,longitude"`
+	AddressLat   float64             `json:"address_lat" binding:"omitempty,latitude"`
+}
 
-	if params.Name != "" {
-		event.Name = params.Name
+// applyEventChanges переносит переданные изменения в событие, загружая изображения и определяя адрес.
+func (hs *handlerService) applyEventChanges(ctx *gin.Context, event *models.Event, changes eventChanges) error {
+	if changes.Name != "" {
+		event.Name = changes.Name
 	}
-	if params.Description != "" {
-		event.Description = params.Description
+	if changes.Description != "" {
+		event.Description = changes.Description
 	}
-	if len(params.Carousel) > 0 {
-		event.Carousel = params.Carousel
+	if changes.Translations != nil {
+		event.Translations = event.Translations.Merge(changes.Translations)
 	}
-	if len(params.Tags) > 0 {
-		event.Tags = params.Tags
+	if len(changes.Carousel) > 0 {
+		carousel, err := hs.resolveImageRefs(ctx, changes.Carousel...)
+		if err != nil {
+			return err
+		}
+
+		event.Carousel = carousel
 	}
-	if params.Icon != "" {
-		event.Icon = params.Icon
+	if changes.TagIDs != nil {
+		tagIDs, err := hs.tagIDs(ctx, changes.TagIDs)
+		if err != nil {
+			return err
+		}
+
+		event.TagIDs = tagIDs
 	}
-	if params.StartTime != "" {
-		startTime, err := time.Parse("2006-01-02T15:04:05Z07:00", params.StartTime)
+	if changes.Icon != "" {
+		icon, err := hs.resolveImageRefs(ctx, changes.Icon)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"StartTime\" failed on the 'timezone=2006-01-02T15:04:05Z07:00' tag.")))
-			ctx.Abort()
+			return err
+		}
 
-			return
+		event.Icon = icon[0]
+	}
+	if changes.StartTime != "" {
+		startTime, err := time.Parse("2006-01-02T15:04:05Z07:00", changes.StartTime)
+		if err != nil {
+			return errs.Invalid("start_time", "timezone", time.RFC3339)
 		}
 		event.StartTime = startTime
 	}
-	if params.AddressLng != 0 && params.AddressLat != 0 {
-		address, err := maps.New(config.Config).GetAddressByGeo(params.AddressLng, params.AddressLat)
+	if changes.Address != "" || (changes.AddressLng != 0 && changes.AddressLat != 0) {
+		address, err := hs.resolveAddress(ctx, changes.Address, changes.AddressLng, changes.AddressLat)
 		if err != nil {
-			hs.logger.Error("Error get address", zap.Error(err))
+			return err
+		}
 
-			ctx.JSON(http.StatusBadGateway, models.NewErrorResponse(errs.NewBadGateway("Internal server error on vk maps")))
-			ctx.Abort()
+		event.AddressText = address.Text
+		event.AddressLng = address.Lng
+		event.AddressLat = address.Lat
+		event.Address = address.Address
 
-			return
+		if event.CityID, err = hs.cityAt(ctx, address.Lng, address.Lat); err != nil {
+			return err
 		}
-
-		event.AddressText = address
-		event.AddressLng = params.AddressLng
-		event.AddressLat = params.AddressLat
 	}
 
-	if err = hs.pg.SaveEvent(ctx, event); err != nil {
-		hs.logger.Error("Error save event", zap.Error(err))
+	return nil
+}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+// suggestEventChanges сохраняет правку события как предложенное изменение. Изображения загружаются сразу,
+// а время начала проверяется до сохранения.
+func (hs *handlerService) suggestEventChanges(ctx *gin.Context, user *models.User, event *models.Event, changes eventChanges, comment string) error {
+	if len(changes.Carousel) > 0 {
+		carousel, err := hs.resolveImageRefs(ctx, changes.Carousel...)
+		if err != nil {
+			return err
+		}
 
-		return
+		changes.Carousel = carousel
 	}
+	if changes.Icon != "" {
+		icon, err := hs.resolveImageRefs(ctx, changes.Icon)
+		if err != nil {
+			return err
+		}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(event))
-	ctx.Abort()
+		changes.Icon = icon[0]
+	}
+	if changes.TagIDs != nil {
+		if _, err := hs.tagIDs(ctx, changes.TagIDs); err != nil {
+			return err
+		}
+	}
+	if changes.StartTime != "" {
+		if _, err := time.Parse("2006-01-02T15:04:05Z07:00", changes.StartTime); err != nil {
+			return errs.Invalid("start_time", "timezone", time.RFC3339)
+		}
+	}
+
+	return hs.suggestEdit(ctx, user, models.EntityTypeEvent, event.ID, changes, comment)
 }
 
 // GetCompanyEvents
@@ -351,37 +383,39 @@ func (hs *handlerService) EditEvent(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param tag query string false "Тег (UUID или slug), для категории учитываются и ее дочерние теги"
+// @Param sort query string false "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом"
 // @Success 200 {object} []models.Event
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /companies/{companyId}/events [get]
-func (hs *handlerService) GetCompanyEvents(ctx *gin.Context) {
+func (hs *handlerService) GetCompanyEvents(ctx *gin.Context) error {
 	var params struct {
 		CompanyID string `uri:"companyId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
 	}
 
 	companyID, _ := uuid.Parse(params.CompanyID)
-	events, err := hs.pg.GetAllEventsByCompanyID(ctx, companyID)
-
+	filter, err := hs.listFilter(ctx)
 	if err != nil {
-		hs.logger.Error("Error get all events by company id", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	events, err := hs.pg.GetAllEventsByCompanyID(ctx, companyID, filter)
 
-		return
+	if err != nil {
+		return fmt.Errorf("get all events by company id: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(events))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, events)))
+
+	return nil
 }
 
 // SearchEvents
@@ -391,35 +425,37 @@ func (hs *handlerService) GetCompanyEvents(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param query path string true "Поисковый запрос (минимум 2 символа)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param tag query string false "Тег (UUID или slug), для категории учитываются и ее дочерние теги"
+// @Param sort query string false "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом"
 // @Success 200 {object} []models.Event
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /events/search/{query} [get]
-func (hs *handlerService) SearchEvents(ctx *gin.Context) {
+func (hs *handlerService) SearchEvents(ctx *gin.Context) error {
 	var params struct {
 		Query string `uri:"query" binding:"required,min=2"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
 	}
 
-	events, err := hs.pg.SearchEvents(ctx, params.Query)
+	filter, err := hs.listFilter(ctx)
 	if err != nil {
-		hs.logger.Error("Error search events", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		return err
+	}
 
-		return
+	events, err := hs.pg.SearchEvents(ctx, params.Query, filter)
+	if err != nil {
+		return fmt.Errorf("search events: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(events))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, events)))
+
+	return nil
 }
 
 // GetAllEvents
@@ -429,22 +465,72 @@ func (hs *handlerService) SearchEvents(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
+// @Param include_deleted query bool false "Включить удаленные записи (только для администраторов)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param tag query string false "Тег (UUID или slug), для категории учитываются и ее дочерние теги"
+// @Param sort query string false "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом"
 // @Success 200 {object} []models.Event
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /events [get]
-func (hs *handlerService) GetAllEvents(ctx *gin.Context) {
-	events, err := hs.pg.GetAllEvents(ctx)
+func (hs *handlerService) GetAllEvents(ctx *gin.Context) error {
+	includeDeleted, err := hs.includeDeleted(ctx)
 	if err != nil {
-		hs.logger.Error("Error get all events", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	filter, err := hs.listFilter(ctx)
+	if err != nil {
+		return err
+	}
 
-		return
+	events, err := hs.pg.GetAllEvents(ctx, includeDeleted, filter)
+	if err != nil {
+		return fmt.Errorf("get all events: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(events))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, events)))
+
+	return nil
+}
+
+// DeleteEvent
+// @Summary Удалить событие
+// @Description Помечает событие удаленным. Доступно администраторам и владельцу компании, которой принадлежит событие.
+// @ID delete-event
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/{eventId} [delete]
+func (hs *handlerService) DeleteEvent(ctx *gin.Context) error {
+	return hs.setEntityDeleted(ctx, models.EntityTypeEvent, "eventId", true)
+}
+
+// RestoreEvent
+// @Summary Восстановить событие
+// @Description Восстанавливает удаленное событие. Доступно администраторам и владельцу компании, которой принадлежит событие.
+// @ID restore-event
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/{eventId}/restore [post]
+func (hs *handlerService) RestoreEvent(ctx *gin.Context) error {
+	return hs.setEntityDeleted(ctx, models.EntityTypeEvent, "eventId", false)
 }
 
 // NewReviewEvent
@@ -457,80 +543,16 @@ func (hs *handlerService) GetAllEvents(ctx *gin.Context) {
 // @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
 // @Param review_text body string true "Текст отзыва (минимум 6 символов)"
 // @Param stars body float64 true "Оценка события (от 1 до 5)"
-// @Success 200 {object} models.ReviewEvent
+// @Param photos body []string false "Идентификаторы загруженных фотографий (опционально)"
+// @Success 200 {object} models.Review
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 409 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /events/{eventId}/reviews [post]
-func (hs *handlerService) NewReviewEvent(ctx *gin.Context) {
-	var paramsURI struct {
-		EventID string `uri:"eventId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	var params struct {
-		ReviewText string  `json:"review_text" binding:"required,min=6"`
-		Stars      float64 `json:"stars" binding:"required"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-	vkParams := hs.GetVKParams(ctx)
-
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
-	if err != nil {
-		hs.logger.Error("Error get user", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	if params.Stars < 1 || params.Stars > 5 {
-		ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"Stars\" failed on the 'min=1,max=5' tag.")))
-		ctx.Abort()
-
-		return
-	} else {
-		params.Stars = math.Round(params.Stars)
-	}
-
-	eventID, _ := uuid.Parse(paramsURI.EventID)
-	reviewEvent, err := hs.pg.NewReviewEvent(ctx, models.ReviewEvent{
-		OwnerID:    user.ID,
-		EventID:    eventID,
-		ReviewText: params.ReviewText,
-		Stars:      params.Stars,
-		CreatedAt:  time.Now(),
-	})
-
-	if err != nil {
-		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
-			ctx.JSON(http.StatusConflict, models.NewErrorResponse(errs.NewConflict("You have already added a review to this event")))
-		} else {
-			hs.logger.Error("Error new review event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
-	}
-
-	ctx.JSON(http.StatusOK, models.NewResponse(reviewEvent))
-	ctx.Abort()
+func (hs *handlerService) NewReviewEvent(ctx *gin.Context) error {
+	return hs.newReview(ctx, models.EntityTypeEvent, "eventId")
 }
 
 // EditReviewsEvent
@@ -543,94 +565,14 @@ func (hs *handlerService) NewReviewEvent(ctx *gin.Context) {
 // @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
 // @Param review_text body string false "Новый текст отзыва (минимум 6 символов)"
 // @Param stars body float64 false "Новая оценка события (от 1 до 5)"
-// @Success 200 {object} models.ReviewEvent
+// @Success 200 {object} models.Review
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /events/{eventId}/reviews [patch]
-func (hs *handlerService) EditReviewsEvent(ctx *gin.Context) {
-	var paramsURI struct {
-		EventID string `uri:"eventId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	var params struct {
-		ReviewText string  `json:"review_text"`
-		Stars      float64 `json:"stars"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-	vkParams := hs.GetVKParams(ctx)
-
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
-	if err != nil {
-		hs.logger.Debug("Error get user", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	eventID, _ := uuid.Parse(paramsURI.EventID)
-	reviewEvent, err := hs.pg.GetReviewEvent(ctx, user.ID, eventID)
-
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, models.NewErrorResponse(errs.NewNotFound("Review event not found")))
-		} else {
-			hs.logger.Error("Error get event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
-	}
-
-	if params.ReviewText != "" {
-		if len(params.ReviewText) < 6 {
-			ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"ReviewText\" failed on the 'min=6' tag.")))
-			ctx.Abort()
-
-			return
-		}
-
-		reviewEvent.ReviewText = params.ReviewText
-	}
-	if params.Stars != 0 {
-		if params.Stars < 1 || params.Stars > 5 {
-			ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"Stars\" failed on the 'min=1,max=5' tag.")))
-			ctx.Abort()
-
-			return
-		} else {
-			reviewEvent.Stars = math.Round(params.Stars)
-		}
-	}
-
-	if err = hs.pg.SaveReviewEvent(ctx, reviewEvent); err != nil {
-		hs.logger.Debug("Error save review event", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	ctx.JSON(http.StatusOK, models.NewResponse(reviewEvent))
-	ctx.Abort()
+func (hs *handlerService) EditReviewsEvent(ctx *gin.Context) error {
+	return hs.editReview(ctx, models.EntityTypeEvent, "eventId")
 }
 
 // GetReviewsEvent
@@ -641,34 +583,91 @@ func (hs *handlerService) EditReviewsEvent(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
 // @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
-// @Success 200 {object} models.ReviewEvent
+// @Param sort query string false "Сортировка: recent (по умолчанию), helpful или stars"
+// @Success 200 {object} []models.ReviewWithMeta
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /events/{eventId}/reviews [get]
-func (hs *handlerService) GetReviewsEvent(ctx *gin.Context) {
-	var params struct {
-		EventID string `uri:"eventId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	eventID, _ := uuid.Parse(params.EventID)
-	reviews, err := hs.pg.GetReviewsEvent(ctx, eventID)
+func (hs *handlerService) GetReviewsEvent(ctx *gin.Context) error {
+	return hs.getReviews(ctx, models.EntityTypeEvent, "eventId")
+}
 
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		hs.logger.Debug("Error get reviews", zap.Error(err))
+// VoteReviewEvent
+// @Summary Отметить отзыв о событии полезным
+// @Description Добавляет отметку "полезно" от текущего пользователя к отзыву о событии.
+// @ID vote-review-event
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Success 200 {object} models.ReviewVote
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/{eventId}/reviews/{reviewId}/helpful [post]
+func (hs *handlerService) VoteReviewEvent(ctx *gin.Context) error {
+	return hs.voteReview(ctx, models.EntityTypeEvent, "eventId")
+}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+// UnvoteReviewEvent
+// @Summary Снять отметку "полезно" с отзыва о событии
+// @Description Удаляет отметку "полезно" текущего пользователя с отзыва о событии.
+// @ID unvote-review-event
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/{eventId}/reviews/{reviewId}/helpful [delete]
+func (hs *handlerService) UnvoteReviewEvent(ctx *gin.Context) error {
+	return hs.unvoteReview(ctx, models.EntityTypeEvent, "eventId")
+}
 
-		return
-	}
+// NewReviewEventReply
+// @Summary Ответить на отзыв о событии
+// @Description Создает официальный ответ компании-владельца на отзыв о событии. На каждый отзыв возможен только один ответ.
+// @ID new-review-event-reply
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Param reply_text body string true "Текст ответа (минимум 2 символа)"
+// @Success 200 {object} models.ReviewReply
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/{eventId}/reviews/{reviewId}/reply [post]
+func (hs *handlerService) NewReviewEventReply(ctx *gin.Context) error {
+	return hs.replyReview(ctx, models.EntityTypeEvent, "eventId", false)
+}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(reviews))
-	ctx.Abort()
+// EditReviewEventReply
+// @Summary Редактировать ответ на отзыв о событии
+// @Description Редактирует официальный ответ компании-владельца на отзыв о событии.
+// @ID edit-review-event-reply
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Param reply_text body string true "Текст ответа (минимум 2 символа)"
+// @Success 200 {object} models.ReviewReply
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/{eventId}/reviews/{reviewId}/reply [patch]
+func (hs *handlerService) EditReviewEventReply(ctx *gin.Context) error {
+	return hs.replyReview(ctx, models.EntityTypeEvent, "eventId", true)
 }
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/ShpullRequest/backend/pkg/geocoder"
+	"github.com/ShpullRequest/backend/pkg/ip"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SuggestAddress
+// @Summary Подсказки адреса
+// @Description Возвращает варианты адреса для текстового запроса со структурированными компонентами и координатами.
+// @Description Выбранный вариант можно передать в address при создании места или события.
+// @ID suggest-address
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param q query string true "Текст адреса (минимум 3 символа)"
+// @Param limit query integer false "Количество вариантов (по умолчанию 5, максимум 10)"
+// @Success 200 {object} []models.GeoCandidate
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 502 {object} models.ErrorResponse
+// @Router /geo/suggest [get]
+func (hs *handlerService) SuggestAddress(ctx *gin.Context) error {
+	var params struct {
+		Q     string `form:"q" binding:"required,min=3,max=255"`
+		Limit int    `form:"limit" binding:"omitempty,min=1,max=10"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	if params.Limit == 0 {
+		params.Limit = 5
+	}
+
+	candidates, err := hs.geocoder.Suggest(ctx, params.Q, params.Limit)
+	if err != nil {
+		return errs.New(errs.CodeVKMapsUnavailable).Wrap(err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(candidates))
+
+	return nil
+}
+
+// resolveAddress определяет адрес по тексту, если координаты не переданы, и по координатам в остальных случаях.
+// Если геокодер недоступен, а координаты переданы, они сохраняются без текста адреса, чтобы его
+// можно было заполнить позже.
+func (hs *handlerService) resolveAddress(ctx *gin.Context, query string, lng, lat float64) (*models.GeoCandidate, error) {
+	var candidate *models.GeoCandidate
+	var err error
+
+	if query != "" && lng == 0 && lat == 0 {
+		var candidates []models.GeoCandidate
+		if candidates, err = hs.geocoder.Suggest(ctx, query, 1); err == nil {
+			if len(candidates) == 0 {
+				err = geocoder.ErrNoAddress
+			} else {
+				candidate = &candidates[0]
+			}
+		}
+	} else {
+		candidate, err = hs.geocoder.GetAddressByGeo(ctx, lng, lat)
+	}
+
+	if err != nil {
+		if errors.Is(err, geocoder.ErrNoAddress) {
+			return nil, errs.New(errs.CodeAddressNotFound)
+		}
+		if lng != 0 || lat != 0 {
+			hs.logger.Warn("Geocoder unavailable, saving address without text", zap.Error(err))
+			return &models.GeoCandidate{Lng: lng, Lat: lat}, nil
+		}
+
+		return nil, errs.New(errs.CodeVKMapsUnavailable).Wrap(err)
+	}
+
+	return candidate, nil
+}
+
+// GetPlacesNearby
+// @Summary Места рядом
+// @Description Возвращает места в радиусе от точки, ближайшие первыми. Без координат используется
+// @Description выбранная пользователем точка, центр его домашнего города или местоположение по IP.
+// @ID get-places-nearby
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
+// @Param lat query number false "Широта центра поиска"
+// @Param lng query number false "Долгота центра поиска"
+// @Param radius query number false "Радиус поиска в километрах (по умолчанию 10, максимум 200)"
+// @Param limit query integer false "Количество мест (по умолчанию 50, максимум 100)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Success 200 {object} []models.Place
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/nearby [get]
+func (hs *handlerService) GetPlacesNearby(ctx *gin.Context) error {
+	query, err := hs.nearbyQuery(ctx)
+	if err != nil {
+		return err
+	}
+
+	cityID, err := hs.cityID(ctx)
+	if err != nil {
+		return err
+	}
+
+	places, err := hs.pg.GetPlacesNearby(ctx, query.center, query.Radius, query.Limit, cityID)
+	if err != nil {
+		return fmt.Errorf("get places nearby: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, places)))
+
+	return nil
+}
+
+// GetEventsNearby
+// @Summary События рядом
+// @Description Возвращает предстоящие события в радиусе от точки, ближайшие первыми. Без координат используется
+// @Description выбранная пользователем точка, центр его домашнего города или местоположение по IP.
+// @ID get-events-nearby
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
+// @Param lat query number false "Широта центра поиска"
+// @Param lng query number false "Долгота центра поиска"
+// @Param radius query number false "Радиус поиска в километрах (по умолчанию 10, максимум 200)"
+// @Param limit query integer false "Количество событий (по умолчанию 50, максимум 100)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Success 200 {object} []models.Event
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/nearby [get]
+func (hs *handlerService) GetEventsNearby(ctx *gin.Context) error {
+	query, err := hs.nearbyQuery(ctx)
+	if err != nil {
+		return err
+	}
+
+	cityID, err := hs.cityID(ctx)
+	if err != nil {
+		return err
+	}
+
+	events, err := hs.pg.GetEventsNearby(ctx, query.center, query.Radius, query.Limit, cityID)
+	if err != nil {
+		return fmt.Errorf("get events nearby: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, events)))
+
+	return nil
+}
+
+type nearbyQuery struct {
+	Lat    *float64 `form:"lat" binding:"omitempty,latitude"`
+	Lng    *float64 `form:"lng" binding:"omitempty,longitude"`
+	Radius float64  `form:"radius" binding:"omitempty,gt=0,lte=200"`
+	Limit  int      `form:"limit" binding:"omitempty,min=1,max=100"`
+
+	center models.GeoPoint
+}
+
+// nearbyQuery разбирает параметры выборки "рядом" и определяет ее центр.
+func (hs *handlerService) nearbyQuery(ctx *gin.Context) (*nearbyQuery, error) {
+	var query nearbyQuery
+
+	if err := hs.validateAndShouldBindQuery(ctx, &query); err != nil {
+		return nil, err
+	}
+
+	if query.Radius == 0 {
+		query.Radius = 10
+	}
+	if query.Limit == 0 {
+		query.Limit = 50
+	}
+
+	if (query.Lat == nil) != (query.Lng == nil) {
+		return nil, errs.New(errs.CodeLocationIncomplete)
+	}
+
+	if query.Lat != nil {
+		query.center = models.GeoPoint{Lat: *query.Lat, Lng: *query.Lng}
+		return &query, nil
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	homeCity, err := hs.locality(ctx, user.HomeCityID)
+	if err != nil {
+		return nil, err
+	}
+
+	center := defaultMapCenter(user, homeCity, hs.locateClient(ctx))
+	if center == nil {
+		return nil, errs.New(errs.CodeLocationUnknown)
+	}
+
+	query.center = *center
+	return &query, nil
+}
+
+// defaultMapCenter выбирает центр карты и выборок "рядом": точку, выбранную пользователем,
+// затем центр домашнего города и в последнюю очередь местоположение по IP.
+func defaultMapCenter(user *models.User, homeCity *models.Locality, currentGeo *models.GeoPoint) *models.GeoPoint {
+	switch {
+	case user != nil && user.SelectedGeo != nil:
+		return user.SelectedGeo
+	case homeCity != nil:
+		return &homeCity.Center
+	default:
+		return currentGeo
+	}
+}
+
+// locateClient определяет местоположение клиента по IP. Ошибки не прерывают запрос.
+func (hs *handlerService) locateClient(ctx *gin.Context) *models.GeoPoint {
+	addr, err := netip.ParseAddr(ctx.ClientIP())
+	if err != nil {
+		return nil
+	}
+
+	location, err := hs.locator.Locate(ctx, addr)
+	if err != nil {
+		if !errors.Is(err, ip.ErrNotFound) {
+			hs.logger.Error("Error get user geo", zap.Error(err))
+		}
+
+		return nil
+	}
+
+	return &models.GeoPoint{Lat: location.Lat, Lng: location.Lng}
+}
+
+// locality загружает город или регион пользователя. Пустой идентификатор - не ошибка.
+func (hs *handlerService) locality(ctx *gin.Context, id *uuid.UUID) (*models.Locality, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	locality, err := hs.pg.GetLocality(ctx, *id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get locality: %w", err)
+	}
+
+	return locality, nil
+}
+
+func (hs *handlerService) saveLocality(ctx *gin.Context, locality models.Locality) (*models.Locality, error) {
+	saved, err := hs.pg.UpsertLocality(ctx, locality)
+	if err != nil {
+		return nil, fmt.Errorf("save locality: %w", err)
+	}
+
+	return saved, nil
+}
@@ -3,69 +3,205 @@ package handlers
 import (
 	_ "github.com/ShpullRequest/backend/docs"
 	"github.com/ShpullRequest/backend/internal/api"
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/models"
 	"github.com/ShpullRequest/backend/internal/repository"
+	"github.com/ShpullRequest/backend/pkg/blobstore"
+	"github.com/ShpullRequest/backend/pkg/geocoder"
+	"github.com/ShpullRequest/backend/pkg/ip"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
 	"go.uber.org/zap"
 )
 
+// handlerFunc обработчик запроса. Ответ на возвращенную ошибку формирует middleware Errors
+type handlerFunc func(ctx *gin.Context) error
+
 type handlerService struct {
-	pg     *repository.Pg
-	logger *zap.Logger
+	pg       *repository.Pg
+	logger   *zap.Logger
+	blobs    blobstore.Store
+	sessions *auth.Sessions
+	geocoder geocoder.Geocoder
+	locator  ip.Locator
 }
 
 func ConfigureService(apiService api.Service) {
 	hs := &handlerService{
-		pg:     apiService.GetPg(),
-		logger: apiService.GetLogger(),
+		pg:       apiService.GetPg(),
+		logger:   apiService.GetLogger(),
+		blobs:    apiService.GetBlobStore(),
+		sessions: apiService.GetSessions(),
+		geocoder: apiService.GetGeocoder(),
+		locator:  apiService.GetLocator(),
+	}
+
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(fieldName)
 	}
 
-	apiService.GetRouter().GET("/achievements/", hs.GetAllAchievements)
-	apiService.GetRouter().GET("/achievements/:achievementId", hs.GetAchievement)
-	apiService.GetRouter().POST("/achievements/", hs.NewAchievement)
-	apiService.GetRouter().PATCH("/achievements/:achievementId", hs.EditAchievement)
-
-	apiService.GetRouter().GET("/users/", hs.GetMe)
-	apiService.GetRouter().GET("/users/:vkId/", hs.GetUserByVkID)
-	apiService.GetRouter().PATCH("/users/", hs.EditUser)
-
-	apiService.GetRouter().GET("/companies/", hs.GetAllCompanies)
-	apiService.GetRouter().GET("/companies/:companyId/", hs.GetCompany)
-	apiService.GetRouter().GET("/companies/my/", hs.GetMyCompanies)
-	apiService.GetRouter().POST("/companies/", hs.NewCompany)
-	apiService.GetRouter().POST("/companies/:companyId/accept/", hs.AcceptCompany)
-
-	apiService.GetRouter().GET("/places/", hs.GetAllPlaces)
-	apiService.GetRouter().GET("/places/search/:query/", hs.SearchPlaces)
-	apiService.GetRouter().GET("/places/:placeId", hs.GetPlace)
-	apiService.GetRouter().GET("/places/:placeId/reviews", hs.GetReviewsPlace)
-	apiService.GetRouter().POST("/places/", hs.NewPlace)
-	apiService.GetRouter().POST("/places/:placeId/reviews/", hs.NewReviewPlace)
-	apiService.GetRouter().PATCH("/places/:placeId", hs.EditPlace)
-	apiService.GetRouter().PATCH("/places/:placeId/reviews/", hs.EditReviewPlace)
-
-	apiService.GetRouter().GET("/events/", hs.GetAllEvents)
-	apiService.GetRouter().GET("/events/company/:companyId", hs.GetCompanyEvents)
-	apiService.GetRouter().GET("/events/search/:query/", hs.SearchEvents)
-	apiService.GetRouter().GET("/events/:eventId/", hs.GetEvent)
-	apiService.GetRouter().GET("/events/:eventId/reviews/", hs.GetReviewsEvent)
-	apiService.GetRouter().POST("/events/", hs.NewEvent)
-	apiService.GetRouter().POST("/events/:eventId/reviews/", hs.NewReviewEvent)
-	apiService.GetRouter().PATCH("/events/:eventId/", hs.EditEvent)
-	apiService.GetRouter().PATCH("/events/:eventId/reviews/", hs.EditReviewsEvent)
-
-	apiService.GetRouter().GET("/routes/", hs.GetAllRoutes)
-	apiService.GetRouter().GET("/routes/company/:companyId/", hs.GetCompanyRoutes)
-	apiService.GetRouter().GET("/routes/search/:query/", hs.SearchRoutes)
-	apiService.GetRouter().GET("/routes/:routeId/", hs.GetRoute)
-	apiService.GetRouter().GET("/routes/:routeId/reviews/", hs.GetReviewsRoutes)
-	apiService.GetRouter().POST("/routes/", hs.NewRoute)
-	apiService.GetRouter().POST("/routes/:routeId/reviews/", hs.NewReviewRoute)
-	apiService.GetRouter().PATCH("/routes/:routeId/", hs.EditRoute)
-	apiService.GetRouter().PATCH("/routes/:routeId/reviews/", hs.EditReviewRoute)
+	apiService.GetRouter().Use(hs.middleware(hs.requireAPIKeyScope))
+
+	apiService.GetRouter().POST("/auth/sessions/", hs.handle(hs.NewSession))
+	// Изменяющие методы объявляются с requirePermission или перечисляются в handlerAuthorization
+	apiService.GetRouter().GET("/auth/sessions/", hs.handle(hs.GetSessions))
+	apiService.GetRouter().DELETE("/auth/sessions/:sessionId/", hs.handle(hs.RevokeSession))
+	apiService.GetRouter().POST("/auth/refresh/", hs.handle(hs.RefreshSession))
+
+	apiService.GetRouter().GET("/achievements/", hs.handle(hs.GetAllAchievements))
+	apiService.GetRouter().GET("/achievements/:achievementId", hs.handle(hs.GetAchievement))
+	apiService.GetRouter().POST("/achievements/", hs.requirePermission(models.PermissionAchievementManage), hs.handle(hs.NewAchievement))
+	apiService.GetRouter().PATCH("/achievements/:achievementId", hs.requirePermission(models.PermissionAchievementManage), hs.handle(hs.EditAchievement))
+
+	apiService.GetRouter().GET("/users/", hs.handle(hs.GetMe))
+	apiService.GetRouter().GET("/users/:vkId/", hs.handle(hs.GetUserByVkID))
+	apiService.GetRouter().PATCH("/users/", hs.handle(hs.EditUser))
+	apiService.GetRouter().GET("/users/:vkId/roles/", hs.requirePermission(models.PermissionRoleManage), hs.handle(hs.GetUserRoles))
+	apiService.GetRouter().POST("/users/:vkId/roles/", hs.requirePermission(models.PermissionRoleManage), hs.handle(hs.GrantRole))
+	apiService.GetRouter().DELETE("/users/:vkId/roles/:role/", hs.requirePermission(models.PermissionRoleManage), hs.handle(hs.RevokeRole))
+
+	apiService.GetRouter().GET("/roles/", hs.requirePermission(models.PermissionRoleManage), hs.handle(hs.GetRoles))
+	apiService.GetRouter().GET("/roles/audit/", hs.requirePermission(models.PermissionRoleManage), hs.handle(hs.GetRolesAudit))
+
+	apiService.GetRouter().GET("/api-keys/", hs.requirePermission(models.PermissionAPIKeyManage), hs.handle(hs.GetAllAPIKeys))
+	apiService.GetRouter().POST("/api-keys/", hs.requirePermission(models.PermissionAPIKeyManage), hs.handle(hs.NewAPIKey))
+	apiService.GetRouter().PATCH("/api-keys/:keyId/", hs.requirePermission(models.PermissionAPIKeyManage), hs.handle(hs.EditAPIKey))
+	apiService.GetRouter().DELETE("/api-keys/:keyId/", hs.requirePermission(models.PermissionAPIKeyManage), hs.handle(hs.RevokeAPIKey))
+	apiService.GetRouter().GET("/api-keys/:keyId/usage/", hs.requirePermission(models.PermissionAPIKeyManage), hs.handle(hs.GetAPIKeyUsage))
+
+	apiService.GetRouter().GET("/companies/", hs.handle(hs.GetAllCompanies))
+	apiService.GetRouter().GET("/companies/:companyId/", hs.handle(hs.GetCompany))
+	apiService.GetRouter().GET("/companies/my/", hs.handle(hs.GetMyCompanies))
+	apiService.GetRouter().POST("/companies/", hs.requirePermission(models.PermissionCompanyCreate), hs.handle(hs.NewCompany))
+	apiService.GetRouter().POST("/companies/:companyId/accept/", hs.requirePermission(models.PermissionCompanyApprove), hs.handle(hs.AcceptCompany))
+	apiService.GetRouter().PATCH("/companies/:companyId/", hs.handle(hs.EditCompany))
+	apiService.GetRouter().GET("/companies/:companyId/claims/", hs.handle(hs.GetCompanyPlaceClaims))
+	apiService.GetRouter().GET("/companies/:companyId/members/", hs.handle(hs.GetCompanyMembers))
+	apiService.GetRouter().POST("/companies/:companyId/members/", hs.handle(hs.AddCompanyMember))
+	apiService.GetRouter().DELETE("/companies/:companyId/members/:vkId/", hs.handle(hs.RemoveCompanyMember))
+	apiService.GetRouter().GET("/companies/:companyId/suggestions/", hs.handle(hs.GetCompanyEditSuggestions))
+
+	apiService.GetRouter().GET("/geo/suggest/", hs.handle(hs.SuggestAddress))
+
+	apiService.GetRouter().GET("/recommendations/", hs.handle(hs.GetRecommendations))
+	apiService.GetRouter().GET("/trending/", hs.handle(hs.GetTrending))
+
+	apiService.GetRouter().GET("/bookmarks/", hs.handle(hs.GetBookmarks))
+	apiService.GetRouter().POST("/bookmarks/", hs.handle(hs.NewBookmark))
+	apiService.GetRouter().DELETE("/bookmarks/:entityType/:entityId/", hs.handle(hs.DeleteBookmark))
+
+	apiService.GetRouter().GET("/checkins/", hs.handle(hs.GetCheckins))
+	apiService.GetRouter().POST("/checkins/", hs.handle(hs.NewCheckin))
+
+	apiService.GetRouter().GET("/localities/", hs.handle(hs.GetLocalities))
+	apiService.GetRouter().GET("/localities/:localityId/", hs.handle(hs.GetLocality))
+	apiService.GetRouter().GET("/localities/:localityId/landing/", hs.handle(hs.GetLocalityLanding))
+	apiService.GetRouter().POST("/localities/", hs.requirePermission(models.PermissionLocalityManage), hs.handle(hs.NewLocality))
+	apiService.GetRouter().PATCH("/localities/:localityId/", hs.requirePermission(models.PermissionLocalityManage), hs.handle(hs.EditLocality))
+
+	apiService.GetRouter().GET("/claims/", hs.requirePermission(models.PermissionPlaceClaimReview), hs.handle(hs.GetPlaceClaims))
+	apiService.GetRouter().POST("/claims/:claimId/approve/", hs.requirePermission(models.PermissionPlaceClaimReview), hs.handle(hs.ApprovePlaceClaim))
+	apiService.GetRouter().POST("/claims/:claimId/reject/", hs.requirePermission(models.PermissionPlaceClaimReview), hs.handle(hs.RejectPlaceClaim))
+
+	apiService.GetRouter().GET("/suggestions/", hs.requirePermission(models.PermissionSuggestionReview), hs.handle(hs.GetEditSuggestions))
+	apiService.GetRouter().POST("/suggestions/:suggestionId/accept/", hs.handle(hs.AcceptEditSuggestion))
+	apiService.GetRouter().POST("/suggestions/:suggestionId/reject/", hs.handle(hs.RejectEditSuggestion))
+
+	apiService.GetRouter().GET("/history/:entityType/:entityId/", hs.requirePermission(models.PermissionHistoryManage), hs.handle(hs.GetEntityHistory))
+	apiService.GetRouter().GET("/history/:entityType/:entityId/diff/", hs.requirePermission(models.PermissionHistoryManage), hs.handle(hs.GetEntityHistoryDiff))
+	apiService.GetRouter().POST("/history/:entityType/:entityId/revert/", hs.requirePermission(models.PermissionHistoryManage), hs.handle(hs.RevertEntity))
+
+	apiService.GetRouter().GET("/tags/", hs.handle(hs.GetTags))
+	apiService.GetRouter().GET("/tags/:tagId/", hs.handle(hs.GetTag))
+	apiService.GetRouter().POST("/tags/", hs.requirePermission(models.PermissionTagManage), hs.handle(hs.NewTag))
+	apiService.GetRouter().PATCH("/tags/:tagId/", hs.requirePermission(models.PermissionTagManage), hs.handle(hs.EditTag))
+	apiService.GetRouter().POST("/tags/:tagId/merge/", hs.requirePermission(models.PermissionTagManage), hs.handle(hs.MergeTag))
+
+	apiService.GetRouter().GET("/places/", hs.handle(hs.GetAllPlaces))
+	apiService.GetRouter().GET("/places/search/:query/", hs.handle(hs.SearchPlaces))
+	apiService.GetRouter().GET("/places/nearby/", hs.handle(hs.GetPlacesNearby))
+	apiService.GetRouter().GET("/places/duplicates/", hs.requirePermission(models.PermissionPlaceMerge), hs.handle(hs.GetPlaceDuplicates))
+	apiService.GetRouter().POST("/places/duplicates/dismiss/", hs.requirePermission(models.PermissionPlaceMerge), hs.handle(hs.DismissPlaceDuplicate))
+	apiService.GetRouter().GET("/places/:placeId", hs.handle(hs.GetPlace))
+	apiService.GetRouter().GET("/places/:placeId/reviews", hs.handle(hs.GetReviewsPlace))
+	apiService.GetRouter().GET("/places/:placeId/hours/", hs.handle(hs.GetPlaceHours))
+	apiService.GetRouter().POST("/places/", hs.requirePermission(models.PermissionPlaceCreate), hs.handle(hs.NewPlace))
+	apiService.GetRouter().POST("/places/:placeId/reviews/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.NewReviewPlace))
+	apiService.GetRouter().PATCH("/places/:placeId", hs.handle(hs.EditPlace))
+	apiService.GetRouter().POST("/places/:placeId/claims/", hs.requirePermission(models.PermissionPlaceCreate), hs.handle(hs.NewPlaceClaim))
+	apiService.GetRouter().DELETE("/places/:placeId", hs.requirePermission(models.PermissionPlaceDelete), hs.handle(hs.DeletePlace))
+	apiService.GetRouter().POST("/places/:placeId/restore/", hs.requirePermission(models.PermissionPlaceDelete), hs.handle(hs.RestorePlace))
+	apiService.GetRouter().POST("/places/:placeId/merge/", hs.requirePermission(models.PermissionPlaceMerge), hs.handle(hs.MergePlace))
+	apiService.GetRouter().PATCH("/places/:placeId/reviews/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.EditReviewPlace))
+	apiService.GetRouter().POST("/places/:placeId/reviews/:reviewId/helpful/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.VoteReviewPlace))
+	apiService.GetRouter().DELETE("/places/:placeId/reviews/:reviewId/helpful/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.UnvoteReviewPlace))
+
+	apiService.GetRouter().GET("/events/", hs.handle(hs.GetAllEvents))
+	apiService.GetRouter().GET("/events/company/:companyId", hs.handle(hs.GetCompanyEvents))
+	apiService.GetRouter().GET("/events/search/:query/", hs.handle(hs.SearchEvents))
+	apiService.GetRouter().GET("/events/nearby/", hs.handle(hs.GetEventsNearby))
+	apiService.GetRouter().GET("/events/:eventId/", hs.handle(hs.GetEvent))
+	apiService.GetRouter().GET("/events/:eventId/reviews/", hs.handle(hs.GetReviewsEvent))
+	apiService.GetRouter().POST("/events/", hs.requirePermission(models.PermissionEventCreate), hs.handle(hs.NewEvent))
+	apiService.GetRouter().POST("/events/:eventId/reviews/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.NewReviewEvent))
+	apiService.GetRouter().PATCH("/events/:eventId/", hs.handle(hs.EditEvent))
+	apiService.GetRouter().DELETE("/events/:eventId/", hs.requirePermission(models.PermissionEventDelete), hs.handle(hs.DeleteEvent))
+	apiService.GetRouter().POST("/events/:eventId/restore/", hs.requirePermission(models.PermissionEventDelete), hs.handle(hs.RestoreEvent))
+	apiService.GetRouter().POST("/events/:eventId/rsvp/", hs.handle(hs.NewRSVPEvent))
+	apiService.GetRouter().DELETE("/events/:eventId/rsvp/", hs.handle(hs.DeleteRSVPEvent))
+	apiService.GetRouter().PATCH("/events/:eventId/reviews/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.EditReviewsEvent))
+	apiService.GetRouter().POST("/events/:eventId/reviews/:reviewId/helpful/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.VoteReviewEvent))
+	apiService.GetRouter().DELETE("/events/:eventId/reviews/:reviewId/helpful/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.UnvoteReviewEvent))
+	apiService.GetRouter().POST("/events/:eventId/reviews/:reviewId/reply/", hs.requirePermission(models.PermissionReviewReply), hs.handle(hs.NewReviewEventReply))
+	apiService.GetRouter().PATCH("/events/:eventId/reviews/:reviewId/reply/", hs.requirePermission(models.PermissionReviewReply), hs.handle(hs.EditReviewEventReply))
+
+	apiService.GetRouter().GET("/routes/", hs.handle(hs.GetAllRoutes))
+	apiService.GetRouter().GET("/routes/company/:companyId/", hs.handle(hs.GetCompanyRoutes))
+	apiService.GetRouter().GET("/routes/search/:query/", hs.handle(hs.SearchRoutes))
+	apiService.GetRouter().GET("/routes/:routeId/", hs.handle(hs.GetRoute))
+	apiService.GetRouter().GET("/routes/:routeId/reviews/", hs.handle(hs.GetReviewsRoutes))
+	apiService.GetRouter().POST("/routes/", hs.requirePermission(models.PermissionRouteCreate), hs.handle(hs.NewRoute))
+	apiService.GetRouter().POST("/routes/:routeId/reviews/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.NewReviewRoute))
+	apiService.GetRouter().PATCH("/routes/:routeId/", hs.handle(hs.EditRoute))
+	apiService.GetRouter().DELETE("/routes/:routeId/", hs.requirePermission(models.PermissionRouteDelete), hs.handle(hs.DeleteRoute))
+	apiService.GetRouter().POST("/routes/:routeId/restore/", hs.requirePermission(models.PermissionRouteDelete), hs.handle(hs.RestoreRoute))
+	apiService.GetRouter().PATCH("/routes/:routeId/reviews/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.EditReviewRoute))
+	apiService.GetRouter().POST("/routes/:routeId/reviews/:reviewId/helpful/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.VoteReviewRoute))
+	apiService.GetRouter().DELETE("/routes/:routeId/reviews/:reviewId/helpful/", hs.requirePermission(models.PermissionReviewCreate), hs.handle(hs.UnvoteReviewRoute))
+	apiService.GetRouter().POST("/routes/:routeId/reviews/:reviewId/reply/", hs.requirePermission(models.PermissionReviewReply), hs.handle(hs.NewReviewRouteReply))
+	apiService.GetRouter().PATCH("/routes/:routeId/reviews/:reviewId/reply/", hs.requirePermission(models.PermissionReviewReply), hs.handle(hs.EditReviewRouteReply))
+
+	apiService.GetRouter().DELETE("/reviews/:reviewId/", hs.requirePermission(models.PermissionReviewModerate), hs.handle(hs.DeleteReview))
+
+	apiService.GetRouter().POST("/uploads/", hs.requirePermission(models.PermissionUploadCreate), hs.handle(hs.UploadImage))
+	apiService.GetRouter().GET("/blobs/*key", hs.handle(hs.GetBlob))
 
 	apiService.GetRouter().GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	apiService.GetRouter().NoRoute(hs.NoRoute)
-	apiService.GetRouter().NoMethod(hs.NoRoute)
+	apiService.GetRouter().NoRoute(hs.handle(hs.NoRoute))
+}
+
+// handle превращает handlerFunc в обработчик метода: ошибка прерывает запрос и передается middleware Errors.
+// Перед обработчиком изменяющего метода проверяется, что доступ к нему проверен (checkRouteAuthorization).
+func (hs *handlerService) handle(handler handlerFunc) gin.HandlerFunc {
+	return hs.middleware(func(ctx *gin.Context) error {
+		if err := hs.checkRouteAuthorization(ctx); err != nil {
+			return err
+		}
+
+		return handler(ctx)
+	})
+}
+
+// middleware превращает handlerFunc в промежуточный обработчик gin с той же передачей ошибок.
+func (hs *handlerService) middleware(handler handlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if err := handler(ctx); err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+		}
+	}
 }
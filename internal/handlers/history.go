@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/ShpullRequest/backend/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type historyURI struct {
+	EntityType string `uri:"entityType" binding:"required,oneof=place event route company"`
+	EntityID   string `uri:"entityId" binding:"required,uuid"`
+}
+
+// versionedEntity загруженная сущность с историей изменений
+type versionedEntity struct {
+	// companyID компания-владелец сущности, для самой компании - ее идентификатор
+	companyID *uuid.UUID
+	// snapshot указатель на значение, по которому снимаются и восстанавливаются версии
+	snapshot any
+	// value сохраняемое и возвращаемое в ответе значение
+	value any
+}
+
+// routeVersion маршрут в истории изменений. Места и события не входят в JSON-представление маршрута,
+// поэтому добавляются в снимок отдельно.
+type routeVersion struct {
+	Route *models.Route
+}
+
+type routeSnapshot struct {
+	*models.Route
+	Places pq.StringArray `json:"places"`
+	Events pq.StringArray `json:"events"`
+}
+
+func (v routeVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(routeSnapshot{Route: v.Route, Places: v.Route.Places, Events: v.Route.Events})
+}
+
+func (v *routeVersion) UnmarshalJSON(data []byte) error {
+	snapshot := routeSnapshot{Route: v.Route, Places: v.Route.Places, Events: v.Route.Events}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	v.Route.Places, v.Route.Events = snapshot.Places, snapshot.Events
+	return nil
+}
+
+// GetEntityHistory
+// @Summary История изменений
+// @Description Возвращает версии места, события, маршрута или компании, новые первыми.
+// @ID get-entity-history
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param entityType path string true "Тип сущности: place, event, route, company"
+// @Param entityId path string true "Уникальный идентификатор сущности (в формате UUID)"
+// @Success 200 {object} []models.EntityVersion
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /history/{entityType}/{entityId} [get]
+func (hs *handlerService) GetEntityHistory(ctx *gin.Context) error {
+	var paramsURI historyURI
+
+	if err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
+		return err
+	}
+
+	entityID, _ := uuid.Parse(paramsURI.EntityID)
+	versions, err := hs.pg.GetEntityVersions(ctx, paramsURI.EntityType, entityID)
+	if err != nil {
+		return fmt.Errorf("get entity versions: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(versions))
+
+	return nil
+}
+
+// GetEntityHistoryDiff
+// @Summary Сравнение версий
+// @Description Возвращает поля, изменившиеся между двумя версиями сущности. По умолчанию последняя версия
+// @Description сравнивается с предыдущей.
+// @ID get-entity-history-diff
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param entityType path string true "Тип сущности: place, event, route, company"
+// @Param entityId path string true "Уникальный идентификатор сущности (в формате UUID)"
+// @Param from query integer false "Номер исходной версии, по умолчанию предыдущая перед to"
+// @Param to query integer false "Номер конечной версии, по умолчанию последняя"
+// @Success 200 {object} models.VersionDiff
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /history/{entityType}/{entityId}/diff [get]
+func (hs *handlerService) GetEntityHistoryDiff(ctx *gin.Context) error {
+	var paramsURI historyURI
+
+	if err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
+		return err
+	}
+
+	var params struct {
+		From int `form:"from" binding:"omitempty,min=1"`
+		To   int `form:"to" binding:"omitempty,min=1"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	entityID, _ := uuid.Parse(paramsURI.EntityID)
+	to, err := hs.entityVersion(ctx, paramsURI.EntityType, entityID, params.To)
+	if err != nil {
+		return err
+	}
+
+	if params.From == 0 {
+		params.From = to.Version - 1
+	}
+
+	// Первая версия сравнивается с пустой сущностью
+	from := &models.EntityVersion{}
+	if params.From > 0 {
+		if from, err = hs.entityVersion(ctx, paramsURI.EntityType, entityID, params.From); err != nil {
+			return err
+		}
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(models.VersionDiff{
+		EntityType:  paramsURI.EntityType,
+		EntityID:    entityID,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Changes:     to.Snapshot.Diff(from.Snapshot),
+	}))
+
+	return nil
+}
+
+// RevertEntity
+// @Summary Откатить сущность к версии
+// @Description Восстанавливает поля сущности из указанной версии. Откат сохраняется в истории как новая версия,
+// @Description владелец, статус удаления и рейтинг сущности не меняются.
+// @ID revert-entity
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param entityType path string true "Тип сущности: place, event, route, company"
+// @Param entityId path string true "Уникальный идентификатор сущности (в формате UUID)"
+// @Param version body integer true "Номер версии, к которой откатывается сущность"
+// @Success 200 {object} object "Сущность после отката"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /history/{entityType}/{entityId}/revert [post]
+func (hs *handlerService) RevertEntity(ctx *gin.Context) error {
+	var paramsURI historyURI
+
+	if err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
+		return err
+	}
+
+	var params struct {
+		Version int `json:"version" binding:"required,min=1"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	entityID, _ := uuid.Parse(paramsURI.EntityID)
+	version, err := hs.entityVersion(ctx, paramsURI.EntityType, entityID, params.Version)
+	if err != nil {
+		return err
+	}
+
+	if _, err := hs.versionedEntity(ctx, paramsURI.EntityType, entityID); err != nil {
+		return err
+	}
+
+	entity, err := hs.updateVersioned(ctx, paramsURI.EntityType, entityID, models.EntityVersion{
+		EntityType: paramsURI.EntityType,
+		EntityID:   entityID,
+		Action:     models.VersionActionRevert,
+		UserID:     &user.ID,
+	}, func(entity *versionedEntity) error {
+		if err := version.Snapshot.Apply(entity.snapshot); err != nil {
+			return fmt.Errorf("apply entity snapshot: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save reverted entity: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(entity.value))
+
+	return nil
+}
+
+// entityVersion загружает версию сущности по номеру, 0 - последнюю версию.
+func (hs *handlerService) entityVersion(ctx *gin.Context, entityType string, entityID uuid.UUID, number int) (*models.EntityVersion, error) {
+	version, err := hs.pg.GetEntityVersion(ctx, entityType, entityID, number)
+	if err != nil {
+		return nil, errs.NotFound(err, errs.CodeVersionNotFound)
+	}
+
+	return version, nil
+}
+
+// versionedEntity загружает сущность для проверки доступа перед откатом или применением предложенного изменения.
+// Изменение применяется к сущности, заново прочитанной в транзакции (updateVersioned).
+func (hs *handlerService) versionedEntity(ctx *gin.Context, entityType string, entityID uuid.UUID) (*versionedEntity, error) {
+	var (
+		value    any
+		err      error
+		notFound errs.Code
+	)
+
+	switch entityType {
+	case models.EntityTypePlace:
+		notFound = errs.CodePlaceNotFound
+		value, err = hs.pg.GetPlace(ctx, entityID, false)
+	case models.EntityTypeEvent:
+		notFound = errs.CodeEventNotFound
+		value, err = hs.pg.GetEvent(ctx, entityID, false)
+	case models.EntityTypeRoute:
+		notFound = errs.CodeRouteNotFound
+		value, err = hs.pg.GetRoute(ctx, entityID, false)
+	case models.EntityTypeCompany:
+		notFound = errs.CodeCompanyNotFound
+		value, err = hs.pg.GetCompanyByID(ctx, entityID)
+	}
+
+	if err != nil {
+		return nil, errs.NotFound(err, notFound)
+	}
+
+	return newVersionedEntity(value), nil
+}
+
+// newVersionedEntity оборачивает *models.Place, *models.Event, *models.RouteWithGeo или *models.Company.
+func newVersionedEntity(value any) *versionedEntity {
+	switch entity := value.(type) {
+	case *models.Place:
+		return &versionedEntity{companyID: entity.CompanyID, snapshot: entity, value: entity}
+	case *models.Event:
+		return &versionedEntity{companyID: entity.CompanyID, snapshot: entity, value: entity}
+	case *models.RouteWithGeo:
+		return &versionedEntity{companyID: entity.CompanyID, snapshot: &routeVersion{Route: &entity.Route}, value: entity}
+	case *models.Company:
+		return &versionedEntity{companyID: &entity.ID, snapshot: entity, value: entity}
+	}
+
+	return nil
+}
+
+// snapshotOf снимает редактируемые поля сущности перед изменением.
+func (hs *handlerService) snapshotOf(entity any) models.EntitySnapshot {
+	snapshot, err := models.NewEntitySnapshot(entity)
+	if err != nil {
+		hs.logger.Error("Error snapshot entity", zap.Error(err))
+	}
+
+	return snapshot
+}
+
+// newEntityChange собирает изменение сущности value для сохранения вместе с версией. snapshot - значение,
+// по которому снимается версия после изменения, before - снимок до изменения.
+func newEntityChange(value, snapshot any, version models.EntityVersion, before models.EntitySnapshot) (*models.EntityChange, error) {
+	after, err := models.NewEntitySnapshot(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot entity: %w", err)
+	}
+
+	version.Snapshot = after
+	return &models.EntityChange{Entity: value, Version: version, Before: before}, nil
+}
+
+// updateVersioned применяет apply к сущности, заново прочитанной с мастера под блокировкой, и сохраняет ее
+// вместе с версией в одной транзакции. Возвращает сохраненную сущность.
+func (hs *handlerService) updateVersioned(ctx *gin.Context, entityType string, entityID uuid.UUID, version models.EntityVersion, apply func(entity *versionedEntity) error) (*versionedEntity, error) {
+	value, err := hs.pg.UpdateEntity(ctx, entityType, entityID, hs.entityUpdate(version, apply))
+	if err != nil {
+		return nil, err
+	}
+
+	return newVersionedEntity(value), nil
+}
+
+// entityUpdate собирает изменение, которое репозиторий применяет к заблокированной сущности.
+func (hs *handlerService) entityUpdate(version models.EntityVersion, apply func(entity *versionedEntity) error) repository.EntityUpdate {
+	return func(value any) (*models.EntityChange, error) {
+		entity := newVersionedEntity(value)
+		before := hs.snapshotOf(entity.snapshot)
+		if err := apply(entity); err != nil {
+			return nil, err
+		}
+
+		return newEntityChange(entity.value, entity.snapshot, version, before)
+	}
+}
+
+// createdVersion снимает первую версию создаваемой сущности. Идентификатор в снимок не входит,
+// поэтому версия готова до вставки и сохраняется в одной транзакции с ней.
+func createdVersion(version models.EntityVersion, entity any) (models.EntityVersion, error) {
+	snapshot, err := models.NewEntitySnapshot(entity)
+	if err != nil {
+		return version, fmt.Errorf("snapshot entity: %w", err)
+	}
+
+	version.Snapshot = snapshot
+	return version, nil
+}
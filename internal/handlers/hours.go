@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+const (
+	placeHoursDefaultDays = 7
+	placeHoursMaxDays     = 62
+)
+
+// placeDetails часы работы, цены, доступность и удобства места в запросах создания и редактирования.
+// Непереданные поля не меняются.
+type placeDetails struct {
+	Timezone        string                  `json:"timezone" binding:"omitempty,timezone"`
+	OpeningHours    *models.WeeklyHours     `json:"opening_hours"`
+	HoursExceptions []models.HoursException `json:"hours_exceptions" binding:"omitempty,max=366,dive"`
+	PriceMin        *int                    `json:"price_min" binding:"omitempty,min=0"`
+	PriceMax        *int                    `json:"price_max" binding:"omitempty,min=0"`
+	Accessibility   []string                `json:"accessibility" binding:"omitempty,dive,oneof=wheelchair step_free accessible_toilet hearing_loop guide_dogs"`
+	Amenities       []string                `json:"amenities" binding:"omitempty,dive,oneof=wifi parking toilet cafe cloakroom card_payment child_friendly pet_friendly"`
+}
+
+// GetPlaceHours
+// @Summary Часы работы места
+// @Description Возвращает расписание места по дням с учетом праздничных исключений. Даты и время указаны
+// @Description в часовом поясе места. По умолчанию возвращается неделя начиная с сегодняшнего дня.
+// @ID get-place-hours
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
+// @Param from query string false "Первый день (в формате 2006-01-02)"
+// @Param to query string false "Последний день (в формате 2006-01-02), не более 62 дней от первого"
+// @Success 200 {object} models.PlaceHours
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 301 {string} string "Место объединено с другим, Location указывает на него"
+// @Router /places/{placeId}/hours [get]
+func (hs *handlerService) GetPlaceHours(ctx *gin.Context) error {
+	placeID, err := hs.uuidParam(ctx, "placeId")
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		From string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+		To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	place, err := hs.pg.GetPlace(ctx, placeID, false)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if hs.redirectMergedPlace(ctx, placeID) {
+				return nil
+			}
+
+			return errs.New(errs.CodePlaceNotFound)
+		}
+
+		return fmt.Errorf("get place: %w", err)
+	}
+
+	loc := place.Location()
+	now := time.Now().In(loc)
+
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if params.From != "" {
+		from, _ = time.ParseInLocation(models.HoursDateLayout, params.From, loc)
+	}
+
+	to := from.AddDate(0, 0, placeHoursDefaultDays-1)
+	if params.To != "" {
+		to, _ = time.ParseInLocation(models.HoursDateLayout, params.To, loc)
+	}
+
+	if to.Before(from) || to.After(from.AddDate(0, 0, placeHoursMaxDays-1)) {
+		return errs.New(errs.CodeDateRangeInvalid)
+	}
+
+	hours := models.PlaceHours{
+		Timezone: loc.String(),
+		OpenNow:  place.OpenNow,
+		Days:     make([]models.DaySchedule, 0),
+	}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day, _ := place.ScheduleOn(date)
+		hours.Days = append(hours.Days, day)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(hours))
+
+	return nil
+}
+
+// applyPlaceDetails переносит переданные часы работы, цены, доступность и удобства в место.
+func (hs *handlerService) applyPlaceDetails(ctx *gin.Context, place *models.Place, details placeDetails) error {
+	if details.Timezone != "" {
+		place.Timezone = details.Timezone
+	}
+	if details.OpeningHours != nil {
+		place.OpeningHours = details.OpeningHours
+	}
+	if details.HoursExceptions != nil {
+		dates := make(map[string]bool, len(details.HoursExceptions))
+		for _, exception := range details.HoursExceptions {
+			if dates[exception.Date] {
+				return errs.New(errs.CodeHoursExceptionsDup)
+			}
+			dates[exception.Date] = true
+		}
+
+		place.HoursExceptions = details.HoursExceptions
+	}
+	if details.PriceMin != nil {
+		place.PriceMin = details.PriceMin
+	}
+	if details.PriceMax != nil {
+		place.PriceMax = details.PriceMax
+	}
+	if place.PriceMin != nil && place.PriceMax != nil && *place.PriceMax < *place.PriceMin {
+		return errs.New(errs.CodePriceRangeInvalid)
+	}
+	if details.Accessibility != nil {
+		place.Accessibility = uniqueStrings(details.Accessibility)
+	}
+	if details.Amenities != nil {
+		place.Amenities = uniqueStrings(details.Amenities)
+	}
+
+	return nil
+}
+
+// openNow разбирает необязательный фильтр open_now списков мест.
+func (hs *handlerService) openNow(ctx *gin.Context) (bool, error) {
+	var params struct {
+		OpenNow bool `form:"open_now"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return false, err
+	}
+
+	return params.OpenNow, nil
+}
+
+// openPlaces оставляет места, открытые сейчас. Места с неизвестными часами работы не попадают в выборку.
+func openPlaces(places []models.Place) []models.Place {
+	open := make([]models.Place, 0, len(places))
+	for _, place := range places {
+		if place.OpenNow != nil && *place.OpenNow {
+			open = append(open, place)
+		}
+	}
+
+	return open
+}
+
+func uniqueStrings(values []string) pq.StringArray {
+	result := make(pq.StringArray, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+)
+
+const localityLandingLimit = 10
+
+// GetLocalities
+// @Summary Получить справочник городов и регионов
+// @Description Возвращает города и регионы, к которым привязываются места и события.
+// @ID get-localities
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param kind query string false "Тип записи: city или region (по умолчанию все)"
+// @Success 200 {object} []models.Locality
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /localities [get]
+func (hs *handlerService) GetLocalities(ctx *gin.Context) error {
+	var params struct {
+		Kind string `form:"kind" binding:"omitempty,oneof=city region"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	localities, err := hs.pg.GetLocalities(ctx, params.Kind)
+	if err != nil {
+		return fmt.Errorf("get localities: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(localities))
+
+	return nil
+}
+
+// GetLocality
+// @Summary Получить город или регион
+// @Description Возвращает город или регион из справочника по его ID.
+// @ID get-locality
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param localityId path string true "Уникальный идентификатор города или региона (в формате UUID)"
+// @Success 200 {object} models.Locality
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /localities/{localityId} [get]
+func (hs *handlerService) GetLocality(ctx *gin.Context) error {
+	locality, err := hs.localityParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(locality))
+
+	return nil
+}
+
+// GetLocalityLanding
+// @Summary Главная страница города
+// @Description Возвращает подборку для главной страницы города или региона: лучшие места, ближайшие события
+// @Description и лучшие маршруты, проходящие через него.
+// @ID get-locality-landing
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
+// @Param localityId path string true "Уникальный идентификатор города или региона (в формате UUID)"
+// @Success 200 {object} models.LocalityLanding
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /localities/{localityId}/landing [get]
+func (hs *handlerService) GetLocalityLanding(ctx *gin.Context) error {
+	locality, err := hs.localityParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	landing := models.LocalityLanding{Locality: locality}
+
+	if landing.TopPlaces, err = hs.pg.GetTopPlaces(ctx, locality.ID, localityLandingLimit); err == nil {
+		if landing.UpcomingEvents, err = hs.pg.GetUpcomingEvents(ctx, locality.ID, localityLandingLimit); err == nil {
+			landing.FeaturedRoutes, err = hs.pg.GetFeaturedRoutes(ctx, locality.ID, localityLandingLimit)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("get locality landing: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, &landing)))
+
+	return nil
+}
+
+// NewLocality
+// @Summary Добавить город или регион
+// @Description Добавляет город или регион в справочник. Граница задается многоугольником boundary или кругом
+// @Description радиусом radius_km вокруг center. После добавления места и события заново привязываются к городам.
+// @ID create-locality
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param kind body string true "Тип записи: city или region"
+// @Param name body string true "Название"
+// @Param region body string false "Регион"
+// @Param country body string false "Страна"
+// @Param parent_id body string false "Регион, в который входит город (в формате UUID)"
+// @Param center body models.GeoPoint true "Центр"
+// @Param radius_km body number false "Радиус в километрах, если граница не задана"
+// @Param boundary body []models.GeoPoint false "Граница (минимум 3 точки)"
+// @Success 200 {object} models.Locality
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /localities [post]
+func (hs *handlerService) NewLocality(ctx *gin.Context) error {
+	var params struct {
+		Kind     string            `json:"kind" binding:"required,oneof=city region"`
+		Name     string            `json:"name" binding:"required,max=255"`
+		Region   string            `json:"region" binding:"max=255"`
+		Country  string            `json:"country" binding:"max=255"`
+		ParentID string            `json:"parent_id" binding:"omitempty,uuid"`
+		Center   *models.GeoPoint  `json:"center" binding:"required"`
+		RadiusKm float64           `json:"radius_km" binding:"gte=0,lte=1000"`
+		Boundary []models.GeoPoint `json:"boundary" binding:"omitempty,min=3,dive"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	locality := models.Locality{
+		Kind:     params.Kind,
+		Name:     params.Name,
+		Region:   params.Region,
+		Country:  params.Country,
+		Center:   *params.Center,
+		RadiusKm: params.RadiusKm,
+		Boundary: params.Boundary,
+	}
+
+	if params.ParentID != "" {
+		parentID, _ := uuid.Parse(params.ParentID)
+		if err := hs.checkLocalityParent(ctx, uuid.Nil, parentID); err != nil {
+			return err
+		}
+
+		locality.ParentID = &parentID
+	}
+
+	created, err := hs.pg.NewLocality(ctx, locality)
+	if err != nil {
+		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
+			return errs.New(errs.CodeLocalityAlreadyExists)
+		}
+
+		return fmt.Errorf("new locality: %w", err)
+	}
+
+	if err := hs.reassignLocalities(ctx, created); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(created))
+
+	return nil
+}
+
+// EditLocality
+// @Summary Редактировать город или регион
+// @Description Редактирует запись справочника. При изменении границ места и события заново привязываются к городам.
+// @ID edit-locality
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param localityId path string true "Уникальный идентификатор города или региона (в формате UUID)"
+// @Param name body string false "Название"
+// @Param region body string false "Регион"
+// @Param country body string false "Страна"
+// @Param parent_id body string false "Регион, в который входит город (в формате UUID)"
+// @Param center body models.GeoPoint false "Центр"
+// @Param radius_km body number false "Радиус в километрах, если граница не задана"
+// @Param boundary body []models.GeoPoint false "Граница (минимум 3 точки, пустой массив удаляет границу)"
+// @Success 200 {object} models.Locality
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /localities/{localityId} [patch]
+func (hs *handlerService) EditLocality(ctx *gin.Context) error {
+	locality, err := hs.localityParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		Name     string             `json:"name" binding:"max=255"`
+		Region   *string            `json:"region" binding:"omitempty,max=255"`
+		Country  *string            `json:"country" binding:"omitempty,max=255"`
+		ParentID string             `json:"parent_id" binding:"omitempty,uuid"`
+		Center   *models.GeoPoint   `json:"center"`
+		RadiusKm *float64           `json:"radius_km" binding:"omitempty,gte=0,lte=1000"`
+		Boundary *[]models.GeoPoint `json:"boundary" binding:"omitempty,dive"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	if params.Name != "" {
+		locality.Name = params.Name
+	}
+	if params.Region != nil {
+		locality.Region = *params.Region
+	}
+	if params.Country != nil {
+		locality.Country = *params.Country
+	}
+	if params.ParentID != "" {
+		parentID, _ := uuid.Parse(params.ParentID)
+		if err := hs.checkLocalityParent(ctx, locality.ID, parentID); err != nil {
+			return err
+		}
+
+		locality.ParentID = &parentID
+	}
+
+	boundsChanged := params.Center != nil || params.RadiusKm != nil || params.Boundary != nil
+	if params.Center != nil {
+		locality.Center = *params.Center
+	}
+	if params.RadiusKm != nil {
+		locality.RadiusKm = *params.RadiusKm
+	}
+	if params.Boundary != nil {
+		if len(*params.Boundary) != 0 && len(*params.Boundary) < 3 {
+			return errs.New(errs.CodeBoundaryTooShort)
+		}
+
+		locality.Boundary = *params.Boundary
+	}
+
+	if err := hs.pg.SaveLocality(ctx, locality); err != nil {
+		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
+			return errs.New(errs.CodeLocalityAlreadyExists)
+		}
+
+		return fmt.Errorf("save locality: %w", err)
+	}
+
+	if boundsChanged {
+		if err := hs.reassignLocalities(ctx, locality); err != nil {
+			return err
+		}
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(locality))
+
+	return nil
+}
+
+func (hs *handlerService) localityParam(ctx *gin.Context) (*models.Locality, error) {
+	localityID, err := hs.uuidParam(ctx, "localityId")
+	if err != nil {
+		return nil, err
+	}
+
+	locality, err := hs.pg.GetLocality(ctx, localityID)
+	if err != nil {
+		return nil, errs.NotFound(err, errs.CodeLocalityNotFound)
+	}
+
+	return locality, nil
+}
+
+// checkLocalityParent проверяет, что родителем может быть parentID: это существующий регион, а не сама запись.
+func (hs *handlerService) checkLocalityParent(ctx *gin.Context, localityID, parentID uuid.UUID) error {
+	parent, err := hs.pg.GetLocality(ctx, parentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("get locality: %w", err)
+	}
+
+	if err != nil || parent.Kind != models.LocalityKindRegion || parentID == localityID {
+		return errs.New(errs.CodeLocalityParentInvalid)
+	}
+
+	return nil
+}
+
+func (hs *handlerService) reassignLocalities(ctx *gin.Context, locality *models.Locality) error {
+	if err := hs.pg.ReassignLocalities(ctx, locality); err != nil {
+		return fmt.Errorf("reassign localities: %w", err)
+	}
+
+	return nil
+}
+
+// cityID разбирает необязательный фильтр city_id списков мест, событий и маршрутов.
+func (hs *handlerService) cityID(ctx *gin.Context) (*uuid.UUID, error) {
+	var params struct {
+		CityID string `form:"city_id" binding:"omitempty,uuid"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return nil, err
+	}
+
+	if params.CityID == "" {
+		return nil, nil
+	}
+
+	cityID, _ := uuid.Parse(params.CityID)
+	return &cityID, nil
+}
+
+// cityAt определяет город, к которому относится точка. nil - точка вне всех городов и регионов справочника.
+func (hs *handlerService) cityAt(ctx *gin.Context, lng, lat float64) (*uuid.UUID, error) {
+	cityID, err := hs.pg.GetLocalityAt(ctx, models.GeoPoint{Lat: lat, Lng: lng})
+	if err != nil {
+		return nil, fmt.Errorf("get locality at point: %w", err)
+	}
+
+	return cityID, nil
+}
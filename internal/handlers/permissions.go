@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	permissionsKey       = "permissions"
+	permissionCheckedKey = "permissionChecked"
+)
+
+// handlerAuthorization изменяющие методы без requirePermission и проверки, которые выполняют их обработчики.
+// Доступ к этим методам зависит от конкретной сущности или касается только данных самого пользователя.
+// Изменяющий метод, которого нет ни здесь, ни с requirePermission, отклоняется: см. checkRouteAuthorization.
+var handlerAuthorization = map[string]string{
+	"POST /auth/sessions/":                        "только параметры запуска VK",
+	"DELETE /auth/sessions/:sessionId/":           "только собственные сессии",
+	"POST /auth/refresh/":                         "токен обновления",
+	"PATCH /users/":                               "только собственный профиль",
+	"PATCH /companies/:companyId/":                "editAccess, без прав создается предложение правки",
+	"POST /companies/:companyId/members/":         "ownCompany",
+	"DELETE /companies/:companyId/members/:vkId/": "ownCompany",
+	"POST /bookmarks/":                            "только собственные закладки",
+	"DELETE /bookmarks/:entityType/:entityId/":    "только собственные закладки",
+	"POST /checkins/":                             "только собственные отметки",
+	"POST /suggestions/:suggestionId/accept/":     "checkSuggestionReviewAccess",
+	"POST /suggestions/:suggestionId/reject/":     "checkSuggestionReviewAccess",
+	"PATCH /places/:placeId":                      "editAccess, без прав создается предложение правки",
+	"PATCH /events/:eventId/":                     "editAccess, без прав создается предложение правки",
+	"PATCH /routes/:routeId/":                     "editAccess, без прав создается предложение правки",
+	"POST /events/:eventId/rsvp/":                 "только собственный ответ",
+	"DELETE /events/:eventId/rsvp/":               "только собственный ответ",
+}
+
+// getPermissions возвращает разрешения текущего пользователя и кэширует их в контексте запроса.
+// Для ключа API разрешения владельца дополнительно ограничиваются областями действия ключа.
+func (hs *handlerService) getPermissions(ctx *gin.Context) (models.Permissions, error) {
+	if cached, ok := ctx.Get(permissionsKey); ok {
+		return cached.(models.Permissions), nil
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := hs.pg.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := models.NewPermissions(roles)
+	if principal := hs.GetPrincipal(ctx); principal.Method == auth.MethodAPIKey {
+		permissions = permissions.Intersect(models.NewScopePermissions(principal.Scopes))
+	}
+	ctx.Set(permissionsKey, permissions)
+
+	return permissions, nil
+}
+
+// requirePermission возвращает middleware, пропускающий запрос только при наличии разрешения.
+func (hs *handlerService) requirePermission(permission string) gin.HandlerFunc {
+	return hs.middleware(func(ctx *gin.Context) error {
+		permissions, err := hs.getPermissions(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !permissions.Has(permission) {
+			return errs.New(errs.CodeAccessDenied)
+		}
+
+		ctx.Set(permissionCheckedKey, true)
+		return nil
+	})
+}
+
+// checkRouteAuthorization не дает изменяющему методу выполниться без проверки доступа:
+// метод должен быть объявлен с requirePermission или перечислен в handlerAuthorization.
+func (hs *handlerService) checkRouteAuthorization(ctx *gin.Context) error {
+	switch ctx.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+
+	route := ctx.FullPath()
+	if route == "" || ctx.GetBool(permissionCheckedKey) {
+		return nil
+	}
+
+	if _, ok := handlerAuthorization[ctx.Request.Method+" "+route]; ok {
+		return nil
+	}
+
+	hs.logger.Error("Route has no authorization", zap.String("Method", ctx.Request.Method), zap.String("Route", route))
+
+	return errs.New(errs.CodeAccessDenied)
+}
@@ -3,171 +3,312 @@ package handlers
 import (
 	"database/sql"
 	"errors"
-	"github.com/jackc/pgerrcode"
-	"math"
+	"fmt"
 	"net/http"
-	"time"
 
-	"github.com/ShpullRequest/backend/internal/config"
 	"github.com/ShpullRequest/backend/internal/errs"
 	"github.com/ShpullRequest/backend/internal/models"
-	"github.com/ShpullRequest/backend/pkg/vk/maps"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"go.uber.org/zap"
+	"github.com/lib/pq"
 )
 
 // NewPlace
 // @Summary Добавить новое место
-// @Description Создает новое место.
+// @Description Создает новое место от имени компании пользователя или, для администраторов, без владельца.
 // @ID create-place
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param company_id body string false "Компания-владелец места (в формате UUID), без нее место может добавить только администратор"
 // @Param name body string true "Название места"
 // @Param description body string true "Описание места"
-// @Param carousel body []string true "Список изображений для карусели"
-// @Param address_lng body float64 true "Долгота местоположения"
-// @Param address_lat body float64 true "Широта местоположения"
+// @Param carousel body []string true "Список изображений для карусели (ссылки или идентификаторы загруженных изображений)"
+// @Param tag_ids body []string false "Теги места из справочника (в формате UUID)"
+// @Param timezone body string false "Часовой пояс места (по умолчанию Europe/Moscow)"
+// @Param opening_hours body models.WeeklyHours false "Часы работы по дням недели"
+// @Param hours_exceptions body []models.HoursException false "Часы работы в праздники и другие особые даты"
+// @Param price_min body integer false "Минимальная цена в рублях"
+// @Param price_max body integer false "Максимальная цена в рублях"
+// @Param accessibility body []string false "Доступность: wheelchair, step_free, accessible_toilet, hearing_loop, guide_dogs"
+// @Param amenities body []string false "Удобства: wifi, parking, toilet, cafe, cloakroom, card_payment, child_friendly, pet_friendly"
+// @Param address body string false "Текст адреса, используется, если не переданы координаты"
+// @Param address_lng body float64 false "Долгота местоположения (обязательна без address)"
+// @Param address_lat body float64 false "Широта местоположения (обязательна без address)"
 // @Success 200 {object} models.Place
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 502 {object} models.ErrorResponse
 // @Router /places [post]
-func (hs *handlerService) NewPlace(ctx *gin.Context) {
+func (hs *handlerService) NewPlace(ctx *gin.Context) error {
 	var params struct {
+		CompanyID   string   `json:"company_id" binding:"omitempty,uuid"`
 		Name        string   `json:"name" binding:"required,min=6"`
 		Description string   `json:"description" binding:"required,min=10"`
 		Carousel    []string `json:"carousel" binding:"required"`
-		AddressLng  float64  `json:"address_lng" binding:"required"`
-		AddressLat  float64  `json:"address_lat" binding:"required"`
+		TagIDs      []string `json:"tag_ids" binding:"omitempty,dive,uuid"`
+		Address     string   `json:"address" binding:"omitempty,min=3"`
+		AddressLng  float64  `json:"address_lng" binding:"required_without=Address,longitude"`
+		AddressLat  float64  `json:"address_lat" binding:"required_without=Address,latitude"`
+		placeDetails
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
 
-	addressText, err := maps.New(config.Config).GetAddressByGeo(params.AddressLng, params.AddressLat)
+	user, err := hs.currentUser(ctx)
 	if err != nil {
-		hs.logger.Error("Error get address", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusBadGateway, models.NewErrorResponse(errs.NewBadGateway("Internal server error on vk maps")))
-		ctx.Abort()
+	var companyID *uuid.UUID
+	if params.CompanyID != "" {
+		paramCompanyID, _ := uuid.Parse(params.CompanyID)
+		company, err := hs.ownCompany(ctx, paramCompanyID)
+		if err != nil {
+			return err
+		}
 
-		return
+		companyID = &company.ID
+	} else {
+		permissions, err := hs.getPermissions(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !permissions.Has(models.PermissionContentManage) {
+			return errs.New(errs.CodeAccessDenied)
+		}
 	}
 
-	place, err := hs.pg.NewPlace(ctx, models.Place{
-		Name:        params.Name,
-		Description: params.Description,
-		Carousel:    params.Carousel,
-		AddressText: addressText,
-		AddressLng:  params.AddressLng,
-		AddressLat:  params.AddressLat,
-	})
+	if err := hs.checkAPIKeyCompany(ctx, companyID); err != nil {
+		return err
+	}
+
+	carousel, err := hs.resolveImageRefs(ctx, params.Carousel...)
 	if err != nil {
-		hs.logger.Error("Error new place", zap.Error(err))
+		return err
+	}
+
+	tagIDs, err := hs.tagIDs(ctx, params.TagIDs)
+	if err != nil {
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	address, err := hs.resolveAddress(ctx, params.Address, params.AddressLng, params.AddressLat)
+	if err != nil {
+		return err
+	}
+	cityID, err := hs.cityAt(ctx, address.Lng, address.Lat)
+	if err != nil {
+		return err
+	}
+
+	place := models.Place{
+		CompanyID:     companyID,
+		Name:          params.Name,
+		Description:   params.Description,
+		Carousel:      carousel,
+		TagIDs:        tagIDs,
+		AddressText:   address.Text,
+		AddressLng:    address.Lng,
+		AddressLat:    address.Lat,
+		Address:       address.Address,
+		CityID:        cityID,
+		Timezone:      models.DefaultPlaceTimezone,
+		Accessibility: make(pq.StringArray, 0),
+		Amenities:     make(pq.StringArray, 0),
+	}
+	if err := hs.applyPlaceDetails(ctx, &place, params.placeDetails); err != nil {
+		return err
+	}
+
+	version, err := createdVersion(models.EntityVersion{
+		EntityType: models.EntityTypePlace,
+		Action:     models.VersionActionCreate,
+		UserID:     &user.ID,
+	}, &place)
+	if err != nil {
+		return err
+	}
 
-		return
+	created, err := hs.pg.NewPlace(ctx, place, version)
+	if err != nil {
+		return fmt.Errorf("new place: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(place))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(created))
+
+	return nil
 }
 
 // EditPlace
 // @Summary Редактировать место
-// @Description Редактирует существующее место.
+// @Description Редактирует существующее место. Место компании может редактировать только она сама или администратор.
+// @Description Правки остальных пользователей сохраняются как предложенное изменение и применяются после одобрения
+// @Description владельцем или модератором, в этом случае возвращается код 202.
 // @ID edit-place
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
 // @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
+// @Param name body string false "Новое название места"
+// @Param description body string false "Новое описание места"
+// @Param translations body models.Translations false "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык"
+// @Param carousel body []string false "Новый список изображений для карусели (ссылки или идентификаторы загруженных изображений)"
+// @Param tag_ids body []string false "Новые теги места из справочника (в формате UUID)"
+// @Param timezone body string false "Часовой пояс места (по умолчанию Europe/Moscow)"
+// @Param opening_hours body models.WeeklyHours false "Часы работы по дням недели"
+// @Param hours_exceptions body []models.HoursException false "Часы работы в праздники и другие особые даты"
+// @Param price_min body integer false "Минимальная цена в рублях"
+// @Param price_max body integer false "Максимальная цена в рублях"
+// @Param accessibility body []string false "Доступность: wheelchair, step_free, accessible_toilet, hearing_loop, guide_dogs"
+// @Param amenities body []string false "Удобства: wifi, parking, toilet, cafe, cloakroom, card_payment, child_friendly, pet_friendly"
+// @Param address body string false "Новый текст адреса, используется, если не переданы координаты"
+// @Param address_lng body float64 false "Новая долгота местоположения"
+// @Param address_lat body float64 false "Новая широта местоположения"
+// @Param comment body string false "Пояснение к предложенному изменению"
 // @Success 200 {object} models.Place
+// @Success 202 {object} models.EditSuggestion
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 502 {object} models.ErrorResponse
 // @Router /places/{placeID} [patch]
-func (hs *handlerService) EditPlace(ctx *gin.Context) {
+func (hs *handlerService) EditPlace(ctx *gin.Context) error {
 	var paramsURI struct {
 		PlaceID string `uri:"placeId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
+		return err
 	}
 
 	var params struct {
-		Name        string   `json:"name" binding:"omitempty,min=6"`
-		Description string   `json:"description" binding:"omitempty,min=10"`
-		Carousel    []string `json:"carousel"`
-		AddressLng  float64  `json:"address_lng"`
-		AddressLat  float64  `json:"address_lat"`
+		placeChanges
+		Comment string `json:"comment" binding:"max=1000"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
 
 	placeID, _ := uuid.Parse(paramsURI.PlaceID)
-	place, err := hs.pg.GetPlace(ctx, placeID)
+	place, err := hs.pg.GetPlace(ctx, placeID, false)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, models.NewErrorResponse(errs.NewNotFound("Place not found")))
-		} else {
-			hs.logger.Error("Error get place", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
+		return errs.NotFound(err, errs.CodePlaceNotFound)
+	}
+
+	user, direct, err := hs.editAccess(ctx, place.CompanyID, models.PermissionPlaceEdit)
+	if err != nil {
+		return err
+	}
+
+	if !direct {
+		return hs.suggestPlaceChanges(ctx, user, place, params.placeChanges, params.Comment)
+	}
 
-		return
+	entity, err := hs.updateVersioned(ctx, models.EntityTypePlace, place.ID, models.EntityVersion{
+		EntityType: models.EntityTypePlace,
+		EntityID:   place.ID,
+		Action:     models.VersionActionEdit,
+		UserID:     &user.ID,
+	}, func(entity *versionedEntity) error {
+		return hs.applyPlaceChanges(ctx, entity.value.(*models.Place), params.placeChanges)
+	})
+	if err != nil {
+		return fmt.Errorf("save place: %w", err)
 	}
 
-	if params.Name != "" {
-		place.Name = params.Name
+	ctx.JSON(http.StatusOK, models.NewResponse(entity.value))
+
+	return nil
+}
+
+// placeChanges поля запроса редактирования места. В том же виде хранятся в предложенных изменениях.
+type placeChanges struct {
+	Name         string              `json:"name" binding:"omitempty,min=6"`
+	Description  string              `json:"description" binding:"omitempty,min=10"`
+	Translations models.Translations `json:"translations" binding:"omitempty,dive,keys,len=2,ne=ru,endkeys"`
+	Carousel     []string            `json:"carousel"`
+	TagIDs       []string            `json:"tag_ids" binding:"omitempty,dive,uuid"`
+	Address      string              `json:"address" binding:"omitempty,min=3"`
+	AddressLng   float64             `json:"address_lng" binding:"omitempty,longitude"`
+	AddressLat   float64             `json:"address_lat" binding:"omitempty,latitude"`
+	placeDetails
+}
+
+// applyPlaceChanges переносит переданные изменения в место, загружая изображения и определяя адрес.
+func (hs *handlerService) applyPlaceChanges(ctx *gin.Context, place *models.Place, changes placeChanges) error {
+	if changes.Name != "" {
+		place.Name = changes.Name
 	}
-	if params.Description != "" {
-		place.Description = params.Description
+	if changes.Description != "" {
+		place.Description = changes.Description
 	}
-	if len(params.Carousel) > 0 {
-		place.Carousel = params.Carousel
+	if changes.Translations != nil {
+		place.Translations = place.Translations.Merge(changes.Translations)
 	}
-	if params.AddressLng != 0 && params.AddressLat != 0 {
-		address, err := maps.New(config.Config).GetAddressByGeo(params.AddressLng, params.AddressLat)
+	if len(changes.Carousel) > 0 {
+		carousel, err := hs.resolveImageRefs(ctx, changes.Carousel...)
 		if err != nil {
-			hs.logger.Error("Error get address", zap.Error(err))
+			return err
+		}
 
-			ctx.JSON(http.StatusBadGateway, models.NewErrorResponse(errs.NewBadGateway("Internal server error on vk maps")))
-			ctx.Abort()
+		place.Carousel = carousel
+	}
+	if changes.TagIDs != nil {
+		tagIDs, err := hs.tagIDs(ctx, changes.TagIDs)
+		if err != nil {
+			return err
+		}
 
-			return
+		place.TagIDs = tagIDs
+	}
+	if err := hs.applyPlaceDetails(ctx, place, changes.placeDetails); err != nil {
+		return err
+	}
+	if changes.Address != "" || (changes.AddressLng != 0 && changes.AddressLat != 0) {
+		address, err := hs.resolveAddress(ctx, changes.Address, changes.AddressLng, changes.AddressLat)
+		if err != nil {
+			return err
 		}
 
-		place.AddressText = address
-		place.AddressLng = params.AddressLng
-		place.AddressLat = params.AddressLat
+		place.AddressText = address.Text
+		place.AddressLng = address.Lng
+		place.AddressLat = address.Lat
+		place.Address = address.Address
+
+		if place.CityID, err = hs.cityAt(ctx, address.Lng, address.Lat); err != nil {
+			return err
+		}
 	}
 
-	if err = hs.pg.SavePlace(ctx, place); err != nil {
-		hs.logger.Error("Error save place", zap.Error(err))
+	return nil
+}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+// suggestPlaceChanges сохраняет правку места как предложенное изменение. Изображения загружаются сразу,
+// чтобы при принятии изменения не зависеть от загрузок автора.
+func (hs *handlerService) suggestPlaceChanges(ctx *gin.Context, user *models.User, place *models.Place, changes placeChanges, comment string) error {
+	if len(changes.Carousel) > 0 {
+		carousel, err := hs.resolveImageRefs(ctx, changes.Carousel...)
+		if err != nil {
+			return err
+		}
 
-		return
+		changes.Carousel = carousel
+	}
+	if changes.TagIDs != nil {
+		if _, err := hs.tagIDs(ctx, changes.TagIDs); err != nil {
+			return err
+		}
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(place))
+	return hs.suggestEdit(ctx, user, models.EntityTypePlace, place.ID, changes, comment)
 }
 
 // GetPlace
@@ -177,75 +318,102 @@ func (hs *handlerService) EditPlace(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
+// @Param include_deleted query bool false "Включить удаленные записи (только для администраторов)"
 // @Success 200 {object} models.Place
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
-// @Router /places/{placeID} [get]
-func (hs *handlerService) GetPlace(ctx *gin.Context) {
+// @Failure 301 {string} string "Место объединено с другим, Location указывает на него"
+// @Router /places/{placeId} [get]
+func (hs *handlerService) GetPlace(ctx *gin.Context) error {
 	var params struct {
 		PlaceID string `uri:"placeId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
+	}
 
-		return
+	includeDeleted, err := hs.includeDeleted(ctx)
+	if err != nil {
+		return err
 	}
 
 	placeID, _ := uuid.Parse(params.PlaceID)
-	place, err := hs.pg.GetPlace(ctx, placeID)
+	place, err := hs.pg.GetPlace(ctx, placeID, includeDeleted)
 
 	if err != nil {
-		hs.logger.Error("Error get place", zap.Error(err))
+		if errors.Is(err, sql.ErrNoRows) {
+			if !includeDeleted && hs.redirectMergedPlace(ctx, placeID) {
+				return nil
+			}
+
+			return errs.New(errs.CodePlaceNotFound)
+		}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		return fmt.Errorf("get place: %w", err)
+	}
 
-		return
+	if !place.IsDeleted {
+		hs.recordView(ctx, models.EntityTypePlace, place.ID)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(place))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, place)))
+
+	return nil
 }
 
 // SearchPlaces
 // @Summary Поиск мест
 // @Description Ищет места по заданному запросу.
-// @ID search-routes
+// @ID search-places
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param query path string true "Запрос для поиска мест (минимум 2 символа)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param tag query string false "Тег (UUID или slug), для категории учитываются и ее дочерние теги"
+// @Param sort query string false "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом"
+// @Param open_now query bool false "Только места, открытые сейчас"
 // @Success 200 {object} []models.Place
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
-// @Router /routes/search/{query} [get]
-func (hs *handlerService) SearchPlaces(ctx *gin.Context) {
+// @Router /places/search/{query} [get]
+func (hs *handlerService) SearchPlaces(ctx *gin.Context) error {
 	var params struct {
 		Query string `uri:"query" binding:"required,min=2"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
+	}
 
-		return
+	filter, err := hs.listFilter(ctx)
+	if err != nil {
+		return err
 	}
 
-	places, err := hs.pg.SearchPlace(ctx, params.Query)
+	openNow, err := hs.openNow(ctx)
 	if err != nil {
-		hs.logger.Error("Error search routes", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	places, err := hs.pg.SearchPlace(ctx, params.Query, filter)
+	if err != nil {
+		return fmt.Errorf("search places: %w", err)
+	}
 
-		return
+	if openNow {
+		places = openPlaces(places)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(places))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, places)))
+
+	return nil
 }
 
 // GetAllPlaces
@@ -255,22 +423,82 @@ func (hs *handlerService) SearchPlaces(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
+// @Param include_deleted query bool false "Включить удаленные записи (только для администраторов)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param tag query string false "Тег (UUID или slug), для категории учитываются и ее дочерние теги"
+// @Param sort query string false "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом"
+// @Param open_now query bool false "Только места, открытые сейчас"
 // @Success 200 {object} []models.Place
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /places [get]
-func (hs *handlerService) GetAllPlaces(ctx *gin.Context) {
-	places, err := hs.pg.GetAllPlaces(ctx)
+func (hs *handlerService) GetAllPlaces(ctx *gin.Context) error {
+	includeDeleted, err := hs.includeDeleted(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter, err := hs.listFilter(ctx)
 	if err != nil {
-		hs.logger.Error("Error get all places", zap.Error(err))
+		return err
+	}
+
+	openNow, err := hs.openNow(ctx)
+	if err != nil {
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	places, err := hs.pg.GetAllPlaces(ctx, includeDeleted, filter)
+	if err != nil {
+		return fmt.Errorf("get all places: %w", err)
+	}
 
-		return
+	if openNow {
+		places = openPlaces(places)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(places))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, places)))
+
+	return nil
+}
+
+// DeletePlace
+// @Summary Удалить место
+// @Description Помечает место удаленным. Доступно только администраторам.
+// @ID delete-place
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/{placeId} [delete]
+func (hs *handlerService) DeletePlace(ctx *gin.Context) error {
+	return hs.setEntityDeleted(ctx, models.EntityTypePlace, "placeId", true)
+}
+
+// RestorePlace
+// @Summary Восстановить место
+// @Description Восстанавливает удаленное место. Доступно только администраторам.
+// @ID restore-place
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/{placeId}/restore [post]
+func (hs *handlerService) RestorePlace(ctx *gin.Context) error {
+	return hs.setEntityDeleted(ctx, models.EntityTypePlace, "placeId", false)
 }
 
 // NewReviewPlace
@@ -283,176 +511,36 @@ func (hs *handlerService) GetAllPlaces(ctx *gin.Context) {
 // @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
 // @Param review_text body string true "Текст отзыва (минимум 6 символов)"
 // @Param stars body float64 true "Оценка места (от 1 до 5)"
-// @Success 200 {object} models.ReviewPlace
+// @Param photos body []string false "Идентификаторы загруженных фотографий (опционально)"
+// @Success 200 {object} models.Review
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
-// @Router /places/{placeID}/reviews [post]
-func (hs *handlerService) NewReviewPlace(ctx *gin.Context) {
-	var paramsURI struct {
-		PlaceID string `uri:"placeId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	var params struct {
-		ReviewText string  `json:"review_text" binding:"required,min=6"`
-		Stars      float64 `json:"stars" binding:"required"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-	vkParams := hs.GetVKParams(ctx)
-
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
-	if err != nil {
-		hs.logger.Error("Error get user", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	if params.Stars < 1 || params.Stars > 5 {
-		ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"Stars\" failed on the 'min=1,max=5' tag.")))
-		ctx.Abort()
-
-		return
-	} else {
-		params.Stars = math.Round(params.Stars)
-	}
-
-	placeID, _ := uuid.Parse(paramsURI.PlaceID)
-	reviewPlace, err := hs.pg.NewReviewPlace(ctx, models.ReviewPlace{
-		OwnerID:    user.ID,
-		PlaceID:    placeID,
-		ReviewText: params.ReviewText,
-		Stars:      params.Stars,
-		CreatedAt:  time.Now(),
-	})
-
-	if err != nil {
-		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
-			ctx.JSON(http.StatusConflict, models.NewErrorResponse(errs.NewConflict("You have already added a review to this place")))
-		} else {
-			hs.logger.Error("Error new review event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
-	}
-
-	ctx.JSON(http.StatusOK, models.NewResponse(reviewPlace))
-	ctx.Abort()
+// @Router /places/{placeId}/reviews [post]
+func (hs *handlerService) NewReviewPlace(ctx *gin.Context) error {
+	return hs.newReview(ctx, models.EntityTypePlace, "placeId")
 }
 
 // EditReviewPlace
 // @Summary Редактировать отзыв о месте
 // @Description Редактирует существующий отзыв о месте с указанными параметрами.
-// @ID edit-review-route
+// @ID edit-review-place
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
-// @Param routeId path string true "Уникальный идентификатор места"
+// @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
 // @Param review_text body string false "Текст отзыва (минимум 6 символов, опционально)"
 // @Param stars body number false "Оценка (от 1 до 5, опционально)"
-// @Success 200 {object} models.ReviewPlace
+// @Success 200 {object} models.Review
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
-// @Router /routes/{routeId}/reviews [patch]
-func (hs *handlerService) EditReviewPlace(ctx *gin.Context) {
-	var paramsURI struct {
-		RouteID string `uri:"routeId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	var params struct {
-		ReviewText string  `json:"review_text"`
-		Stars      float64 `json:"stars"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-	vkParams := hs.GetVKParams(ctx)
-
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
-	if err != nil {
-		hs.logger.Debug("Error get user", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	routeID, _ := uuid.Parse(paramsURI.RouteID)
-	reviewRoute, err := hs.pg.GetReviewRoute(ctx, user.ID, routeID)
-
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, models.NewErrorResponse(errs.NewNotFound("Review route not found")))
-		} else {
-			hs.logger.Error("Error get event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
-	}
-
-	if params.ReviewText != "" {
-		if len(params.ReviewText) < 6 {
-			ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"ReviewText\" failed on the 'min=6' tag.")))
-			ctx.Abort()
-
-			return
-		}
-
-		reviewRoute.ReviewText = params.ReviewText
-	}
-	if params.Stars != 0 {
-		if params.Stars < 1 || params.Stars > 5 {
-			ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"Stars\" failed on the 'min=1,max=5' tag.")))
-			ctx.Abort()
-
-			return
-		} else {
-			reviewRoute.Stars = math.Round(params.Stars)
-		}
-	}
-
-	if err = hs.pg.SaveReviewRoute(ctx, reviewRoute); err != nil {
-		hs.logger.Debug("Error save review route", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	ctx.JSON(http.StatusOK, models.NewResponse(reviewRoute))
-	ctx.Abort()
+// @Router /places/{placeId}/reviews [patch]
+func (hs *handlerService) EditReviewPlace(ctx *gin.Context) error {
+	return hs.editReview(ctx, models.EntityTypePlace, "placeId")
 }
 
 // GetReviewsPlace
@@ -463,34 +551,50 @@ func (hs *handlerService) EditReviewPlace(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
 // @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
-// @Success 200 {object} []models.ReviewPlace
+// @Param sort query string false "Сортировка: recent (по умолчанию), helpful или stars"
+// @Success 200 {object} []models.ReviewWithMeta
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /places/{placeId}/reviews [get]
-func (hs *handlerService) GetReviewsPlace(ctx *gin.Context) {
-	var params struct {
-		PlaceID string `uri:"placeId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	placeID, _ := uuid.Parse(params.PlaceID)
-	places, err := hs.pg.GetReviewsEvent(ctx, placeID)
-
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		hs.logger.Debug("Error get reviews", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+func (hs *handlerService) GetReviewsPlace(ctx *gin.Context) error {
+	return hs.getReviews(ctx, models.EntityTypePlace, "placeId")
+}
 
-		return
-	}
+// VoteReviewPlace
+// @Summary Отметить отзыв о месте полезным
+// @Description Добавляет отметку "полезно" от текущего пользователя к отзыву о месте.
+// @ID vote-review-place
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Success 200 {object} models.ReviewVote
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/{placeId}/reviews/{reviewId}/helpful [post]
+func (hs *handlerService) VoteReviewPlace(ctx *gin.Context) error {
+	return hs.voteReview(ctx, models.EntityTypePlace, "placeId")
+}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(places))
-	ctx.Abort()
+// UnvoteReviewPlace
+// @Summary Снять отметку "полезно" с отзыва о месте
+// @Description Удаляет отметку "полезно" текущего пользователя с отзыва о месте.
+// @ID unvote-review-place
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param placeId path string true "Уникальный идентификатор места (в формате UUID)"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /places/{placeId}/reviews/{reviewId}/helpful [delete]
+func (hs *handlerService) UnvoteReviewPlace(ctx *gin.Context) error {
+	return hs.unvoteReview(ctx, models.EntityTypePlace, "placeId")
 }
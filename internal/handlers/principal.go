@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/i18n"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+func (hs *handlerService) GetPrincipal(ctx *gin.Context) *auth.Principal {
+	return auth.GetPrincipal(ctx)
+}
+
+// currentUser возвращает пользователя, от имени которого выполняется запрос.
+// Пользователя находит или создает middleware Users, поэтому обработчикам не нужно регистрировать его самим.
+func (hs *handlerService) currentUser(ctx *gin.Context) (*models.User, error) {
+	user := auth.GetUser(ctx)
+	if user == nil {
+		return nil, errs.New(errs.CodeUnauthorized)
+	}
+
+	return user, nil
+}
+
+// localized переводит названия и описания сущностей ответа на языки запроса и возвращает v.
+// Ответы на изменение сущностей не переводятся, чтобы редактор видел сохраненные исходные тексты.
+func (hs *handlerService) localized(ctx *gin.Context, v any) any {
+	i18n.Localize(v, i18n.FromContext(ctx))
+
+	return v
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/ShpullRequest/backend/pkg/recommend"
+	"github.com/gin-gonic/gin"
+)
+
+// recommendationCandidatesPerType сколько лучших объектов каждого типа оценивается для одной выдачи
+const recommendationCandidatesPerType = 300
+
+// GetRecommendations
+// @Summary Получить рекомендации
+// @Description Возвращает места, события и маршруты, подобранные по местоположению пользователя, тегам объектов из его закладок,
+// @Description посещений и отзывов, рейтингу и актуальности. Объекты, с которыми пользователь уже взаимодействовал, не рекомендуются.
+// @Description Похожие объекты разносятся по выдаче, у каждой рекомендации есть объяснение и список причин.
+// @ID get-recommendations
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
+// @Param city_id query string false "Рекомендовать только объекты города или региона (в формате UUID)"
+// @Param limit query integer false "Количество рекомендаций (по умолчанию 20, максимум 50)"
+// @Success 200 {object} []models.Recommendation
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /recommendations [get]
+func (hs *handlerService) GetRecommendations(ctx *gin.Context) error {
+	var params struct {
+		Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+	if params.Limit == 0 {
+		params.Limit = 20
+	}
+
+	cityID, err := hs.cityID(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	homeCity, err := hs.locality(ctx, user.HomeCityID)
+	if err != nil {
+		return err
+	}
+
+	interactions, err := hs.pg.GetUserInteractions(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get user interactions: %w", err)
+	}
+
+	candidates, err := hs.pg.GetRecommendationCandidates(ctx, cityID, recommendationCandidatesPerType)
+	if err != nil {
+		return fmt.Errorf("get recommendation candidates: %w", err)
+	}
+
+	now := time.Now()
+	profile := recommend.NewProfile(defaultMapCenter(user, homeCity, hs.locateClient(ctx)), interactions, now)
+
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, recommend.Recommend(profile, candidates, params.Limit, now, recommend.DefaultWeights))))
+
+	return nil
+}
@@ -0,0 +1,362 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+)
+
+// entityNotFound коды ошибок "не найдено" для сущностей с отзывами
+var entityNotFound = map[string]errs.Code{
+	models.EntityTypePlace: errs.CodePlaceNotFound,
+	models.EntityTypeEvent: errs.CodeEventNotFound,
+	models.EntityTypeRoute: errs.CodeRouteNotFound,
+}
+
+// uuidParam достает UUID из параметра пути.
+func (hs *handlerService) uuidParam(ctx *gin.Context, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		return uuid.UUID{}, errs.Invalid(name, "uuid", "")
+	}
+
+	return id, nil
+}
+
+func (hs *handlerService) newReview(ctx *gin.Context, entityType string, entityParam string) error {
+	entityID, err := hs.uuidParam(ctx, entityParam)
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		ReviewText string   `json:"review_text" binding:"required,min=6"`
+		Stars      float64  `json:"stars" binding:"required"`
+		Photos     []string `json:"photos" binding:"omitempty,dive,uuid"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	if params.Stars < 1 || params.Stars > 5 {
+		return errs.Invalid("stars", "range", "1-5")
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	photos, err := hs.checkReviewPhotos(ctx, user.ID, params.Photos)
+	if err != nil {
+		return err
+	}
+
+	photoIDs := make([]uuid.UUID, len(photos))
+	for i, photo := range photos {
+		photoIDs[i] = photo.ID
+	}
+
+	review, err := hs.pg.NewReview(ctx, models.Review{
+		EntityType: entityType,
+		EntityID:   entityID,
+		OwnerID:    user.ID,
+		ReviewText: params.ReviewText,
+		Stars:      math.Round(params.Stars),
+		CreatedAt:  time.Now(),
+	}, photoIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return errs.New(entityNotFound[entityType])
+		case hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err):
+			return errs.New(errs.CodeReviewAlreadyExists).WithParam("entity_type", entityType)
+		default:
+			return fmt.Errorf("new review: %w", err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(struct {
+		*models.Review
+		Photos []models.Blob `json:"photos"`
+	}{
+		Review: review,
+		Photos: photos,
+	}))
+
+	return nil
+}
+
+func (hs *handlerService) editReview(ctx *gin.Context, entityType string, entityParam string) error {
+	entityID, err := hs.uuidParam(ctx, entityParam)
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		ReviewText string  `json:"review_text" binding:"omitempty,min=6"`
+		Stars      float64 `json:"stars"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	if params.Stars != 0 && (params.Stars < 1 || params.Stars > 5) {
+		return errs.Invalid("stars", "range", "1-5")
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	review, err := hs.pg.GetReview(ctx, entityType, entityID, user.ID)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeReviewNotFound)
+	}
+
+	if params.ReviewText != "" {
+		review.ReviewText = params.ReviewText
+	}
+	if params.Stars != 0 {
+		review.Stars = math.Round(params.Stars)
+	}
+
+	if err = hs.pg.SaveReview(ctx, review); err != nil {
+		return errs.NotFound(err, entityNotFound[entityType])
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(review))
+
+	return nil
+}
+
+func (hs *handlerService) getReviews(ctx *gin.Context, entityType string, entityParam string) error {
+	entityID, err := hs.uuidParam(ctx, entityParam)
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		Sort string `form:"sort" binding:"omitempty,oneof=recent helpful stars"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	reviews, err := hs.pg.GetReviews(ctx, entityType, entityID, params.Sort)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("get reviews: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(reviews))
+
+	return nil
+}
+
+// DeleteReview
+// @Summary Удалить отзыв
+// @Description Скрывает отзыв о месте, событии или маршруте и пересчитывает рейтинг. Доступно модераторам и администраторам.
+// @ID delete-review
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /reviews/{reviewId} [delete]
+func (hs *handlerService) DeleteReview(ctx *gin.Context) error {
+	reviewID, err := hs.uuidParam(ctx, "reviewId")
+	if err != nil {
+		return err
+	}
+
+	if err := hs.pg.DeleteReview(ctx, reviewID); err != nil {
+		return errs.NotFound(err, errs.CodeReviewNotFound)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// reviewIDs достает из пути идентификаторы сущности и отзыва.
+func (hs *handlerService) reviewIDs(ctx *gin.Context, entityParam string) (uuid.UUID, uuid.UUID, error) {
+	entityID, err := hs.uuidParam(ctx, entityParam)
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, err
+	}
+
+	reviewID, err := hs.uuidParam(ctx, "reviewId")
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, err
+	}
+
+	return entityID, reviewID, nil
+}
+
+func (hs *handlerService) voteReview(ctx *gin.Context, entityType string, entityParam string) error {
+	entityID, reviewID, err := hs.reviewIDs(ctx, entityParam)
+	if err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	ownerID, err := hs.pg.GetReviewOwnerID(ctx, entityType, entityID, reviewID)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeReviewNotFound)
+	}
+
+	if ownerID == user.ID {
+		return errs.New(errs.CodeReviewSelfVote)
+	}
+
+	vote, err := hs.pg.NewReviewVote(ctx, models.ReviewVote{
+		ReviewID:  reviewID,
+		UserID:    user.ID,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
+			return errs.New(errs.CodeReviewVoteExists)
+		}
+
+		return fmt.Errorf("new review vote: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(vote))
+
+	return nil
+}
+
+func (hs *handlerService) unvoteReview(ctx *gin.Context, entityType string, entityParam string) error {
+	entityID, reviewID, err := hs.reviewIDs(ctx, entityParam)
+	if err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = hs.pg.GetReviewOwnerID(ctx, entityType, entityID, reviewID); err != nil {
+		return errs.NotFound(err, errs.CodeReviewNotFound)
+	}
+
+	deleted, err := hs.pg.DeleteReviewVote(ctx, reviewID, user.ID)
+	if err != nil {
+		return fmt.Errorf("delete review vote: %w", err)
+	}
+
+	if !deleted {
+		return errs.New(errs.CodeVoteNotFound)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// replyReview создает или редактирует официальный ответ компании на отзыв.
+// Отвечать может любой сотрудник компании, которой принадлежит событие или маршрут:
+// роль company_member проверяет маршрут, членство в конкретной компании - обработчик.
+func (hs *handlerService) replyReview(ctx *gin.Context, entityType string, entityParam string, edit bool) error {
+	entityID, reviewID, err := hs.reviewIDs(ctx, entityParam)
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		ReplyText string `json:"reply_text" binding:"required,min=2"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	companyID, err := hs.pg.GetEntityCompanyID(ctx, entityType, entityID, false)
+	if err != nil {
+		return errs.NotFound(err, entityNotFound[entityType])
+	}
+
+	if companyID == nil {
+		return errs.New(errs.CodeReviewReplyForbidden)
+	}
+
+	isMember, err := hs.pg.IsCompanyMember(ctx, *companyID, user.ID)
+	if err != nil {
+		return fmt.Errorf("check company member: %w", err)
+	}
+
+	if !isMember {
+		return errs.New(errs.CodeReviewReplyForbidden)
+	}
+
+	if _, err = hs.pg.GetReviewOwnerID(ctx, entityType, entityID, reviewID); err != nil {
+		return errs.NotFound(err, errs.CodeReviewNotFound)
+	}
+
+	if !edit {
+		reply, err := hs.pg.NewReviewReply(ctx, models.ReviewReply{
+			ReviewID:  reviewID,
+			CompanyID: *companyID,
+			OwnerID:   user.ID,
+			ReplyText: params.ReplyText,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+		if err != nil {
+			if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
+				return errs.New(errs.CodeReviewReplyExists)
+			}
+
+			return fmt.Errorf("new review reply: %w", err)
+		}
+
+		ctx.JSON(http.StatusOK, models.NewResponse(reply))
+
+		return nil
+	}
+
+	reply, err := hs.pg.GetReviewReply(ctx, reviewID)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeReviewReplyNotFound)
+	}
+
+	reply.OwnerID = user.ID
+	reply.ReplyText = params.ReplyText
+	reply.UpdatedAt = time.Now()
+
+	if err = hs.pg.SaveReviewReply(ctx, reply); err != nil {
+		return fmt.Errorf("save review reply: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(reply))
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetRoles
+// @Summary Получить список ролей
+// @Description Возвращает все роли и их разрешения. Доступно только администраторам.
+// @ID get-roles
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Success 200 {object} []models.RoleInfo
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /roles [get]
+func (hs *handlerService) GetRoles(ctx *gin.Context) error {
+	roles := make([]models.RoleInfo, 0, len(models.RolePermissions))
+	for role, permissions := range models.RolePermissions {
+		roles = append(roles, models.RoleInfo{Role: role, Permissions: permissions})
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Role < roles[j].Role
+	})
+
+	ctx.JSON(http.StatusOK, models.NewResponse(roles))
+
+	return nil
+}
+
+// GetUserRoles
+// @Summary Получить роли пользователя
+// @Description Возвращает роли пользователя по его VK ID. Доступно только администраторам.
+// @ID get-user-roles
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param vkId path integer true "VK ID пользователя"
+// @Success 200 {object} []string
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /users/{vkId}/roles [get]
+func (hs *handlerService) GetUserRoles(ctx *gin.Context) error {
+	user, err := hs.roleTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	roles, err := hs.pg.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get user roles: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(roles))
+
+	return nil
+}
+
+// GrantRole
+// @Summary Выдать роль пользователю
+// @Description Выдает роль пользователю по его VK ID. Изменение записывается в журнал. Доступно только администраторам.
+// @ID grant-role
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param vkId path integer true "VK ID пользователя"
+// @Param role body string true "Роль: admin, moderator, content_editor или company_member"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /users/{vkId}/roles [post]
+func (hs *handlerService) GrantRole(ctx *gin.Context) error {
+	var params struct {
+		Role string `json:"role" binding:"required,oneof=admin moderator content_editor company_member"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	user, err := hs.roleTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	actor, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	granted, err := hs.pg.GrantRole(ctx, &actor.ID, user.ID, params.Role)
+	if err != nil {
+		return fmt.Errorf("grant role: %w", err)
+	}
+
+	if !granted {
+		return errs.New(errs.CodeRoleAlreadyAssigned)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// RevokeRole
+// @Summary Отозвать роль у пользователя
+// @Description Отзывает роль у пользователя по его VK ID. Изменение записывается в журнал. Доступно только администраторам.
+// @ID revoke-role
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param vkId path integer true "VK ID пользователя"
+// @Param role path string true "Роль: admin, moderator, content_editor или company_member"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /users/{vkId}/roles/{role} [delete]
+func (hs *handlerService) RevokeRole(ctx *gin.Context) error {
+	var params struct {
+		Role string `uri:"role" binding:"required,oneof=admin moderator content_editor company_member"`
+	}
+
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
+	}
+
+	user, err := hs.roleTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	actor, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Иначе администратор может случайно лишить себя доступа к управлению ролями
+	if actor.ID == user.ID && params.Role == models.RoleAdmin {
+		return errs.New(errs.CodeAdminRoleSelfRevoke)
+	}
+
+	revoked, err := hs.pg.RevokeRole(ctx, &actor.ID, user.ID, params.Role)
+	if err != nil {
+		return fmt.Errorf("revoke role: %w", err)
+	}
+
+	if !revoked {
+		return errs.New(errs.CodeRoleNotAssigned)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// GetRolesAudit
+// @Summary Получить журнал изменения ролей
+// @Description Возвращает историю выдачи и отзыва ролей, начиная с последних изменений. Доступно только администраторам.
+// @ID get-roles-audit
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param vk_id query integer false "VK ID пользователя, по которому нужно отфильтровать журнал"
+// @Success 200 {object} []models.RoleAudit
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /roles/audit [get]
+func (hs *handlerService) GetRolesAudit(ctx *gin.Context) error {
+	var params struct {
+		VkID int64 `form:"vk_id"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	var userID *uuid.UUID
+	if params.VkID != 0 {
+		user, err := hs.pg.GetUserByVkID(ctx, params.VkID)
+		if err != nil {
+			return errs.NotFound(err, errs.CodeUserNotFound)
+		}
+
+		userID = &user.ID
+	}
+
+	audit, err := hs.pg.GetRolesAudit(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get roles audit: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(audit))
+
+	return nil
+}
+
+// roleTarget находит пользователя, чьими ролями управляют, по параметру пути vkId.
+func (hs *handlerService) roleTarget(ctx *gin.Context) (*models.User, error) {
+	vkID, err := strconv.ParseInt(ctx.Param("vkId"), 10, 64)
+	if err != nil {
+		return nil, errs.New(errs.CodeInvalidURI)
+	}
+
+	user, err := hs.pg.GetUserByVkID(ctx, vkID)
+	if err != nil {
+		return nil, errs.NotFound(err, errs.CodeUserNotFound)
+	}
+
+	return user, nil
+}
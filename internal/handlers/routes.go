@@ -1,17 +1,12 @@
 package handlers
 
 import (
-	"database/sql"
-	"errors"
+	"fmt"
 	"github.com/ShpullRequest/backend/internal/errs"
 	"github.com/ShpullRequest/backend/internal/models"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/jackc/pgerrcode"
-	"go.uber.org/zap"
-	"math"
 	"net/http"
-	"time"
 )
 
 // NewRoute
@@ -31,7 +26,7 @@ import (
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes [post]
-func (hs *handlerService) NewRoute(ctx *gin.Context) {
+func (hs *handlerService) NewRoute(ctx *gin.Context) error {
 	var params struct {
 		CompanyID   string   `json:"company_id" binding:"omitempty,uuid"`
 		Name        string   `json:"name" binding:"min=6"`
@@ -40,76 +35,73 @@ func (hs *handlerService) NewRoute(ctx *gin.Context) {
 		Events      []string `json:"events,omitempty"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
-	vkParams := hs.GetVKParams(ctx)
 
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
+	user, err := hs.currentUser(ctx)
 	if err != nil {
-		hs.logger.Error("Error get user by vk id", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		return err
 	}
 
 	var companyID *uuid.UUID = nil
 	paramCompanyID, err := uuid.Parse(params.CompanyID)
 
 	if err != nil {
-		if !user.IsAdmin {
-			ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You don't have access to this method")))
-			ctx.Abort()
+		permissions, err := hs.getPermissions(ctx)
+		if err != nil {
+			return err
+		}
 
-			return
+		if !permissions.Has(models.PermissionContentManage) {
+			return errs.New(errs.CodeAccessDenied)
 		}
 	} else {
 		company, err := hs.pg.GetCompanyByID(ctx, paramCompanyID)
 		if err != nil {
-			hs.logger.Error("Error get company by id", zap.Error(err))
-
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-			ctx.Abort()
-
-			return
+			return fmt.Errorf("get company by id: %w", err)
 		} else if company.UserID != user.ID {
-			ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You can't create an event on behalf of this company")))
-			ctx.Abort()
-
-			return
+			return errs.New(errs.CodeEventCompanyForbidden)
 		}
 
 		companyID = &company.ID
 	}
 
-	route, err := hs.pg.NewRoute(ctx, models.Route{
+	if err := hs.checkAPIKeyCompany(ctx, companyID); err != nil {
+		return err
+	}
+
+	route := models.Route{
 		CompanyID:   companyID,
 		Name:        params.Name,
 		Description: params.Description,
 		Places:      params.Places,
 		Events:      params.Events,
-	})
-	if err != nil {
-		hs.logger.Error("Error new route", zap.Error(err))
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	version, err := createdVersion(models.EntityVersion{
+		EntityType: models.EntityTypeRoute,
+		Action:     models.VersionActionCreate,
+		UserID:     &user.ID,
+	}, &routeVersion{Route: &route})
+	if err != nil {
+		return err
+	}
 
-		return
+	created, err := hs.pg.NewRoute(ctx, route, version)
+	if err != nil {
+		return fmt.Errorf("new route: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(route))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(created))
+
+	return nil
 }
 
 // EditRoute
 // @Summary Редактировать маршрут
-// @Description Редактирует существующий маршрут с указанными параметрами.
+// @Description Редактирует существующий маршрут с указанными параметрами. Правки пользователей, которые не могут
+// @Description редактировать маршрут, сохраняются как предложенное изменение, в этом случае возвращается код 202.
 // @ID edit-route
 // @Accept json
 // @Produce json
@@ -117,116 +109,95 @@ func (hs *handlerService) NewRoute(ctx *gin.Context) {
 // @Param routeId path string true "Уникальный идентификатор маршрута"
 // @Param name body string false "Название маршрута (минимум 6 символов, опционально)"
 // @Param description body string false "Описание маршрута (минимум 10 символов, опционально)"
+// @Param translations body models.Translations false "Переводы названия и описания по кодам языков, кроме ru. Переданные языки заменяются, пустой перевод удаляет язык"
 // @Param places body array false "Список мест (опционально)"
 // @Param events body array false "Список событий (опционально)"
+// @Param comment body string false "Пояснение к предложенному изменению"
 // @Success 200 {object} models.RouteWithGeo
+// @Success 202 {object} models.EditSuggestion
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes/{routeId} [patch]
-func (hs *handlerService) EditRoute(ctx *gin.Context) {
+func (hs *handlerService) EditRoute(ctx *gin.Context) error {
 	var paramsURI struct {
 		RouteID string `uri:"routeId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
+		return err
 	}
 
 	var params struct {
-		Name        string   `json:"name" binding:"omitempty,min=6"`
-		Description string   `json:"description" binding:"omitempty,min=10"`
-		Places      []string `json:"places,omitempty"`
-		Events      []string `json:"events,omitempty"`
+		routeChanges
+		Comment string `json:"comment" binding:"max=1000"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
-	vkParams := hs.GetVKParams(ctx)
 
 	routeID, _ := uuid.Parse(paramsURI.RouteID)
-	route, err := hs.pg.GetRoute(ctx, routeID)
+	route, err := hs.pg.GetRoute(ctx, routeID, false)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, models.NewErrorResponse(errs.NewNotFound("Route not found")))
-		} else {
-			hs.logger.Error("Error get event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
+		return errs.NotFound(err, errs.CodeRouteNotFound)
 	}
 
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
+	user, direct, err := hs.editAccess(ctx, route.CompanyID, models.PermissionRouteEdit)
 	if err != nil {
-		hs.logger.Error("Error get user by vk id", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+		return err
 	}
 
-	if route.CompanyID == nil {
-		if !user.IsAdmin {
-			ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You don't have access to this method")))
-			ctx.Abort()
+	if !direct {
+		return hs.suggestEdit(ctx, user, models.EntityTypeRoute, route.ID, params.routeChanges, params.Comment)
+	}
 
-			return
-		}
-	} else {
-		company, err := hs.pg.GetCompanyByID(ctx, *route.CompanyID)
-		if err != nil {
-			hs.logger.Error("Error get company by id", zap.Error(err))
+	entity, err := hs.updateVersioned(ctx, models.EntityTypeRoute, route.ID, models.EntityVersion{
+		EntityType: models.EntityTypeRoute,
+		EntityID:   route.ID,
+		Action:     models.VersionActionEdit,
+		UserID:     &user.ID,
+	}, func(entity *versionedEntity) error {
+		applyRouteChanges(&entity.value.(*models.RouteWithGeo).Route, params.routeChanges)
 
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-			ctx.Abort()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save route: %w", err)
+	}
 
-			return
-		}
+	ctx.JSON(http.StatusOK, models.NewResponse(entity.value))
 
-		if company.UserID != user.ID {
-			ctx.JSON(http.StatusForbidden, models.NewErrorResponse(errs.NewForbidden("You can't create an event on behalf of this company")))
-			ctx.Abort()
+	return nil
+}
 
-			return
-		}
-	}
+// routeChanges поля запроса редактирования маршрута. В том же виде хранятся в предложенных изменениях.
+type routeChanges struct {
+	Name         string              `json:"name" binding:"omitempty,min=6"`
+	Description  string              `json:"description" binding:"omitempty,min=10"`
+	Translations models.Translations `json:"translations,omitempty" binding:"omitempty,dive,keys,len=2,ne=ru,endkeys"`
+	Places       []string            `json:"places,omitempty"`
+	Events       []string            `json:"events,omitempty"`
+}
 
-	if params.Name != "" {
-		route.Name = params.Name
+func applyRouteChanges(route *models.Route, changes routeChanges) {
+	if changes.Name != "" {
+		route.Name = changes.Name
 	}
-	if params.Description != "" {
-		route.Description = params.Description
+	if changes.Description != "" {
+		route.Description = changes.Description
 	}
-	if len(params.Places) > 0 {
-		route.Places = params.Places
+	if changes.Translations != nil {
+		route.Translations = route.Translations.Merge(changes.Translations)
 	}
-	if len(params.Events) > 0 {
-		route.Events = params.Events
+	if len(changes.Places) > 0 {
+		route.Places = changes.Places
 	}
-
-	if err = hs.pg.SaveRoute(ctx, route); err != nil {
-		hs.logger.Error("Error save route", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+	if len(changes.Events) > 0 {
+		route.Events = changes.Events
 	}
-
-	ctx.JSON(http.StatusOK, models.NewResponse(route))
-	ctx.Abort()
 }
 
 // GetRoute
@@ -236,38 +207,43 @@ func (hs *handlerService) EditRoute(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param routeId path string true "Уникальный идентификатор маршрута"
+// @Param include_deleted query bool false "Включить удаленные записи (только для администраторов)"
 // @Success 200 {object} models.RouteWithGeo
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes/{routeId} [get]
-func (hs *handlerService) GetRoute(ctx *gin.Context) {
+func (hs *handlerService) GetRoute(ctx *gin.Context) error {
 	var params struct {
 		RouteID string `uri:"routeId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
+	}
 
-		return
+	includeDeleted, err := hs.includeDeleted(ctx)
+	if err != nil {
+		return err
 	}
 
 	routeID, _ := uuid.Parse(params.RouteID)
-	route, err := hs.pg.GetRoute(ctx, routeID)
+	route, err := hs.pg.GetRoute(ctx, routeID, includeDeleted)
 
 	if err != nil {
-		hs.logger.Error("Error get route", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		return errs.NotFound(err, errs.CodeRouteNotFound)
+	}
 
-		return
+	if !route.IsDeleted {
+		hs.recordView(ctx, models.EntityTypeRoute, route.ID)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(route))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, route)))
+
+	return nil
 }
 
 // SearchRoutes
@@ -277,35 +253,37 @@ func (hs *handlerService) GetRoute(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param query path string true "Запрос для поиска маршрутов (минимум 2 символа)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param tag query string false "Тег (UUID или slug), для категории учитываются и ее дочерние теги"
+// @Param sort query string false "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом"
 // @Success 200 {object} []models.RouteWithGeo
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes/search/{query} [get]
-func (hs *handlerService) SearchRoutes(ctx *gin.Context) {
+func (hs *handlerService) SearchRoutes(ctx *gin.Context) error {
 	var params struct {
 		Query string `uri:"query" binding:"required,min=2"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
 	}
 
-	routes, err := hs.pg.SearchRoutes(ctx, params.Query)
+	filter, err := hs.listFilter(ctx)
 	if err != nil {
-		hs.logger.Error("Error search routes", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		return err
+	}
 
-		return
+	routes, err := hs.pg.SearchRoutes(ctx, params.Query, filter)
+	if err != nil {
+		return fmt.Errorf("search routes: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(routes))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, routes)))
+
+	return nil
 }
 
 // GetCompanyRoutes
@@ -315,38 +293,40 @@ func (hs *handlerService) SearchRoutes(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
 // @Param companyId path string true "Уникальный идентификатор компании"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param tag query string false "Тег (UUID или slug), для категории учитываются и ее дочерние теги"
+// @Param sort query string false "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом"
 // @Success 200 {object} []models.RouteWithGeo
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes/company/{companyId} [get]
-func (hs *handlerService) GetCompanyRoutes(ctx *gin.Context) {
+func (hs *handlerService) GetCompanyRoutes(ctx *gin.Context) error {
 	var params struct {
 		CompanyID string `uri:"companyId" binding:"required,uuid"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
 	}
 
 	companyID, _ := uuid.Parse(params.CompanyID)
-	routes, err := hs.pg.GetAllRoutesByCompanyID(ctx, companyID)
-
+	filter, err := hs.listFilter(ctx)
 	if err != nil {
-		hs.logger.Error("Error get all routes by company id", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	routes, err := hs.pg.GetAllRoutesByCompanyID(ctx, companyID, filter)
 
-		return
+	if err != nil {
+		return fmt.Errorf("get all routes by company id: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(routes))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, routes)))
+
+	return nil
 }
 
 // GetAllRoutes
@@ -356,22 +336,72 @@ func (hs *handlerService) GetCompanyRoutes(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
+// @Param include_deleted query bool false "Включить удаленные записи (только для администраторов)"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param tag query string false "Тег (UUID или slug), для категории учитываются и ее дочерние теги"
+// @Param sort query string false "Порядок: trending - сначала популярные сейчас, rating - сначала с лучшим рейтингом"
 // @Success 200 {object} []models.RouteWithGeo
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes [get]
-func (hs *handlerService) GetAllRoutes(ctx *gin.Context) {
-	routes, err := hs.pg.GetAllRoutes(ctx)
+func (hs *handlerService) GetAllRoutes(ctx *gin.Context) error {
+	includeDeleted, err := hs.includeDeleted(ctx)
 	if err != nil {
-		hs.logger.Error("Error get all routes", zap.Error(err))
+		return err
+	}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+	filter, err := hs.listFilter(ctx)
+	if err != nil {
+		return err
+	}
 
-		return
+	routes, err := hs.pg.GetAllRoutes(ctx, includeDeleted, filter)
+	if err != nil {
+		return fmt.Errorf("get all routes: %w", err)
 	}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(routes))
-	ctx.Abort()
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, routes)))
+
+	return nil
+}
+
+// DeleteRoute
+// @Summary Удалить маршрут
+// @Description Помечает маршрут удаленным. Доступно администраторам и владельцу компании, которой принадлежит маршрут.
+// @ID delete-route
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param routeId path string true "Уникальный идентификатор маршрута (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /routes/{routeId} [delete]
+func (hs *handlerService) DeleteRoute(ctx *gin.Context) error {
+	return hs.setEntityDeleted(ctx, models.EntityTypeRoute, "routeId", true)
+}
+
+// RestoreRoute
+// @Summary Восстановить маршрут
+// @Description Восстанавливает удаленный маршрут. Доступно администраторам и владельцу компании, которой принадлежит маршрут.
+// @ID restore-route
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param routeId path string true "Уникальный идентификатор маршрута (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /routes/{routeId}/restore [post]
+func (hs *handlerService) RestoreRoute(ctx *gin.Context) error {
+	return hs.setEntityDeleted(ctx, models.EntityTypeRoute, "routeId", false)
 }
 
 // NewReviewRoute
@@ -384,79 +414,16 @@ func (hs *handlerService) GetAllRoutes(ctx *gin.Context) {
 // @Param routeId path string true "Уникальный идентификатор маршрута"
 // @Param review_text body string true "Текст отзыва (минимум 6 символов)"
 // @Param stars body number true "Оценка (от 1 до 5)"
-// @Success 200 {object} models.ReviewRoute
+// @Param photos body []string false "Идентификаторы загруженных фотографий (опционально)"
+// @Success 200 {object} models.Review
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 409 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes/{routeId}/reviews [post]
-func (hs *handlerService) NewReviewRoute(ctx *gin.Context) {
-	var paramsURI struct {
-		RouteID string `uri:"routeId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	var params struct {
-		ReviewText string  `json:"review_text" binding:"required,min=6"`
-		Stars      float64 `json:"stars" binding:"required"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-	vkParams := hs.GetVKParams(ctx)
-
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
-	if err != nil {
-		hs.logger.Error("Error get user", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	if params.Stars < 1 || params.Stars > 5 {
-		ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"Stars\" failed on the 'min=1,max=5' tag.")))
-		ctx.Abort()
-
-		return
-	} else {
-		params.Stars = math.Round(params.Stars)
-	}
-
-	routeID, _ := uuid.Parse(paramsURI.RouteID)
-	reviewRoute, err := hs.pg.NewReviewRoute(ctx, models.ReviewRoute{
-		OwnerID:    user.ID,
-		RouteID:    routeID,
-		ReviewText: params.ReviewText,
-		Stars:      params.Stars,
-		CreatedAt:  time.Now(),
-	})
-
-	if err != nil {
-		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
-			ctx.JSON(http.StatusConflict, models.NewErrorResponse(errs.NewConflict("You have already added a review to this route")))
-		} else {
-			hs.logger.Error("Error new review event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
-	}
-
-	ctx.JSON(http.StatusOK, models.NewResponse(reviewRoute))
-	ctx.Abort()
+func (hs *handlerService) NewReviewRoute(ctx *gin.Context) error {
+	return hs.newReview(ctx, models.EntityTypeRoute, "routeId")
 }
 
 // EditReviewRoute
@@ -469,93 +436,14 @@ func (hs *handlerService) NewReviewRoute(ctx *gin.Context) {
 // @Param routeId path string true "Уникальный идентификатор маршрута"
 // @Param review_text body string false "Текст отзыва (минимум 6 символов, опционально)"
 // @Param stars body number false "Оценка (от 1 до 5, опционально)"
-// @Success 200 {object} models.ReviewRoute
+// @Success 200 {object} models.Review
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes/{routeId}/reviews [patch]
-func (hs *handlerService) EditReviewRoute(ctx *gin.Context) {
-	var paramsURI struct {
-		RouteID string `uri:"routeId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &paramsURI); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	var params struct {
-		ReviewText string  `json:"review_text"`
-		Stars      float64 `json:"stars"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-	vkParams := hs.GetVKParams(ctx)
-
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
-	if err != nil {
-		hs.logger.Debug("Error get user", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	routeID, _ := uuid.Parse(paramsURI.RouteID)
-	reviewRoute, err := hs.pg.GetReviewRoute(ctx, user.ID, routeID)
-
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusNotFound, models.NewErrorResponse(errs.NewNotFound("Review route not found")))
-		} else {
-			hs.logger.Error("Error get event", zap.Error(err))
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-		ctx.Abort()
-
-		return
-	}
-
-	if params.ReviewText != "" {
-		if len(params.ReviewText) < 6 {
-			ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"ReviewText\" failed on the 'min=6' tag.")))
-			ctx.Abort()
-
-			return
-		}
-
-		reviewRoute.ReviewText = params.ReviewText
-	}
-	if params.Stars != 0 {
-		if params.Stars < 1 || params.Stars > 5 {
-			ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("Field validation for \"Stars\" failed on the 'min=1,max=5' tag.")))
-			ctx.Abort()
-
-			return
-		} else {
-			reviewRoute.Stars = math.Round(params.Stars)
-		}
-	}
-
-	if err = hs.pg.SaveReviewRoute(ctx, reviewRoute); err != nil {
-		hs.logger.Debug("Error save review route", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
-	}
-
-	ctx.JSON(http.StatusOK, models.NewResponse(reviewRoute))
-	ctx.Abort()
+func (hs *handlerService) EditReviewRoute(ctx *gin.Context) error {
+	return hs.editReview(ctx, models.EntityTypeRoute, "routeId")
 }
 
 // GetReviewsRoutes
@@ -566,34 +454,91 @@ func (hs *handlerService) EditReviewRoute(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
 // @Param routeId path string true "Уникальный идентификатор маршрута"
-// @Success 200 {object} models.ReviewRoute
+// @Param sort query string false "Сортировка: recent (по умолчанию), helpful или stars"
+// @Success 200 {object} []models.ReviewWithMeta
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /routes/{routeId}/reviews [get]
-func (hs *handlerService) GetReviewsRoutes(ctx *gin.Context) {
-	var params struct {
-		RouteID string `uri:"routeId" binding:"required,uuid"`
-	}
-
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
-	}
-
-	routeID, _ := uuid.Parse(params.RouteID)
-	reviews, err := hs.pg.GetReviewsEvent(ctx, routeID)
+func (hs *handlerService) GetReviewsRoutes(ctx *gin.Context) error {
+	return hs.getReviews(ctx, models.EntityTypeRoute, "routeId")
+}
 
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		hs.logger.Debug("Error get reviews", zap.Error(err))
+// VoteReviewRoute
+// @Summary Отметить отзыв о маршруте полезным
+// @Description Добавляет отметку "полезно" от текущего пользователя к отзыву о маршруте.
+// @ID vote-review-route
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param routeId path string true "Уникальный идентификатор маршрута"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Success 200 {object} models.ReviewVote
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /routes/{routeId}/reviews/{reviewId}/helpful [post]
+func (hs *handlerService) VoteReviewRoute(ctx *gin.Context) error {
+	return hs.voteReview(ctx, models.EntityTypeRoute, "routeId")
+}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+// UnvoteReviewRoute
+// @Summary Снять отметку "полезно" с отзыва о маршруте
+// @Description Удаляет отметку "полезно" текущего пользователя с отзыва о маршруте.
+// @ID unvote-review-route
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param routeId path string true "Уникальный идентификатор маршрута"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /routes/{routeId}/reviews/{reviewId}/helpful [delete]
+func (hs *handlerService) UnvoteReviewRoute(ctx *gin.Context) error {
+	return hs.unvoteReview(ctx, models.EntityTypeRoute, "routeId")
+}
 
-		return
-	}
+// NewReviewRouteReply
+// @Summary Ответить на отзыв о маршруте
+// @Description Создает официальный ответ компании-владельца на отзыв о маршруте. На каждый отзыв возможен только один ответ.
+// @ID new-review-route-reply
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param routeId path string true "Уникальный идентификатор маршрута"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Param reply_text body string true "Текст ответа (минимум 2 символа)"
+// @Success 200 {object} models.ReviewReply
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /routes/{routeId}/reviews/{reviewId}/reply [post]
+func (hs *handlerService) NewReviewRouteReply(ctx *gin.Context) error {
+	return hs.replyReview(ctx, models.EntityTypeRoute, "routeId", false)
+}
 
-	ctx.JSON(http.StatusOK, models.NewResponse(reviews))
-	ctx.Abort()
+// EditReviewRouteReply
+// @Summary Редактировать ответ на отзыв о маршруте
+// @Description Редактирует официальный ответ компании-владельца на отзыв о маршруте.
+// @ID edit-review-route-reply
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param routeId path string true "Уникальный идентификатор маршрута"
+// @Param reviewId path string true "Уникальный идентификатор отзыва (в формате UUID)"
+// @Param reply_text body string true "Текст ответа (минимум 2 символа)"
+// @Success 200 {object} models.ReviewReply
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /routes/{routeId}/reviews/{reviewId}/reply [patch]
+func (hs *handlerService) EditReviewRouteReply(ctx *gin.Context) error {
+	return hs.replyReview(ctx, models.EntityTypeRoute, "routeId", true)
 }
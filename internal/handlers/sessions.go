@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// NewSession
+// @Summary Создать сессию
+// @Description Обменивает параметры запуска VK на пару токенов доступа и обновления для веб-клиентов.
+// @ID new-session
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Параметры запуска VK"
+// @Success 200 {object} models.SessionTokens
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /auth/sessions [post]
+func (hs *handlerService) NewSession(ctx *gin.Context) error {
+	principal := hs.GetPrincipal(ctx)
+	if principal.Method != auth.MethodVKLaunchParams {
+		return errs.New(errs.CodeSessionLaunchParamsRequired)
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	session, err := hs.pg.NewSession(ctx, models.Session{
+		UserID:     user.ID,
+		VkID:       user.VkID,
+		RefreshJTI: uuid.New(),
+		UserAgent:  ctx.Request.UserAgent(),
+		IP:         ctx.ClientIP(),
+		ExpiresAt:  time.Now().Add(hs.sessions.RefreshTTL()),
+	})
+	if err != nil {
+		return fmt.Errorf("new session: %w", err)
+	}
+
+	return hs.issueSessionTokens(ctx, session)
+}
+
+// RefreshSession
+// @Summary Обновить токены сессии
+// @Description Выпускает новую пару токенов по токену обновления. Каждый токен обновления одноразовый,
+// @Description повторное использование отзывает всю сессию.
+// @ID refresh-session
+// @Accept json
+// @Produce json
+// @Param refresh_token body string true "Токен обновления"
+// @Success 200 {object} models.SessionTokens
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /auth/refresh [post]
+func (hs *handlerService) RefreshSession(ctx *gin.Context) error {
+	var params struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	claims, err := hs.sessions.ParseRefreshToken(params.RefreshToken)
+	if err != nil {
+		return errs.New(errs.CodeRefreshTokenInvalid)
+	}
+
+	session, err := hs.pg.GetSession(ctx, claims.SessionID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	if err != nil || !session.IsActive() {
+		return errs.New(errs.CodeSessionRevoked)
+	}
+
+	newJTI := uuid.New()
+	expiresAt := time.Now().Add(hs.sessions.RefreshTTL())
+
+	rotated, err := hs.pg.RotateSessionRefresh(ctx, session.ID, claims.ID, newJTI, expiresAt)
+	if err != nil {
+		return fmt.Errorf("rotate session refresh token: %w", err)
+	}
+
+	if !rotated {
+		// Токен обновления уже был использован: вероятна утечка, поэтому отзываем сессию целиком
+		if _, err = hs.pg.RevokeSession(ctx, session.ID, session.UserID); err != nil {
+			hs.logger.Error("Error revoke session", zap.Error(err))
+		}
+		hs.logger.Warn("Refresh token reuse detected", zap.String("SessionID", session.ID.String()))
+
+		return errs.New(errs.CodeSessionRevoked)
+	}
+
+	session.RefreshJTI = newJTI
+	session.ExpiresAt = expiresAt
+
+	return hs.issueSessionTokens(ctx, session)
+}
+
+// GetSessions
+// @Summary Получить активные сессии
+// @Description Возвращает действующие сессии текущего пользователя.
+// @ID get-sessions
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Success 200 {object} []models.Session
+// @Failure 500 {object} models.ErrorResponse
+// @Router /auth/sessions [get]
+func (hs *handlerService) GetSessions(ctx *gin.Context) error {
+	principal := hs.GetPrincipal(ctx)
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := hs.pg.GetUserSessions(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get user sessions: %w", err)
+	}
+
+	for i := range sessions {
+		sessions[i].IsCurrent = principal.SessionID != nil && *principal.SessionID == sessions[i].ID
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(sessions))
+
+	return nil
+}
+
+// RevokeSession
+// @Summary Отозвать сессию
+// @Description Отзывает сессию текущего пользователя. Выданные в ней токены перестают действовать сразу.
+// @ID revoke-session
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param sessionId path string true "Уникальный идентификатор сессии (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /auth/sessions/{sessionId} [delete]
+func (hs *handlerService) RevokeSession(ctx *gin.Context) error {
+	sessionID, err := hs.uuidParam(ctx, "sessionId")
+	if err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := hs.pg.RevokeSession(ctx, sessionID, user.ID)
+	if err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+
+	if !revoked {
+		return errs.New(errs.CodeSessionNotFound)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+func (hs *handlerService) issueSessionTokens(ctx *gin.Context, session *models.Session) error {
+	tokens, err := hs.sessions.Issue(session)
+	if err != nil {
+		return fmt.Errorf("issue session tokens: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(tokens))
+
+	return nil
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/ShpullRequest/backend/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// GetEditSuggestions
+// @Summary Предложенные изменения
+// @Description Возвращает изменения мест, событий, маршрутов и компаний, предложенные пользователями.
+// @ID get-edit-suggestions
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param status query string false "Статус: pending (по умолчанию), accepted, rejected"
+// @Param entity_type query string false "Тип сущности: place, event, route, company"
+// @Success 200 {object} []models.EditSuggestion
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /suggestions [get]
+func (hs *handlerService) GetEditSuggestions(ctx *gin.Context) error {
+	return hs.respondEditSuggestions(ctx, nil)
+}
+
+// GetCompanyEditSuggestions
+// @Summary Предложенные изменения компании
+// @Description Возвращает изменения, предложенные для компании и принадлежащих ей мест, событий и маршрутов.
+// @Description Доступно только владельцу компании.
+// @ID get-company-edit-suggestions
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param companyId path string true "Уникальный идентификатор компании (в формате UUID)"
+// @Param status query string false "Статус: pending (по умолчанию), accepted, rejected"
+// @Param entity_type query string false "Тип сущности: place, event, route, company"
+// @Success 200 {object} []models.EditSuggestion
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /companies/{companyId}/suggestions [get]
+func (hs *handlerService) GetCompanyEditSuggestions(ctx *gin.Context) error {
+	companyID, err := hs.uuidParam(ctx, "companyId")
+	if err != nil {
+		return err
+	}
+
+	company, err := hs.ownCompany(ctx, companyID)
+	if err != nil {
+		return err
+	}
+
+	return hs.respondEditSuggestions(ctx, &company.ID)
+}
+
+// AcceptEditSuggestion
+// @Summary Принять предложенное изменение
+// @Description Применяет предложенное изменение к сущности и отмечает его принятым. Доступно владельцу сущности
+// @Description и модераторам.
+// @ID accept-edit-suggestion
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param suggestionId path string true "Уникальный идентификатор изменения (в формате UUID)"
+// @Param comment body string false "Комментарий"
+// @Success 200 {object} models.EditSuggestion
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 502 {object} models.ErrorResponse
+// @Router /suggestions/{suggestionId}/accept [post]
+func (hs *handlerService) AcceptEditSuggestion(ctx *gin.Context) error {
+	return hs.reviewEditSuggestion(ctx, models.SuggestionStatusAccepted)
+}
+
+// RejectEditSuggestion
+// @Summary Отклонить предложенное изменение
+// @Description Отклоняет предложенное изменение, сущность не меняется. Доступно владельцу сущности и модераторам.
+// @ID reject-edit-suggestion
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param suggestionId path string true "Уникальный идентификатор изменения (в формате UUID)"
+// @Param comment body string false "Причина отказа"
+// @Success 200 {object} models.EditSuggestion
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /suggestions/{suggestionId}/reject [post]
+func (hs *handlerService) RejectEditSuggestion(ctx *gin.Context) error {
+	return hs.reviewEditSuggestion(ctx, models.SuggestionStatusRejected)
+}
+
+func (hs *handlerService) respondEditSuggestions(ctx *gin.Context, companyID *uuid.UUID) error {
+	var params struct {
+		Status     string `form:"status" binding:"omitempty,oneof=pending accepted rejected"`
+		EntityType string `form:"entity_type" binding:"omitempty,oneof=place event route company"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+
+	if params.Status == "" {
+		params.Status = models.SuggestionStatusPending
+	}
+
+	suggestions, err := hs.pg.GetEditSuggestions(ctx, params.Status, params.EntityType, companyID)
+	if err != nil {
+		return fmt.Errorf("get edit suggestions: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(suggestions))
+
+	return nil
+}
+
+func (hs *handlerService) reviewEditSuggestion(ctx *gin.Context, status string) error {
+	suggestionID, err := hs.uuidParam(ctx, "suggestionId")
+	if err != nil {
+		return err
+	}
+
+	comment, err := hs.reviewComment(ctx)
+	if err != nil {
+		return err
+	}
+
+	reviewer, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	suggestion, err := hs.pg.GetEditSuggestion(ctx, suggestionID)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeSuggestionNotFound)
+	}
+
+	if suggestion.Status != models.SuggestionStatusPending {
+		return errs.New(errs.CodeSuggestionReviewed)
+	}
+
+	entity, err := hs.versionedEntity(ctx, suggestion.EntityType, suggestion.EntityID)
+	if err != nil {
+		return err
+	}
+
+	if err := hs.checkSuggestionReviewAccess(ctx, entity); err != nil {
+		return err
+	}
+
+	var update repository.EntityUpdate
+	if status == models.SuggestionStatusAccepted {
+		update = hs.entityUpdate(models.EntityVersion{
+			EntityType:   suggestion.EntityType,
+			EntityID:     suggestion.EntityID,
+			Action:       models.VersionActionSuggestion,
+			UserID:       &suggestion.UserID,
+			SuggestionID: &suggestion.ID,
+		}, func(entity *versionedEntity) error {
+			return hs.applySuggestion(ctx, suggestion, entity)
+		})
+	}
+
+	reviewed, err := hs.pg.ReviewEditSuggestion(ctx, suggestion, status, reviewer.ID, comment, update)
+	if err != nil {
+		return fmt.Errorf("review edit suggestion: %w", err)
+	}
+
+	if !reviewed {
+		return errs.New(errs.CodeSuggestionReviewed)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(suggestion))
+
+	return nil
+}
+
+// checkSuggestionReviewAccess проверяет, что пользователь может рассмотреть изменение:
+// модераторы рассматривают любые изменения, компании - изменения своих сущностей.
+func (hs *handlerService) checkSuggestionReviewAccess(ctx *gin.Context, entity *versionedEntity) error {
+	permissions, err := hs.getPermissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !permissions.Has(models.PermissionSuggestionReview) {
+		if entity.companyID == nil {
+			return errs.New(errs.CodeAccessDenied)
+		}
+
+		if _, err := hs.ownCompany(ctx, *entity.companyID); err != nil {
+			return err
+		}
+	}
+
+	return hs.checkAPIKeyCompany(ctx, entity.companyID)
+}
+
+// applySuggestion применяет предложенные изменения к сущности, заблокированной при рассмотрении изменения.
+// Изменения проверяются заново, так как сущность могла измениться после создания предложения.
+func (hs *handlerService) applySuggestion(ctx *gin.Context, suggestion *models.EditSuggestion, entity *versionedEntity) error {
+	var err error
+	switch suggestion.EntityType {
+	case models.EntityTypePlace:
+		var changes placeChanges
+		if err = hs.suggestionChanges(suggestion, &changes); err == nil {
+			err = hs.applyPlaceChanges(ctx, entity.snapshot.(*models.Place), changes)
+		}
+	case models.EntityTypeEvent:
+		var changes eventChanges
+		if err = hs.suggestionChanges(suggestion, &changes); err == nil {
+			err = hs.applyEventChanges(ctx, entity.snapshot.(*models.Event), changes)
+		}
+	case models.EntityTypeRoute:
+		var changes routeChanges
+		if err = hs.suggestionChanges(suggestion, &changes); err == nil {
+			applyRouteChanges(entity.snapshot.(*routeVersion).Route, changes)
+		}
+	case models.EntityTypeCompany:
+		var changes companyChanges
+		if err = hs.suggestionChanges(suggestion, &changes); err == nil {
+			err = hs.applyCompanyChanges(ctx, entity.snapshot.(*models.Company), changes)
+		}
+	}
+
+	return err
+}
+
+// suggestionChanges разбирает сохраненные изменения в changes (указатель на структуру запроса редактирования)
+// и проверяет их по правилам запроса.
+func (hs *handlerService) suggestionChanges(suggestion *models.EditSuggestion, changes any) error {
+	if err := json.Unmarshal(suggestion.Changes, changes); err != nil {
+		return fmt.Errorf("unmarshal suggested changes: %w", err)
+	}
+
+	if err := binding.Validator.ValidateStruct(changes); err != nil {
+		return hs.parseShouldBindErrors(err)
+	}
+
+	return nil
+}
+
+// editAccess определяет, может ли пользователь изменить сущность сам (direct) или только предложить правку.
+// Напрямую сущность редактирует компания-владелец с разрешением editPermission и пользователь с разрешением
+// content.manage, остальные пользователи с разрешением edit.suggest могут предложить изменение.
+func (hs *handlerService) editAccess(ctx *gin.Context, companyID *uuid.UUID, editPermission string) (user *models.User, direct bool, err error) {
+	if user, err = hs.currentUser(ctx); err != nil {
+		return nil, false, err
+	}
+
+	permissions, err := hs.getPermissions(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	direct = permissions.Has(models.PermissionContentManage)
+	if !direct && companyID != nil && permissions.Has(editPermission) {
+		company, err := hs.pg.GetCompanyByID(ctx, *companyID)
+		if err != nil {
+			return nil, false, fmt.Errorf("get company by id: %w", err)
+		}
+
+		direct = company.UserID == user.ID
+	}
+
+	if direct {
+		return user, true, hs.checkAPIKeyCompany(ctx, companyID)
+	}
+
+	if !permissions.Has(models.PermissionEditSuggest) {
+		return nil, false, errs.New(errs.CodeAccessDenied)
+	}
+
+	return user, false, nil
+}
+
+// suggestEdit сохраняет правку сущности как предложенное изменение и отвечает кодом 202.
+func (hs *handlerService) suggestEdit(ctx *gin.Context, user *models.User, entityType string, entityID uuid.UUID, changes any, comment string) error {
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("marshal suggested changes: %w", err)
+	}
+
+	suggestion, err := hs.pg.NewEditSuggestion(ctx, models.EditSuggestion{
+		EntityType: entityType,
+		EntityID:   entityID,
+		UserID:     user.ID,
+		Changes:    changesJSON,
+		Comment:    comment,
+	})
+	if err != nil {
+		return fmt.Errorf("new edit suggestion: %w", err)
+	}
+
+	ctx.JSON(http.StatusAccepted, models.NewResponse(suggestion))
+
+	return nil
+}
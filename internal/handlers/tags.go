@@ -0,0 +1,401 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/lib/pq"
+)
+
+var tagSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// GetTags
+// @Summary Получить справочник тегов
+// @Description Возвращает все теги мест и событий: сначала категории верхнего уровня, затем дочерние теги.
+// @ID get-tags
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Success 200 {object} []models.Tag
+// @Failure 500 {object} models.ErrorResponse
+// @Router /tags [get]
+func (hs *handlerService) GetTags(ctx *gin.Context) error {
+	tags, err := hs.pg.GetTags(ctx)
+	if err != nil {
+		return fmt.Errorf("get tags: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(tags))
+
+	return nil
+}
+
+// GetTag
+// @Summary Получить тег
+// @Description Возвращает тег по ID, slug или прежнему slug тега.
+// @ID get-tag
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param tagId path string true "Уникальный идентификатор (в формате UUID) или slug тега"
+// @Success 200 {object} models.Tag
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /tags/{tagId} [get]
+func (hs *handlerService) GetTag(ctx *gin.Context) error {
+	tag, err := hs.tag(ctx, ctx.Param("tagId"))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(tag))
+
+	return nil
+}
+
+// NewTag
+// @Summary Добавить тег
+// @Description Добавляет тег в справочник. Тег с parent_id входит в категорию верхнего уровня.
+// @ID create-tag
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param slug body string true "Slug: строчные латинские буквы, цифры и дефисы"
+// @Param labels body object true "Названия по кодам языков, например {\"ru\": \"Музей\", \"en\": \"Museum\"}"
+// @Param icon body string false "Иконка (ссылка или идентификатор загруженного изображения)"
+// @Param parent_id body string false "Категория верхнего уровня (в формате UUID)"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /tags [post]
+func (hs *handlerService) NewTag(ctx *gin.Context) error {
+	var params struct {
+		Slug     string            `json:"slug" binding:"required,max=64"`
+		Labels   map[string]string `json:"labels" binding:"required,min=1,dive,keys,len=2,endkeys,required,max=64"`
+		Icon     string            `json:"icon" binding:"omitempty,url|uuid"`
+		ParentID string            `json:"parent_id" binding:"omitempty,uuid"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	if err := hs.checkTagSlug(params.Slug); err != nil {
+		return err
+	}
+
+	tag := models.Tag{
+		Slug:   params.Slug,
+		Labels: params.Labels,
+	}
+
+	if params.Icon != "" {
+		icon, err := hs.resolveImageRefs(ctx, params.Icon)
+		if err != nil {
+			return err
+		}
+
+		tag.Icon = icon[0]
+	}
+
+	if params.ParentID != "" {
+		parentID, _ := uuid.Parse(params.ParentID)
+		if err := hs.checkTagParent(ctx, uuid.Nil, parentID); err != nil {
+			return err
+		}
+
+		tag.ParentID = &parentID
+	}
+
+	created, err := hs.pg.NewTag(ctx, tag)
+	if err != nil {
+		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
+			return errs.New(errs.CodeTagAlreadyExists)
+		}
+
+		return fmt.Errorf("new tag: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(created))
+
+	return nil
+}
+
+// EditTag
+// @Summary Редактировать тег
+// @Description Переименовывает тег или меняет его названия, иконку и категорию. Прежний slug остается синонимом тега,
+// @Description поэтому старые ссылки и фильтры по нему продолжают работать.
+// @ID edit-tag
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param tagId path string true "Уникальный идентификатор тега (в формате UUID)"
+// @Param slug body string false "Новый slug"
+// @Param labels body object false "Названия по кодам языков, переданные языки заменяют текущие"
+// @Param icon body string false "Иконка (ссылка или идентификатор загруженного изображения)"
+// @Param parent_id body string false "Категория верхнего уровня (в формате UUID)"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /tags/{tagId} [patch]
+func (hs *handlerService) EditTag(ctx *gin.Context) error {
+	tag, err := hs.tagParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		Slug     string            `json:"slug" binding:"max=64"`
+		Labels   map[string]string `json:"labels" binding:"omitempty,dive,keys,len=2,endkeys,required,max=64"`
+		Icon     string            `json:"icon" binding:"omitempty,url|uuid"`
+		ParentID string            `json:"parent_id" binding:"omitempty,uuid"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	previousSlug := tag.Slug
+	if params.Slug != "" {
+		if err := hs.checkTagSlug(params.Slug); err != nil {
+			return err
+		}
+
+		tag.Slug = params.Slug
+	}
+	for lang, label := range params.Labels {
+		tag.Labels[lang] = label
+	}
+	if params.Icon != "" {
+		icon, err := hs.resolveImageRefs(ctx, params.Icon)
+		if err != nil {
+			return err
+		}
+
+		tag.Icon = icon[0]
+	}
+	if params.ParentID != "" {
+		parentID, _ := uuid.Parse(params.ParentID)
+		if err := hs.checkTagParent(ctx, tag.ID, parentID); err != nil {
+			return err
+		}
+
+		tag.ParentID = &parentID
+	}
+
+	if err := hs.pg.SaveTag(ctx, tag, previousSlug); err != nil {
+		if hs.pg.IsError(pgerrcode.IsIntegrityConstraintViolation, err) {
+			return errs.New(errs.CodeTagAlreadyExists)
+		}
+
+		return fmt.Errorf("save tag: %w", err)
+	}
+
+	saved, err := hs.tag(ctx, tag.ID.String())
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(saved))
+
+	return nil
+}
+
+// MergeTag
+// @Summary Объединить теги
+// @Description Переносит места, события, дочерние теги и синонимы тега в тег target_id и удаляет исходный тег.
+// @Description Slug исходного тега становится синонимом target_id.
+// @ID merge-tag
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param tagId path string true "Уникальный идентификатор объединяемого тега (в формате UUID)"
+// @Param target_id body string true "Тег, в который объединяется тег (в формате UUID)"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /tags/{tagId}/merge [post]
+func (hs *handlerService) MergeTag(ctx *gin.Context) error {
+	source, err := hs.tagParam(ctx)
+	if err != nil {
+		return err
+	}
+
+	var params struct {
+		TargetID string `json:"target_id" binding:"required,uuid"`
+	}
+
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
+	}
+
+	targetID, _ := uuid.Parse(params.TargetID)
+	if targetID == source.ID {
+		return errs.New(errs.CodeTagMergeSelf)
+	}
+
+	target, err := hs.tag(ctx, targetID.String())
+	if err != nil {
+		return err
+	}
+
+	// Дочерние теги исходного тега переходят к target_id, поэтому он должен остаться категорией верхнего уровня
+	if target.ParentID != nil && *target.ParentID != source.ID {
+		hasChildren, err := hs.pg.HasChildTags(ctx, source.ID)
+		if err != nil {
+			return fmt.Errorf("check child tags: %w", err)
+		}
+
+		if hasChildren {
+			return errs.New(errs.CodeTagMergeNotTopLevel)
+		}
+	}
+
+	if err := hs.pg.MergeTags(ctx, source.ID, target.ID); err != nil {
+		return errs.NotFound(err, errs.CodeTagNotFound)
+	}
+
+	merged, err := hs.tag(ctx, target.ID.String())
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(merged))
+
+	return nil
+}
+
+func (hs *handlerService) tagParam(ctx *gin.Context) (*models.Tag, error) {
+	if _, err := hs.uuidParam(ctx, "tagId"); err != nil {
+		return nil, err
+	}
+
+	return hs.tag(ctx, ctx.Param("tagId"))
+}
+
+// tag ищет тег по ID, если ref - UUID, иначе по текущему или прежнему slug.
+func (hs *handlerService) tag(ctx *gin.Context, ref string) (*models.Tag, error) {
+	var (
+		tag *models.Tag
+		err error
+	)
+
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		tag, err = hs.pg.GetTag(ctx, id)
+	} else {
+		tag, err = hs.pg.GetTagBySlug(ctx, ref)
+	}
+
+	if err != nil {
+		return nil, errs.NotFound(err, errs.CodeTagNotFound)
+	}
+
+	return tag, nil
+}
+
+func (hs *handlerService) checkTagSlug(slug string) error {
+	if !tagSlugPattern.MatchString(slug) {
+		return errs.New(errs.CodeTagSlugInvalid)
+	}
+
+	return nil
+}
+
+// checkTagParent проверяет, что родителем тега tagID может быть parentID: это существующая категория верхнего
+// уровня, а не сам тег, и у тега нет своих дочерних тегов. Теги образуют только два уровня, на этом основан фильтр списков.
+func (hs *handlerService) checkTagParent(ctx *gin.Context, tagID, parentID uuid.UUID) error {
+	parent, err := hs.pg.GetTag(ctx, parentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("get tag: %w", err)
+	}
+
+	if err != nil || parent.ParentID != nil || parentID == tagID {
+		return errs.New(errs.CodeTagParentInvalid)
+	}
+
+	if tagID != uuid.Nil {
+		hasChildren, err := hs.pg.HasChildTags(ctx, tagID)
+		if err != nil {
+			return fmt.Errorf("check child tags: %w", err)
+		}
+
+		if hasChildren {
+			return errs.New(errs.CodeTagCategoryWithParent)
+		}
+	}
+
+	return nil
+}
+
+// tagIDs проверяет, что все теги из ids существуют, и возвращает их без повторов.
+func (hs *handlerService) tagIDs(ctx *gin.Context, ids []string) (pq.StringArray, error) {
+	tagIDs := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		tagID, _ := uuid.Parse(id)
+		if !seen[tagID] {
+			seen[tagID] = true
+			tagIDs = append(tagIDs, tagID)
+		}
+	}
+
+	count, err := hs.pg.CountTags(ctx, tagIDs)
+	if err != nil {
+		return nil, fmt.Errorf("count tags: %w", err)
+	}
+
+	if count != len(tagIDs) {
+		return nil, errs.New(errs.CodeTagUnknown)
+	}
+
+	result := make(pq.StringArray, len(tagIDs))
+	for i, tagID := range tagIDs {
+		result[i] = tagID.String()
+	}
+
+	return result, nil
+}
+
+// listFilter разбирает необязательные фильтры city_id и tag и порядок sort списков мест, событий и маршрутов.
+func (hs *handlerService) listFilter(ctx *gin.Context) (models.ListFilter, error) {
+	var params struct {
+		Tag  string `form:"tag" binding:"omitempty,max=64"`
+		Sort string `form:"sort" binding:"omitempty,oneof=trending rating"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return models.ListFilter{}, err
+	}
+
+	cityID, err := hs.cityID(ctx)
+	if err != nil {
+		return models.ListFilter{}, err
+	}
+
+	filter := models.ListFilter{CityID: cityID, Sort: params.Sort}
+	if params.Tag != "" {
+		tag, err := hs.tag(ctx, params.Tag)
+		if err != nil {
+			return models.ListFilter{}, err
+		}
+
+		filter.TagID = &tag.ID
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetTrending
+// @Summary Популярное сейчас
+// @Description Возвращает места, события и маршруты, популярные в последнее время: учитываются просмотры, закладки,
+// @Description отметки "пойду", посещения и отзывы, причем недавние весят больше. Популярность пересчитывается периодически.
+// @ID get-trending
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param Accept-Language header string false "Языки названий и описаний (например, en-US,en;q=0.9). Язык интерфейса VK из параметров запуска важнее"
+// @Param type query string false "Тип объектов (place, event, route), по умолчанию все"
+// @Param city_id query string false "Город или регион (в формате UUID), для региона учитываются и его города"
+// @Param limit query integer false "Количество объектов (по умолчанию 20, максимум 100)"
+// @Success 200 {object} []models.TrendingItem
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /trending [get]
+func (hs *handlerService) GetTrending(ctx *gin.Context) error {
+	var params struct {
+		Type  string `form:"type" binding:"omitempty,oneof=place event route"`
+		Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	}
+
+	if err := hs.validateAndShouldBindQuery(ctx, &params); err != nil {
+		return err
+	}
+	if params.Limit == 0 {
+		params.Limit = 20
+	}
+
+	cityID, err := hs.cityID(ctx)
+	if err != nil {
+		return err
+	}
+
+	items, err := hs.pg.GetTrending(ctx, params.Type, cityID, params.Limit)
+	if err != nil {
+		return fmt.Errorf("get trending: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(hs.localized(ctx, items)))
+
+	return nil
+}
+
+// NewRSVPEvent
+// @Summary Пойду на событие
+// @Description Отмечает, что текущий пользователь пойдет на событие. Повторная отметка ничего не меняет.
+// @ID create-rsvp-event
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/{eventId}/rsvp [post]
+func (hs *handlerService) NewRSVPEvent(ctx *gin.Context) error {
+	eventID, err := hs.uuidParam(ctx, "eventId")
+	if err != nil {
+		return err
+	}
+
+	if _, err := hs.entityExists(ctx, entityRefParams{EntityType: models.EntityTypeEvent, EntityID: eventID.String()}); err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := hs.pg.NewRSVP(ctx, user.ID, eventID); err != nil {
+		return fmt.Errorf("new rsvp: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// DeleteRSVPEvent
+// @Summary Не пойду на событие
+// @Description Снимает отметку текущего пользователя о том, что он пойдет на событие.
+// @ID delete-rsvp-event
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param eventId path string true "Уникальный идентификатор события (в формате UUID)"
+// @Success 200 {object} bool
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /events/{eventId}/rsvp [delete]
+func (hs *handlerService) DeleteRSVPEvent(ctx *gin.Context) error {
+	eventID, err := hs.uuidParam(ctx, "eventId")
+	if err != nil {
+		return err
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := hs.pg.DeleteRSVP(ctx, user.ID, eventID)
+	if err != nil {
+		return fmt.Errorf("delete rsvp: %w", err)
+	}
+
+	if !deleted {
+		return errs.New(errs.CodeRSVPNotFound)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(true))
+
+	return nil
+}
+
+// recordView учитывает просмотр объекта в популярности не чаще раза в день для пользователя,
+// а без пользователя - для IP. Ошибка не прерывает запрос.
+func (hs *handlerService) recordView(ctx *gin.Context, entityType string, entityID uuid.UUID) {
+	viewer := "ip:" + ctx.ClientIP()
+	if user := auth.GetUser(ctx); user != nil {
+		viewer = "user:" + user.ID.String()
+	}
+
+	if err := hs.pg.RecordView(ctx, entityType, entityID, viewer); err != nil {
+		hs.logger.Error("Error record view", zap.Error(err))
+	}
+}
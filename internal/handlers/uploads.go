@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/config"
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/ShpullRequest/backend/pkg/blobstore"
+	"github.com/ShpullRequest/backend/pkg/images"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UploadImage
+// @Summary Загрузить изображение
+// @Description Загружает изображение (JPEG или PNG), удаляет из него метаданные EXIF и создает миниатюру.
+// @Description Идентификатор загруженного изображения можно передавать в поля photos отзывов, а также вместо ссылок в carousel, icon и photo_card.
+// @ID upload-image
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Строка авторизации"
+// @Param file formData file true "Файл изображения"
+// @Success 200 {object} models.Blob
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /uploads [post]
+func (hs *handlerService) UploadImage(ctx *gin.Context) error {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return errs.New(errs.CodeFileNotProvided)
+	}
+
+	if fileHeader.Size > config.Config.UploadMaxSize {
+		return errs.New(errs.CodeFileTooLarge)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fmt.Errorf("open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, config.Config.UploadMaxSize+1))
+	if err != nil {
+		return fmt.Errorf("read uploaded file: %w", err)
+	}
+
+	processed, err := images.Process(data, images.Options{
+		MaxSize:       config.Config.UploadMaxSize,
+		MaxDimension:  config.Config.UploadMaxDimension,
+		ThumbnailSize: config.Config.ThumbnailSize,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, images.ErrTooLarge):
+			return errs.New(errs.CodeFileTooLarge)
+		case errors.Is(err, images.ErrUnsupportedType):
+			return errs.New(errs.CodeImageFormatUnsupported)
+		case errors.Is(err, images.ErrDimensions):
+			return errs.New(errs.CodeImageDimensionsInvalid).WithParam("max", config.Config.UploadMaxDimension)
+		default:
+			return fmt.Errorf("process image: %w", err)
+		}
+	}
+
+	user, err := hs.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := uuid.New().String()
+	key := fmt.Sprintf("images/%s.%s", name, processed.Extension)
+	thumbnailKey := fmt.Sprintf("images/%s_thumb.jpg", name)
+
+	if err = hs.blobs.Put(ctx, key, processed.Data, processed.ContentType); err != nil {
+		return errs.Upstream(fmt.Errorf("put blob: %w", err))
+	}
+
+	if err = hs.blobs.Put(ctx, thumbnailKey, processed.Thumbnail, "image/jpeg"); err != nil {
+		hs.deleteBlobs(ctx, key)
+
+		return errs.Upstream(fmt.Errorf("put thumbnail blob: %w", err))
+	}
+
+	blob, err := hs.pg.NewBlob(ctx, models.Blob{
+		OwnerID:      user.ID,
+		Key:          key,
+		URL:          hs.blobs.URL(key),
+		ThumbnailKey: thumbnailKey,
+		ThumbnailURL: hs.blobs.URL(thumbnailKey),
+		ContentType:  processed.ContentType,
+		Size:         int64(len(processed.Data)),
+		Width:        processed.Width,
+		Height:       processed.Height,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		hs.deleteBlobs(ctx, key, thumbnailKey)
+
+		return fmt.Errorf("new blob: %w", err)
+	}
+
+	ctx.JSON(http.StatusOK, models.NewResponse(blob))
+
+	return nil
+}
+
+// deleteBlobs удаляет из хранилища объекты, которые не удалось записать в базу.
+// Удаление не зависит от отмены запроса, ошибки только логируются: исходная ошибка важнее.
+func (hs *handlerService) deleteBlobs(ctx *gin.Context, keys ...string) {
+	deleteCtx := context.WithoutCancel(ctx.Request.Context())
+
+	for _, key := range keys {
+		if err := hs.blobs.Delete(deleteCtx, key); err != nil {
+			hs.logger.Error("Error delete orphaned blob", zap.String("Key", key), zap.Error(err))
+		}
+	}
+}
+
+// GetBlob
+// @Summary Получить загруженный файл
+// @Description Отдает содержимое загруженного файла или его миниатюры. Авторизация не требуется.
+// @ID get-blob
+// @Produce image/jpeg,image/png
+// @Param key path string true "Ключ файла"
+// @Success 200 {file} file
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /blobs/{key} [get]
+func (hs *handlerService) GetBlob(ctx *gin.Context) error {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	blob, err := hs.pg.GetBlobByKey(ctx, key)
+	if err != nil {
+		return errs.NotFound(err, errs.CodeFileNotFound)
+	}
+
+	reader, err := hs.blobs.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return errs.New(errs.CodeFileNotFound)
+		}
+
+		return errs.Upstream(fmt.Errorf("open blob: %w", err))
+	}
+	defer reader.Close()
+
+	contentType := blob.ContentType
+	if key == blob.ThumbnailKey {
+		contentType = "image/jpeg"
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.DataFromReader(http.StatusOK, -1, contentType, reader, nil)
+
+	return nil
+}
+
+// resolveImageRefs заменяет идентификаторы загруженных изображений на их ссылки.
+// Значения, не являющиеся UUID, считаются обычными ссылками и возвращаются как есть.
+func (hs *handlerService) resolveImageRefs(ctx *gin.Context, refs ...string) ([]string, error) {
+	resolved := make([]string, len(refs))
+	for i, ref := range refs {
+		blobID, err := uuid.Parse(ref)
+		if err != nil {
+			resolved[i] = ref
+			continue
+		}
+
+		blob, err := hs.pg.GetBlob(ctx, blobID)
+		if err != nil {
+			return nil, errs.NotFound(err, errs.CodeUploadNotFound)
+		}
+
+		resolved[i] = blob.URL
+	}
+
+	return resolved, nil
+}
+
+// checkReviewPhotos проверяет, что фотографии для отзыва загружены текущим пользователем,
+// и возвращает их в переданном порядке.
+func (hs *handlerService) checkReviewPhotos(ctx *gin.Context, userID uuid.UUID, photos []string) ([]models.Blob, error) {
+	if len(photos) > config.Config.ReviewMaxPhotos {
+		return nil, errs.Invalid("photos", "max", strconv.Itoa(config.Config.ReviewMaxPhotos))
+	}
+
+	blobIDs := make([]uuid.UUID, 0, len(photos))
+	seen := make(map[uuid.UUID]bool, len(photos))
+	for _, photo := range photos {
+		blobID, _ := uuid.Parse(photo)
+		if !seen[blobID] {
+			seen[blobID] = true
+			blobIDs = append(blobIDs, blobID)
+		}
+	}
+
+	if len(blobIDs) == 0 {
+		return nil, nil
+	}
+
+	blobs, err := hs.pg.GetBlobsByIDs(ctx, blobIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get blobs by ids: %w", err)
+	}
+
+	blobsByID := make(map[uuid.UUID]models.Blob, len(blobs))
+	for _, blob := range blobs {
+		if blob.OwnerID == userID {
+			blobsByID[blob.ID] = blob
+		}
+	}
+
+	ordered := make([]models.Blob, 0, len(blobIDs))
+	for _, blobID := range blobIDs {
+		blob, ok := blobsByID[blobID]
+		if !ok {
+			return nil, errs.New(errs.CodeUploadNotFound)
+		}
+
+		ordered = append(ordered, blob)
+	}
+
+	return ordered, nil
+}
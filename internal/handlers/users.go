@@ -1,17 +1,16 @@
 package handlers
 
 import (
-	"database/sql"
 	"errors"
 	"fmt"
-	"github.com/ShpullRequest/backend/internal/config"
+	"github.com/ShpullRequest/backend/internal/auth"
 	"github.com/ShpullRequest/backend/internal/errs"
 	"github.com/ShpullRequest/backend/internal/models"
-	"github.com/ShpullRequest/backend/pkg/ip"
-	"github.com/ShpullRequest/backend/pkg/vk/maps"
+	"github.com/ShpullRequest/backend/pkg/geocoder"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"net/http"
+	"sort"
 	"strconv"
 )
 
@@ -26,61 +25,56 @@ import (
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /users [get]
-func (hs *handlerService) GetMe(ctx *gin.Context) {
-	vkParams := hs.GetVKParams(ctx)
-
-	var user *models.User
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
-
+func (hs *handlerService) GetMe(ctx *gin.Context) error {
+	user, err := hs.currentUser(ctx)
 	if err != nil {
-		if !errors.Is(err, sql.ErrNoRows) {
-			hs.logger.Debug("Error get user", zap.Error(err))
-
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-			ctx.Abort()
-
-			return
-		}
-
-		user, err = hs.pg.NewUser(ctx, models.User{
-			VkID:        int64(vkParams.VkUserID),
-			SelectedGeo: "",
-		})
-		if err != nil {
-			hs.logger.Error("Error create user", zap.Error(err))
-
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-			ctx.Abort()
+		return err
+	}
 
-			return
-		}
+	roles, err := hs.pg.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("get user roles: %w", err)
 	}
 
-	var geoText string
-	currentGeo, err := ip.GetGeoByIP(ctx.ClientIP())
+	permissions := make([]string, 0)
+	for permission := range models.NewPermissions(roles) {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
 
+	selectedLocality, err := hs.locality(ctx, user.SelectedLocalityID)
+	if err != nil {
+		return err
+	}
+	homeCity, err := hs.locality(ctx, user.HomeCityID)
 	if err != nil {
-		hs.logger.Error("Error get user geo", zap.Error(err))
+		return err
 	}
 
-	if user.SelectedGeo != "" || currentGeo != "" {
-		geo := currentGeo
-		if user.SelectedGeo != "" {
-			geo = user.SelectedGeo
-		}
+	var geoText string
+	currentGeo := hs.locateClient(ctx)
 
-		geoText, err = maps.New(config.Config).GetAddressByGeoQ(geo)
+	if geo := defaultMapCenter(user, nil, currentGeo); geo != nil {
+		address, err := hs.geocoder.GetAddressByGeo(ctx, geo.Lng, geo.Lat)
 		if err != nil {
 			hs.logger.Error("Error get user geo address (vk maps api)", zap.Error(err))
+		} else {
+			geoText = address.Text
 		}
 	}
 
 	ctx.JSON(http.StatusOK, models.NewResponse(models.UserGetMeResponse{
-		User:       user,
-		CurrentGeo: currentGeo,
-		GeoText:    geoText,
+		User:             user,
+		SelectedLocality: selectedLocality,
+		HomeCity:         homeCity,
+		CurrentGeo:       currentGeo,
+		MapCenter:        defaultMapCenter(user, homeCity, currentGeo),
+		GeoText:          geoText,
+		Roles:            roles,
+		Permissions:      permissions,
 	}))
-	ctx.Abort()
+
+	return nil
 }
 
 // GetUserByVkID
@@ -93,37 +87,28 @@ func (hs *handlerService) GetMe(ctx *gin.Context) {
 // @Param vkId path string true "Уникальный идентификатор пользователя в VK"
 // @Success 200 {object} models.User
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /users/{vkId} [get]
-func (hs *handlerService) GetUserByVkID(ctx *gin.Context) {
+func (hs *handlerService) GetUserByVkID(ctx *gin.Context) error {
 	var params struct {
 		VkID string `uri:"vkId" binding:"required,numeric"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindURI(ctx, &params); err != nil {
+		return err
 	}
 
 	vkID, _ := strconv.Atoi(params.VkID)
 
 	user, err := hs.pg.GetUserByVkID(ctx, int64(vkID))
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
-
-		return
+	if err != nil {
+		return errs.NotFound(err, errs.CodeUserNotFound)
 	}
 
-	if !user.IsNil() {
-		ctx.JSON(http.StatusOK, models.NewResponse(user))
-	} else {
-		ctx.JSON(http.StatusOK, models.NewResponse(nil))
-	}
+	ctx.JSON(http.StatusOK, models.NewResponse(user))
 
-	ctx.Abort()
+	return nil
 }
 
 // EditUser
@@ -134,55 +119,80 @@ func (hs *handlerService) GetUserByVkID(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Строка авторизации"
 // @Param passed_onboarding body bool false "Пройдено обучение (опционально)"
-// @Param selected_geo_lat body float64 false "Широта выбранного местоположения"
-// @Param selected_geo_lot body float64 false "Долгота выбранного местоположения"
+// @Param selected_geo body models.GeoPoint false "Выбранная на карте точка, по ней определяется город или регион"
+// @Param home_city body string false "Название домашнего города (минимум 2 символа)"
 // @Success 200 {object} models.User
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 502 {object} models.ErrorResponse
 // @Router /users [patch]
-func (hs *handlerService) EditUser(ctx *gin.Context) {
+func (hs *handlerService) EditUser(ctx *gin.Context) error {
 	var params struct {
-		PassedOnboarding bool    `json:"passed_onboarding,omitempty"`
-		SelectedGeoLat   float64 `json:"selected_geo_lat" binding:"latitude"`
-		SelectedGeoLot   float64 `json:"selected_geo_lot" binding:"longitude"`
+		PassedOnboarding bool             `json:"passed_onboarding,omitempty"`
+		SelectedGeo      *models.GeoPoint `json:"selected_geo"`
+		HomeCity         string           `json:"home_city" binding:"omitempty,min=2,max=255"`
 	}
 
-	if response, statusCode, err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
-		ctx.JSON(statusCode, response)
-		ctx.Abort()
-
-		return
+	if err := hs.validateAndShouldBindJSON(ctx, &params); err != nil {
+		return err
 	}
-	vkParams := hs.GetVKParams(ctx)
 
-	user, err := hs.pg.GetUserByVkID(ctx, int64(vkParams.VkUserID))
+	user, err := hs.currentUser(ctx)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			ctx.JSON(http.StatusBadRequest, models.NewErrorResponse(errs.NewBadRequest("User not registered")))
-		} else {
-			ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		}
-
-		ctx.Abort()
-		return
+		return err
 	}
 
-	if !user.PassedOnboarding && params.PassedOnboarding {
-		user.PassedOnboarding = true
+	changes := models.UserChanges{
+		PassedOnboarding: params.PassedOnboarding,
+		SelectedGeo:      params.SelectedGeo,
 	}
-	if params.SelectedGeoLat != 0 && params.SelectedGeoLot != 0 {
-		user.SelectedGeo = fmt.Sprintf("%f, %f", params.SelectedGeoLat, params.SelectedGeoLot)
+	if params.SelectedGeo != nil {
+		// Точку можно выбрать и без города: если геокодер недоступен, город определится при следующем выборе
+		address, err := hs.geocoder.GetAddressByGeo(ctx, params.SelectedGeo.Lng, params.SelectedGeo.Lat)
+		if err != nil {
+			if !errors.Is(err, geocoder.ErrNoAddress) {
+				hs.logger.Error("Error get selected geo address", zap.Error(err))
+			}
+		} else if locality, ok := models.NewLocality(address.Address, *params.SelectedGeo); ok {
+			selectedLocality, err := hs.saveLocality(ctx, locality)
+			if err != nil {
+				return err
+			}
+
+			changes.SelectedLocalityID = &selectedLocality.ID
+		}
 	}
+	if params.HomeCity != "" {
+		candidates, err := hs.geocoder.Suggest(ctx, params.HomeCity, 1)
+		if err != nil {
+			return errs.New(errs.CodeVKMapsUnavailable).Wrap(err)
+		}
+
+		var locality models.Locality
+		found := len(candidates) != 0
+		if found {
+			locality, found = models.NewLocality(candidates[0].Address, models.GeoPoint{Lat: candidates[0].Lat, Lng: candidates[0].Lng})
+		}
+		if !found || locality.Kind != models.LocalityKindCity {
+			return errs.New(errs.CodeCityNotFound)
+		}
 
-	if err = hs.pg.SaveUser(ctx, user); err != nil {
-		hs.logger.Error("Error save user", zap.Error(err))
+		homeCity, err := hs.saveLocality(ctx, locality)
+		if err != nil {
+			return err
+		}
 
-		ctx.JSON(http.StatusInternalServerError, models.NewErrorResponse(errs.NewInternalServer("Internal server error")))
-		ctx.Abort()
+		changes.HomeCityID = &homeCity.ID
+	}
 
-		return
+	user, err = hs.pg.UpdateUser(ctx, user.ID, changes)
+	if err != nil {
+		return fmt.Errorf("update user: %w", err)
 	}
+	auth.UserChanged(ctx)
 
 	ctx.JSON(http.StatusOK, models.NewResponse(user))
-	ctx.Abort()
+
+	return nil
 }
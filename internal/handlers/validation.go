@@ -3,87 +3,110 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"github.com/ShpullRequest/backend/internal/errs"
-	"github.com/ShpullRequest/backend/internal/models"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"io"
-	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
-func (hs *handlerService) validateAndShouldBindURI(ctx *gin.Context, obj any) (*models.ErrorResponse, int, error) {
+func (hs *handlerService) validateAndShouldBindURI(ctx *gin.Context, obj any) error {
 	if err := ctx.ShouldBindUri(obj); err != nil {
 		if errors.Is(err, strconv.ErrSyntax) {
-			return models.NewErrorResponse(errs.NewBadRequest("Invalid type uri variable")), http.StatusBadRequest, err
+			return errs.New(errs.CodeInvalidURI).Wrap(err)
 		}
 
 		return hs.parseShouldBindErrors(err)
 	}
 
-	return nil, 0, nil
+	return nil
 }
 
-func (hs *handlerService) validateAndShouldBindJSON(ctx *gin.Context, obj any) (*models.ErrorResponse, int, error) {
+func (hs *handlerService) validateAndShouldBindQuery(ctx *gin.Context, obj any) error {
+	if err := ctx.ShouldBindQuery(obj); err != nil {
+		if errors.Is(err, strconv.ErrSyntax) {
+			return errs.New(errs.CodeInvalidQuery).Wrap(err)
+		}
+
+		return hs.parseShouldBindErrors(err)
+	}
+
+	return nil
+}
+
+func (hs *handlerService) validateAndShouldBindJSON(ctx *gin.Context, obj any) error {
 	if err := ctx.ShouldBindJSON(obj); err != nil {
 		if errors.Is(err, io.EOF) {
-			return models.NewErrorResponse(errs.NewBadRequest("Request body not provided")), http.StatusBadRequest, err
+			return errs.New(errs.CodeRequestBodyMissing).Wrap(err)
 		}
 
 		var jsonTypeError *json.UnmarshalTypeError
 		if ok := errors.As(err, &jsonTypeError); ok {
-			return models.NewErrorResponse(
-					fmt.Sprintf("Field value \"%s\" must be %s", jsonTypeError.Field, jsonTypeError.Type),
-				),
-				http.StatusBadRequest, err
+			return errs.Invalid(jsonTypeError.Field, "type", jsonTypeError.Type.String()).Wrap(err)
 		}
 
 		var jsonError *json.SyntaxError
 		if ok := errors.As(err, &jsonError); ok {
-			return models.NewErrorResponse(errs.NewBadRequest("Invalid json object")), http.StatusBadRequest, err
+			return errs.New(errs.CodeInvalidJSON).Wrap(err)
 		}
 
 		return hs.parseShouldBindErrors(err)
 	}
 
-	return nil, 0, nil
+	return nil
 }
 
-func (hs *handlerService) parseShouldBindErrors(err error) (*models.ErrorResponse, int, error) {
-	if ok, errResponse := hs.parseValidationErrors(err); ok {
-		return errResponse, http.StatusBadRequest, err
+func (hs *handlerService) parseShouldBindErrors(err error) error {
+	if details := hs.parseValidationErrors(err); len(details) > 0 {
+		return errs.New(errs.CodeValidationFailed).WithDetails(details...).Wrap(err)
 	}
 
+	return errs.New(errs.CodeInvalidRequest).WithParam("error", err.Error()).Wrap(err)
+}
+
+// parseValidationErrors возвращает все поля, не прошедшие проверку, в том числе у всех элементов массива
+func (hs *handlerService) parseValidationErrors(err error) []errs.FieldError {
 	var sliceValidationErrors binding.SliceValidationError
-	if ok := errors.As(err, &sliceValidationErrors); ok && len(sliceValidationErrors) > 0 {
-		if ok, errResponse := hs.parseValidationErrors(sliceValidationErrors[0]); ok {
-			return errResponse, http.StatusBadRequest, err
+	if ok := errors.As(err, &sliceValidationErrors); ok {
+		var details []errs.FieldError
+		for _, elemErr := range sliceValidationErrors {
+			details = append(details, hs.parseValidationErrors(elemErr)...)
 		}
+
+		return details
 	}
 
-	return models.NewErrorResponse(errs.NewBadRequest(fmt.Sprint(err))), http.StatusBadRequest, err
+	var validationErrors validator.ValidationErrors
+	if ok := errors.As(err, &validationErrors); !ok {
+		return nil
+	}
+
+	details := make([]errs.FieldError, 0, len(validationErrors))
+	for _, fErr := range validationErrors {
+		details = append(details, errs.FieldError{
+			Field: fErr.Field(),
+			Rule:  fErr.Tag(),
+			Param: fErr.Param(),
+		})
+	}
+
+	return details
 }
 
-func (hs *handlerService) parseValidationErrors(err error) (bool, *models.ErrorResponse) {
-	var validationErrors validator.ValidationErrors
-	if ok := errors.As(err, &validationErrors); ok && len(validationErrors) > 0 {
-		fErr := validationErrors[0]
-
-		var errResponse string
-		if fErr.Param() == "" {
-			errResponse = fErr.Tag()
-		} else {
-			errResponse = fmt.Sprintf("%s=%s", fErr.Tag(), fErr.Param())
+// fieldName возвращает имя поля из тега json, form или uri, чтобы в ошибках проверки были имена из запроса
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
 		}
-
-		return true, models.NewErrorResponse(
-			errs.NewBadRequest(
-				fmt.Sprintf("Field validation for \"%s\" failed on the '%s' tag.", fErr.Field(), errResponse),
-			),
-		)
 	}
 
-	return false, nil
+	return field.Name
 }
@@ -0,0 +1,167 @@
+package i18n
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultLanguage язык исходных названий и описаний. Переводов на него нет, поэтому цепочка языков на нем заканчивается
+const DefaultLanguage = "ru"
+
+// Languages языки ответа в порядке предпочтения вместе с запасными языками
+type Languages []string
+
+// Localizer сущность с переводами текстовых полей
+type Localizer interface {
+	Localize(languages []string)
+}
+
+// vkLanguages коды языков из vk_language, отличающиеся от ISO 639-1
+var vkLanguages = map[string]string{
+	"ua": "uk",
+	"kz": "kk",
+}
+
+// fallbacks запасные языки: тем, кто читает по-украински, по-белорусски или по-казахски, понятнее исходный
+// русский текст, остальным - английский перевод
+var fallbacks = map[string][]string{
+	"uk": {DefaultLanguage},
+	"be": {DefaultLanguage},
+	"kk": {DefaultLanguage},
+}
+
+const defaultFallback = "en"
+
+// Negotiate составляет цепочку языков: язык интерфейса VK (vk_language), затем языки из Accept-Language по убыванию q,
+// после каждого - его запасные языки. Цепочка заканчивается английским и языком по умолчанию.
+// Если язык не указан, цепочка пустая: тексты остаются исходными, а сообщения об ошибках - на английском.
+func Negotiate(acceptLanguage, vkLanguage string) Languages {
+	preferred := make([]string, 0)
+	if vkLanguage != "" {
+		if language, ok := vkLanguages[vkLanguage]; ok {
+			vkLanguage = language
+		}
+		preferred = append(preferred, vkLanguage)
+	}
+	preferred = append(preferred, parseAcceptLanguage(acceptLanguage)...)
+	if len(preferred) == 0 {
+		return Languages{}
+	}
+	preferred = append(preferred, defaultFallback)
+
+	languages := make(Languages, 0, len(preferred)+1)
+	seen := make(map[string]bool, len(preferred)+1)
+	add := func(language string) bool {
+		if !seen[language] {
+			seen[language] = true
+			languages = append(languages, language)
+		}
+
+		return language == DefaultLanguage
+	}
+
+	for _, language := range preferred {
+		if add(language) {
+			return languages
+		}
+		for _, fallback := range fallbacks[language] {
+			if add(fallback) {
+				return languages
+			}
+		}
+	}
+	add(DefaultLanguage)
+
+	return languages
+}
+
+// parseAcceptLanguage возвращает основные коды языков из заголовка Accept-Language по убыванию q.
+// Языки с q=0 и "*" пропускаются.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		language string
+		q        float64
+	}
+
+	ranges := make([]weighted, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if len(language) != 2 {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, weighted{language: language, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	languages := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		languages = append(languages, r.language)
+	}
+
+	return languages
+}
+
+// FromContext возвращает языки ответа на запрос. vk_language известен только после авторизации
+// по параметрам запуска VK, до нее учитывается только Accept-Language.
+func FromContext(ctx *gin.Context) Languages {
+	var vkLanguage string
+	if principal := auth.GetPrincipal(ctx); principal != nil {
+		vkLanguage = principal.Language
+	}
+
+	return Negotiate(ctx.GetHeader("Accept-Language"), vkLanguage)
+}
+
+// Localize переводит названия и описания всех сущностей в v, включая вложенные в структуры, срезы и интерфейсы.
+// v должен быть указателем или срезом, иначе переводить нечего.
+func Localize(v any, languages Languages) {
+	localize(reflect.ValueOf(v), languages)
+}
+
+func localize(v reflect.Value, languages Languages) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			localize(v.Elem(), languages)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			localize(v.Index(i), languages)
+		}
+	case reflect.Struct:
+		// Переводится только значение, доступное по указателю, остальные изменения потерялись бы в копии
+		if v.CanAddr() {
+			if localizer, ok := v.Addr().Interface().(Localizer); ok {
+				localizer.Localize(languages)
+			}
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				localize(v.Field(i), languages)
+			}
+		}
+	}
+}
@@ -1,72 +1,74 @@
 package middlewares
 
 import (
-	"github.com/SevereCloud/vksdk/v2/vkapps"
-	"github.com/ShpullRequest/backend/internal/config"
+	"errors"
+	"expvar"
+	"github.com/ShpullRequest/backend/internal/auth"
 	"github.com/ShpullRequest/backend/internal/errs"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
-	"net/http"
-	"net/url"
-	"strconv"
 	"strings"
-	"time"
 )
 
+// authorizationOutcomes счетчики результатов авторизации, доступные через expvar
+var authorizationOutcomes = expvar.NewMap("authorization")
+
 func (ms *middlewareService) Authorization(ctx *gin.Context) {
-	if strings.HasPrefix(ctx.Request.RequestURI, "/swagger/") {
+	// Документация и загруженные файлы (открываются через <img>) доступны без авторизации,
+	// обновление сессии авторизуется самим токеном обновления
+	if strings.HasPrefix(ctx.Request.RequestURI, "/swagger/") ||
+		strings.HasPrefix(ctx.Request.RequestURI, "/blobs/") ||
+		strings.HasPrefix(ctx.Request.RequestURI, "/auth/refresh/") {
 		return
 	}
 
-	authString := ctx.GetHeader("Authorization")
-	authString = strings.ReplaceAll(authString, "Bearer ", "")
+	// Адрес клиента определяет gin с учетом доверенных прокси, аутентификаторы не читают X-Forwarded-For сами
+	request := ctx.Request.WithContext(auth.WithClientIP(ctx.Request.Context(), ctx.ClientIP()))
 
-	ok, err := vkapps.ParamsVerify(authString, config.Config.AppSecretToken)
+	principal, err := ms.authenticator.Authenticate(request)
 	if err != nil {
-		ms.logger.Error("Failed params verify", zap.Error(err))
-
-		ctx.JSON(http.StatusInternalServerError, errs.NewInternalServer("An unknown error occurred while checking authorization"))
+		switch {
+		case errors.Is(err, auth.ErrFingerprintMismatch):
+			authorizationOutcomes.Add("fingerprint_mismatch", 1)
+			ms.logger.Debug("Authorization failed, signature is bound to another client", zap.Error(err))
+			_ = ctx.Error(errs.New(errs.CodeUnauthorized))
+		case errors.Is(err, auth.ErrExpired):
+			authorizationOutcomes.Add("expired", 1)
+			ms.logger.Debug("Authorization failed, credentials expired", zap.Error(err))
+			_ = ctx.Error(errs.New(errs.CodeCredentialsExpired))
+		case errors.Is(err, auth.ErrRevoked):
+			authorizationOutcomes.Add("revoked", 1)
+			ms.logger.Debug("Authorization failed, credentials revoked", zap.Error(err))
+			_ = ctx.Error(errs.New(errs.CodeCredentialsRevoked))
+		case errors.Is(err, auth.ErrQuotaExceeded):
+			authorizationOutcomes.Add("quota_exceeded", 1)
+			ms.logger.Debug("Authorization failed, api key quota exceeded", zap.Error(err))
+			_ = ctx.Error(errs.New(errs.CodeAPIKeyQuotaExceeded))
+		case errors.Is(err, auth.ErrSignatureCacheFull):
+			authorizationOutcomes.Add("signature_cache_full", 1)
+			ms.logger.Warn("Authorization failed, launch params signature cache is full", zap.Error(err))
+			_ = ctx.Error(errs.New(errs.CodeAuthorizationUnavailable))
+		case errors.Is(err, auth.ErrNoCredentials):
+			authorizationOutcomes.Add("no_credentials", 1)
+			ms.logger.Debug("Authorization failed", zap.Error(err))
+			_ = ctx.Error(errs.New(errs.CodeUnauthorized))
+		case errors.Is(err, auth.ErrUnauthorized):
+			authorizationOutcomes.Add("invalid", 1)
+			ms.logger.Debug("Authorization failed", zap.Error(err))
+			_ = ctx.Error(errs.New(errs.CodeUnauthorized))
+		default:
+			authorizationOutcomes.Add("error", 1)
+			ms.logger.Error("Failed authenticate request", zap.Error(err))
+			_ = ctx.Error(errs.New(errs.CodeAuthorizationError))
+		}
 		ctx.Abort()
 
 		return
 	}
 
-	if ok {
-		u, _ := url.Parse(authString)
-		params, _ := vkapps.NewParams(u)
-
-		ts, err := strconv.Atoi(params.VkTs)
-		if err != nil {
-			ms.logger.Error("Failed convert VkTs (string) to int", zap.Error(err))
-
-			ctx.JSON(http.StatusInternalServerError, errs.NewInternalServer("An unknown error occurred while checking authorization"))
-			ctx.Abort()
-
-			return
-		}
-
-		timeCreateSign := time.Unix(int64(ts), 0)
-		sinceCreateSign := time.Since(timeCreateSign)
-
-		if sinceCreateSign.Hours() >= 1 && config.Config.ProdFlag {
-			ms.logger.Debug("Authorization failed, signature expired", zap.Duration("SinceCreateSign", sinceCreateSign))
+	authorizationOutcomes.Add("success_"+principal.Method, 1)
+	ms.logger.Debug("Authorization success", zap.String("Method", principal.Method))
 
-			ctx.JSON(http.StatusUnauthorized, errs.NotAuthorized("Authorization failed, signature expired"))
-			ctx.Abort()
-
-			return
-		}
-
-		ms.logger.Debug("Authorization success", zap.Bool("ParamsVerify", ok))
-
-		ctx.Set("vk_params", params)
-		ctx.Next()
-	} else {
-		ms.logger.Debug("Authorization failed", zap.Bool("ParamsVerify", ok))
-
-		ctx.JSON(http.StatusUnauthorized, errs.NotAuthorized("Authorization failed"))
-		ctx.Abort()
-
-		return
-	}
+	auth.SetPrincipal(ctx, principal)
+	ctx.Next()
 }
@@ -8,6 +8,7 @@ import (
 func (ms *middlewareService) Cors(ctx *gin.Context) {
 	ctx.Header("Access-Control-Allow-Origin", "*")
 	ctx.Header("Access-Control-Allow-Headers", "*")
+	ctx.Header("Access-Control-Expose-Headers", "RateLimit-Policy, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, Retry-After, X-Request-ID")
 
 	if ctx.Request.Method == http.MethodOptions {
 		ctx.Status(http.StatusOK)
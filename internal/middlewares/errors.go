@@ -0,0 +1,110 @@
+package middlewares
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/internal/i18n"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// Recovery отвечает ошибкой на паники в middleware, которые выполняются до Errors, и регистрируется первым.
+// Паники обработчиков перехватывает Errors, чтобы ответ с ошибкой прошел через Logger и Compress.
+func (ms *middlewareService) Recovery(ctx *gin.Context) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			ms.logPanic(ctx, recovered)
+			ms.respondError(ctx)
+		}
+	}()
+
+	ctx.Next()
+}
+
+// Errors отвечает на ошибки, которые вернули обработчики и middleware, и на паники.
+// Сообщение переводится на языки запроса, к ошибке добавляется идентификатор запроса.
+func (ms *middlewareService) Errors(ctx *gin.Context) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			ms.logPanic(ctx, recovered)
+		}
+
+		ms.respondError(ctx)
+	}()
+
+	ctx.Next()
+}
+
+// logPanic пишет панику в лог и прерывает запрос ошибкой.
+func (ms *middlewareService) logPanic(ctx *gin.Context, recovered any) {
+	ms.logger.Error(
+		"Request panic",
+		zap.String("RequestID", ctx.GetString(RequestIDKey)),
+		zap.Any("Panic", recovered),
+		zap.Stack("Stack"),
+	)
+
+	_ = ctx.Error(fmt.Errorf("panic: %v", recovered))
+	ctx.Abort()
+}
+
+// respondError отвечает на последнюю ошибку запроса, если ответ еще не записан.
+func (ms *middlewareService) respondError(ctx *gin.Context) {
+	if len(ctx.Errors) == 0 {
+		return
+	}
+
+	apiErr := ms.apiError(ctx, ctx.Errors.Last().Err)
+	if ctx.Writer.Written() {
+		return
+	}
+
+	apiErr = apiErr.Localize(i18n.FromContext(ctx))
+	apiErr.RequestID = ctx.GetString(RequestIDKey)
+
+	ctx.JSON(apiErr.Status, models.NewErrorResponse(apiErr))
+}
+
+// apiError сопоставляет ошибке ответ API: sql.ErrNoRows - 404, нарушение уникальности - 409,
+// сбой внешнего сервиса - 502, остальные ошибки - 500. Ошибки сервера пишутся в лог.
+func (ms *middlewareService) apiError(ctx *gin.Context, err error) *errs.Error {
+	var apiErr *errs.Error
+	var pgError *pgconn.PgError
+
+	switch {
+	case errors.As(err, &apiErr):
+	case errors.Is(err, sql.ErrNoRows):
+		apiErr = errs.New(errs.CodeNotFound).Wrap(err)
+	case errors.As(err, &pgError) && pgError.Code == pgerrcode.UniqueViolation:
+		apiErr = errs.New(errs.CodeConflict).Wrap(err)
+	case errors.Is(err, errs.ErrUpstream):
+		apiErr = errs.New(errs.CodeUpstreamFailed).Wrap(err)
+	default:
+		apiErr = errs.New(errs.CodeInternal).Wrap(err)
+	}
+
+	if apiErr.Status >= http.StatusInternalServerError {
+		ms.logger.Error(
+			"Request failed",
+			zap.String("RequestID", ctx.GetString(RequestIDKey)),
+			zap.String("Method", ctx.Request.Method),
+			zap.String("Route", ctx.FullPath()),
+			zap.Error(err),
+		)
+	}
+
+	return apiErr
+}
+
+// abort прерывает обработку запроса ошибкой err. Ответ формирует Errors.
+func abort(ctx *gin.Context, err error) {
+	_ = ctx.Error(err)
+	ctx.Abort()
+}
@@ -0,0 +1,9 @@
+package middlewares
+
+import "github.com/gin-gonic/gin"
+
+// Language отмечает, что ответ зависит от языка запроса (vk_language и Accept-Language).
+// Названия и описания сущностей переводят обработчики, сообщения об ошибках - Errors.
+func (ms *middlewareService) Language(ctx *gin.Context) {
+	ctx.Header("Vary", "Accept-Language")
+}
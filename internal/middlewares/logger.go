@@ -21,6 +21,7 @@ func (ms *middlewareService) Logger(ctx *gin.Context) {
 	ms.logger.Info(
 		"Request",
 		zap.Int64("Duration", timeSinceRequest.Milliseconds()),
+		zap.String("RequestID", ctx.GetString(RequestIDKey)),
 		zap.String("Method", method),
 		zap.String("Path", path),
 		zap.Int("StatusCode", statusCode),
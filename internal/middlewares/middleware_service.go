@@ -1,21 +1,70 @@
 package middlewares
 
 import (
+	"context"
+	"strings"
+
 	"github.com/ShpullRequest/backend/internal/api"
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/config"
+	"github.com/ShpullRequest/backend/internal/repository"
+	"github.com/ShpullRequest/backend/pkg/ratelimit"
 	"go.uber.org/zap"
 )
 
 type middlewareService struct {
-	logger *zap.Logger
+	logger        *zap.Logger
+	authenticator auth.Authenticator
+	rateLimits    ratelimit.Store
+	pg            *repository.Pg
+	users         *userCache
 }
 
 func ConfigureService(apiService api.Service) {
+	rateLimits, ok := newRateLimitStore(config.Config.RateLimitStore, apiService.GetPg().TakeRateLimitToken)
+	if !ok {
+		apiService.GetLogger().Panic("Unknown rate limit store", zap.String("Store", config.Config.RateLimitStore))
+	}
+
+	if err := apiService.GetRouter().SetTrustedProxies(trustedProxies(config.Config.TrustedProxies)); err != nil {
+		apiService.GetLogger().Panic("Invalid trusted proxies", zap.Error(err))
+	}
+
+	// Корзины в памяти процесса удаляет само хранилище, таблицу rate_limits нужно чистить отдельно
+	if config.Config.RateLimitStore == "postgres" {
+		go apiService.GetPg().WatchRateLimits(context.Background(), ratelimit.SweepInterval, maxRateLimitPeriod())
+	}
+
 	ms := &middlewareService{
-		logger: apiService.GetLogger(),
+		logger:        apiService.GetLogger(),
+		authenticator: apiService.GetAuthenticator(),
+		rateLimits:    rateLimits,
+		pg:            apiService.GetPg(),
+		users:         newUserCache(config.Config.UserCacheSize, config.Config.UserCacheTTL),
 	}
 
+	apiService.GetRouter().Use(ms.Recovery)
 	apiService.GetRouter().Use(ms.Cors)
+	apiService.GetRouter().Use(ms.RequestID)
 	apiService.GetRouter().Use(ms.Logger)
 	apiService.GetRouter().Use(ms.Compress)
+	apiService.GetRouter().Use(ms.Errors)
+	apiService.GetRouter().Use(ms.Language)
+	apiService.GetRouter().Use(ms.RateLimitIP)
 	apiService.GetRouter().Use(ms.Authorization)
+	apiService.GetRouter().Use(ms.RateLimit)
+	apiService.GetRouter().Use(ms.Users)
+}
+
+// trustedProxies разбирает список адресов и подсетей через запятую. Пустой список означает,
+// что заголовкам X-Forwarded-For и X-Real-IP не доверяют и адресом клиента считается адрес соединения.
+func trustedProxies(value string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
 }
@@ -0,0 +1,132 @@
+package middlewares
+
+import (
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/errs"
+	"github.com/ShpullRequest/backend/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	defaultRateLimitPolicy = ratelimit.Policy{Name: "default", Limit: 120, Period: time.Minute}
+
+	// ipRateLimitPolicy общий бюджет адреса до авторизации, в том числе для запросов, которые ее не пройдут
+	ipRateLimitPolicy = ratelimit.Policy{Name: "ip", Limit: 600, Period: time.Minute}
+
+	// Методы, которые обращаются к ip-api.com и VK Maps
+	geoRateLimitPolicy     = ratelimit.Policy{Name: "geo", Limit: 20, Period: time.Minute}
+	reviewsRateLimitPolicy = ratelimit.Policy{Name: "reviews", Limit: 5, Period: 10 * time.Minute}
+	uploadsRateLimitPolicy = ratelimit.Policy{Name: "uploads", Limit: 10, Period: time.Minute}
+	authRateLimitPolicy    = ratelimit.Policy{Name: "auth", Limit: 10, Period: time.Minute}
+
+	// rateLimitPolicies бюджеты отдельных методов. Методы с одной политикой расходуют общую корзину,
+	// остальные методы расходуют корзину defaultRateLimitPolicy.
+	rateLimitPolicies = map[string]ratelimit.Policy{
+		"GET /users/":                                    geoRateLimitPolicy,
+		"GET /geo/suggest/":                              geoRateLimitPolicy,
+		"POST /places/":                                  geoRateLimitPolicy,
+		"PATCH /places/:placeId":                         geoRateLimitPolicy,
+		"POST /events/":                                  geoRateLimitPolicy,
+		"PATCH /events/:eventId/":                        geoRateLimitPolicy,
+		"POST /places/:placeId/reviews/":                 reviewsRateLimitPolicy,
+		"POST /events/:eventId/reviews/":                 reviewsRateLimitPolicy,
+		"POST /routes/:routeId/reviews/":                 reviewsRateLimitPolicy,
+		"POST /events/:eventId/reviews/:reviewId/reply/": reviewsRateLimitPolicy,
+		"POST /routes/:routeId/reviews/:reviewId/reply/": reviewsRateLimitPolicy,
+		"POST /uploads/":                                 uploadsRateLimitPolicy,
+		"POST /auth/sessions/":                           authRateLimitPolicy,
+		"POST /auth/refresh/":                            authRateLimitPolicy,
+	}
+)
+
+func newRateLimitStore(storeType string, apiStore ratelimit.StoreFunc) (ratelimit.Store, bool) {
+	switch storeType {
+	case "", "memory":
+		return ratelimit.NewMemory(), true
+	case "postgres":
+		return apiStore, true
+	case "none":
+		return nil, true
+	default:
+		return nil, false
+	}
+}
+
+// maxRateLimitPeriod самый долгий период восстановления среди политик: корзина, не менявшаяся дольше, уже полна.
+func maxRateLimitPeriod() time.Duration {
+	maxPeriod := max(defaultRateLimitPolicy.Period, ipRateLimitPolicy.Period)
+	for _, policy := range rateLimitPolicies {
+		maxPeriod = max(maxPeriod, policy.Period)
+	}
+
+	return maxPeriod
+}
+
+// RateLimitIP ограничивает частоту запросов с одного адреса до авторизации, чтобы поток
+// неавторизованных запросов не доходил до проверки учетных данных. Адрес клиента берется
+// из X-Forwarded-For только от доверенных прокси (TrustedProxies).
+func (ms *middlewareService) RateLimitIP(ctx *gin.Context) {
+	if ms.rateLimits == nil || strings.HasPrefix(ctx.Request.RequestURI, "/swagger/") {
+		return
+	}
+
+	ms.takeRateLimit(ctx, ipRateLimitPolicy, "ip:"+ctx.ClientIP())
+}
+
+// RateLimit ограничивает частоту запросов корзиной токенов. Корзина выбирается по политике метода
+// и ключу клиента: ключу API, VK ID пользователя или IP-адресу для неавторизованных запросов.
+func (ms *middlewareService) RateLimit(ctx *gin.Context) {
+	if ms.rateLimits == nil || ctx.FullPath() == "" ||
+		strings.HasPrefix(ctx.Request.RequestURI, "/swagger/") ||
+		strings.HasPrefix(ctx.Request.RequestURI, "/blobs/") {
+		return
+	}
+
+	policy, ok := rateLimitPolicies[ctx.Request.Method+" "+ctx.FullPath()]
+	if !ok {
+		policy = defaultRateLimitPolicy
+	}
+
+	ms.takeRateLimit(ctx, policy, rateLimitClient(ctx))
+}
+
+// takeRateLimit списывает токен из корзины клиента и прерывает запрос, если корзина пуста.
+func (ms *middlewareService) takeRateLimit(ctx *gin.Context, policy ratelimit.Policy, client string) {
+	result, err := ms.rateLimits.Take(ctx, policy.Name+":"+client, policy)
+	if err != nil {
+		// Недоступность хранилища не должна останавливать API
+		ms.logger.Error("Failed take rate limit token", zap.Error(err))
+		return
+	}
+
+	ctx.Header("RateLimit-Policy", strconv.Itoa(policy.Limit)+";w="+strconv.Itoa(int(policy.Period.Seconds())))
+	ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	ctx.Header("RateLimit-Reset", strconv.Itoa(int(result.ResetAfter.Seconds())))
+
+	if !result.Allowed {
+		ms.logger.Debug("Rate limit exceeded", zap.String("Policy", policy.Name))
+
+		ctx.Header("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
+		abort(ctx, errs.New(errs.CodeTooManyRequests))
+
+		return
+	}
+}
+
+func rateLimitClient(ctx *gin.Context) string {
+	principal := auth.GetPrincipal(ctx)
+	if principal == nil {
+		return "ip:" + ctx.ClientIP()
+	}
+
+	if principal.APIKeyID != nil {
+		return "key:" + principal.APIKeyID.String()
+	}
+
+	return "user:" + strconv.FormatInt(principal.VkUserID, 10)
+}
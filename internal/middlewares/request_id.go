@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// RequestIDKey ключ идентификатора запроса в контексте
+	RequestIDKey = "requestId"
+
+	requestIDHeader = "X-Request-ID"
+)
+
+// requestIDPattern допустимый идентификатор запроса, переданный клиентом или прокси
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID присваивает запросу идентификатор: берет его из X-Request-ID или создает новый.
+// Идентификатор возвращается в заголовке ответа, в ошибках и пишется в лог.
+func (ms *middlewareService) RequestID(ctx *gin.Context) {
+	requestID := ctx.GetHeader(requestIDHeader)
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = uuid.NewString()
+	}
+
+	ctx.Set(RequestIDKey, requestID)
+	ctx.Header(requestIDHeader, requestID)
+}
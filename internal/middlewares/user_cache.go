@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+)
+
+// userCache LRU-кэш пользователей по VK ID. Пока запись жива, пользователь берется из кэша
+// без обращения к базе. Кэш хранит копии, чтобы изменения пользователя в обработчике не попадали в кэш.
+type userCache struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	items map[int64]*list.Element
+	order *list.List
+}
+
+type userCacheEntry struct {
+	vkID      int64
+	user      models.User
+	expiresAt time.Time
+}
+
+func newUserCache(size int, ttl time.Duration) *userCache {
+	return &userCache{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[int64]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (c *userCache) get(vkID int64) (*models.User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[vkID]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*userCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.items, vkID)
+
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	user := entry.user
+	return &user, true
+}
+
+func (c *userCache) add(user *models.User) {
+	if c.size <= 0 || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[user.VkID]; ok {
+		entry := element.Value.(*userCacheEntry)
+		entry.user = *user
+		entry.expiresAt = time.Now().Add(c.ttl)
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.items[user.VkID] = c.order.PushFront(&userCacheEntry{vkID: user.VkID, user: *user, expiresAt: time.Now().Add(c.ttl)})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*userCacheEntry).vkID)
+	}
+}
+
+func (c *userCache) remove(vkID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[vkID]; ok {
+		c.order.Remove(element)
+		delete(c.items, vkID)
+	}
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"fmt"
+
+	"github.com/ShpullRequest/backend/internal/auth"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Users находит или создает пользователя по VK ID авторизованного запроса и сохраняет его в контексте.
+// Пользователь обновляется одним upsert (время последнего обращения, платформа и язык) и на время жизни кэша
+// берется из памяти, поэтому last_seen_at обновляется не чаще раза в USER_CACHE_TTL. Запросы по ключу API
+// выполняются от имени владельца ключа и не считаются его обращениями: пользователь только загружается.
+func (ms *middlewareService) Users(ctx *gin.Context) {
+	principal := auth.GetPrincipal(ctx)
+	if principal == nil {
+		return
+	}
+
+	user, err := ms.provisionUser(ctx, principal)
+	if err != nil {
+		abort(ctx, fmt.Errorf("provision user: %w", err))
+		return
+	}
+
+	auth.SetUser(ctx, user)
+	ctx.Next()
+
+	if auth.IsUserChanged(ctx) {
+		ms.users.remove(principal.VkUserID)
+	}
+}
+
+func (ms *middlewareService) provisionUser(ctx *gin.Context, principal *auth.Principal) (*models.User, error) {
+	if user, ok := ms.users.get(principal.VkUserID); ok {
+		return user, nil
+	}
+
+	// Владелец ключа уже создан при выпуске ключа. В кэш пользователь не попадает, чтобы первое
+	// обращение через VK обновило время последнего обращения, платформу и язык.
+	if principal.Method == auth.MethodAPIKey {
+		return ms.pg.GetUserByVkID(ctx, principal.VkUserID)
+	}
+
+	user, err := ms.pg.UpsertUser(ctx, principal.VkUserID, principal.Platform, principal.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	ms.users.add(user)
+	return user, nil
+}
@@ -4,10 +4,11 @@ import "github.com/google/uuid"
 
 type (
 	Achievements struct {
-		ID          uuid.UUID `json:"_id" db:"id"`
-		Name        string    `json:"name" db:"name"`
-		Description string    `json:"description" db:"description"`
-		Icon        string    `json:"icon" db:"icon"`
-		Coins       int       `json:"coins" db:"coins"`
+		ID           uuid.UUID    `json:"_id" db:"id"`
+		Name         string       `json:"name" db:"name"`
+		Description  string       `json:"description" db:"description"`
+		Translations Translations `json:"translations" db:"translations" swaggertype:"object"`
+		Icon         string       `json:"icon" db:"icon"`
+		Coins        int          `json:"coins" db:"coins"`
 	}
 )
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	ScopeReadPlaces  = "read:places"
+	ScopeReadEvents  = "read:events"
+	ScopeReadRoutes  = "read:routes"
+	ScopeWritePlaces = "write:places"
+	ScopeWriteEvents = "write:events"
+	ScopeWriteRoutes = "write:routes"
+)
+
+// ScopePermissions разрешения, которые открывает область действия ключа.
+// Итоговые разрешения ключа не превышают разрешений его владельца.
+// Публичное чтение разрешений не требует: области read открывают методы GET ресурса (requireAPIKeyScope)
+// и просмотр удаленных записей, если он доступен владельцу.
+var ScopePermissions = map[string][]string{
+	ScopeReadPlaces:  {PermissionDeletedView},
+	ScopeReadEvents:  {PermissionDeletedView},
+	ScopeReadRoutes:  {PermissionDeletedView},
+	ScopeWritePlaces: {PermissionPlaceCreate, PermissionPlaceEdit, PermissionPlaceDelete},
+	ScopeWriteEvents: {PermissionEventCreate, PermissionEventEdit, PermissionEventDelete},
+	ScopeWriteRoutes: {PermissionRouteCreate, PermissionRouteEdit, PermissionRouteDelete},
+}
+
+type (
+	APIKey struct {
+		ID            uuid.UUID      `json:"_id" db:"id"`
+		UserID        uuid.UUID      `json:"user_id" db:"user_id"`
+		VkID          int64          `json:"vk_id" db:"vk_id"`
+		CreatedBy     *uuid.UUID     `json:"created_by" db:"created_by"`
+		Name          string         `json:"name" db:"name"`
+		Prefix        string         `json:"prefix" db:"prefix"`
+		KeyHash       string         `json:"-" db:"key_hash"`
+		Scopes        pq.StringArray `json:"scopes" db:"scopes" swaggertype:"array,string"`
+		CompanyID     *uuid.UUID     `json:"company_id" db:"company_id"`
+		DailyQuota    int            `json:"daily_quota" db:"daily_quota"`
+		RequestsCount int64          `json:"requests_count" db:"requests_count"`
+		LastUsedAt    *time.Time     `json:"last_used_at" db:"last_used_at"`
+		CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+		RevokedAt     *time.Time     `json:"revoked_at" db:"revoked_at"`
+	}
+
+	// APIKeyCreated содержит открытое значение ключа, которое показывается только при создании
+	APIKeyCreated struct {
+		APIKey
+		Key string `json:"key"`
+	}
+
+	APIKeyUsage struct {
+		Day      time.Time `json:"day" db:"day"`
+		Requests int       `json:"requests" db:"requests"`
+	}
+)
+
+func NewScopePermissions(scopes []string) Permissions {
+	permissions := make(Permissions)
+	for _, scope := range scopes {
+		for _, permission := range ScopePermissions[scope] {
+			permissions[permission] = true
+		}
+	}
+
+	return permissions
+}
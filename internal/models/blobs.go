@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Blob struct {
+	ID           uuid.UUID `json:"_id" db:"id"`
+	OwnerID      uuid.UUID `json:"owner_id" db:"owner_id"`
+	Key          string    `json:"-" db:"key"`
+	URL          string    `json:"url" db:"url"`
+	ThumbnailKey string    `json:"-" db:"thumbnail_key"`
+	ThumbnailURL string    `json:"thumbnail_url" db:"thumbnail_url"`
+	ContentType  string    `json:"content_type" db:"content_type"`
+	Size         int64     `json:"size" db:"size"`
+	Width        int       `json:"width" db:"width"`
+	Height       int       `json:"height" db:"height"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Bookmark struct {
+		ID         uuid.UUID `json:"_id" db:"id"`
+		UserID     uuid.UUID `json:"user_id" db:"user_id"`
+		EntityType string    `json:"entity_type" db:"entity_type" enums:"place,event,route"`
+		EntityID   uuid.UUID `json:"entity_id" db:"entity_id"`
+		CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	}
+
+	Checkin struct {
+		ID         uuid.UUID `json:"_id" db:"id"`
+		UserID     uuid.UUID `json:"user_id" db:"user_id"`
+		EntityType string    `json:"entity_type" db:"entity_type" enums:"place,event,route"`
+		EntityID   uuid.UUID `json:"entity_id" db:"entity_id"`
+		CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	}
+)
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	ClaimStatusPending  = "pending"
+	ClaimStatusApproved = "approved"
+	ClaimStatusRejected = "rejected"
+)
+
+// PlaceClaim заявка компании на владение местом. Attachments - ссылки на подтверждающие документы
+type PlaceClaim struct {
+	ID            uuid.UUID      `json:"_id" db:"id"`
+	PlaceID       uuid.UUID      `json:"place_id" db:"place_id"`
+	CompanyID     uuid.UUID      `json:"company_id" db:"company_id"`
+	UserID        uuid.UUID      `json:"user_id" db:"user_id"`
+	Evidence      string         `json:"evidence" db:"evidence"`
+	Attachments   pq.StringArray `json:"attachments" db:"attachments" swaggertype:"array,string"`
+	Status        string         `json:"status" db:"status" enums:"pending,approved,rejected"`
+	ReviewerID    *uuid.UUID     `json:"reviewer_id" db:"reviewer_id"`
+	ReviewComment string         `json:"review_comment" db:"review_comment"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	ReviewedAt    *time.Time     `json:"reviewed_at" db:"reviewed_at"`
+}
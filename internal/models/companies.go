@@ -1,16 +1,29 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Company struct {
-	ID          uuid.UUID `json:"_id" db:"id"`
-	UserID      uuid.UUID `json:"user_id" db:"user_id"`
-	IsReleased  bool      `json:"is_released" db:"is_released"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description" db:"description"`
-	PhotoCard   string    `json:"photo_card" db:"photo_card"`
+	ID           uuid.UUID    `json:"_id" db:"id"`
+	UserID       uuid.UUID    `json:"user_id" db:"user_id"`
+	IsReleased   bool         `json:"is_released" db:"is_released"`
+	Name         string       `json:"name" db:"name"`
+	Description  string       `json:"description" db:"description"`
+	Translations Translations `json:"translations" db:"translations" swaggertype:"object"`
+	PhotoCard    string       `json:"photo_card" db:"photo_card"`
 }
 
 func (c *Company) IsNil() bool {
 	return c.ID.ID() == 0
 }
+
+// CompanyMember сотрудник компании, который может отвечать на отзывы от ее имени.
+type CompanyMember struct {
+	CompanyID uuid.UUID `json:"company_id" db:"company_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	VkID      int64     `json:"vk_id" db:"vk_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
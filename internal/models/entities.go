@@ -0,0 +1,35 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	EntityTypePlace = "place"
+	EntityTypeEvent = "event"
+	EntityTypeRoute = "route"
+	// EntityTypeCompany используется только в истории изменений и предложенных изменениях
+	EntityTypeCompany = "company"
+)
+
+// RatingSummary кэшированная сводка оценок сущности.
+// Histogram содержит количество оценок от 1 до 5 звезд (индекс 0 - одна звезда).
+type RatingSummary struct {
+	Average   float64       `json:"average" db:"rating_avg"`
+	Count     int           `json:"count" db:"rating_count"`
+	Histogram pq.Int64Array `json:"histogram" db:"rating_histogram" swaggertype:"array,integer"`
+}
+
+// Порядок списков мест, событий и маршрутов. По умолчанию порядок не задан.
+const (
+	SortTrending = "trending"
+	SortRating   = "rating"
+)
+
+// ListFilter фильтр и порядок списков мест, событий и маршрутов. Пустые поля не ограничивают выборку.
+type ListFilter struct {
+	CityID *uuid.UUID
+	TagID  *uuid.UUID
+	Sort   string
+}
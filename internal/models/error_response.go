@@ -1,11 +1,13 @@
 package models
 
+import "github.com/ShpullRequest/backend/internal/errs"
+
 type ErrorResponse struct {
-	Error interface{} `json:"error"`
+	Error *errs.Error `json:"error"`
 }
 
-func NewErrorResponse(v interface{}) *ErrorResponse {
+func NewErrorResponse(err *errs.Error) *ErrorResponse {
 	return &ErrorResponse{
-		Error: v,
+		Error: err,
 	}
 }
@@ -8,28 +8,23 @@ import (
 
 type (
 	Event struct {
-		ID          uuid.UUID      `json:"_id" db:"id"`
-		CompanyID   *uuid.UUID     `json:"company_id,omitempty" db:"company_id"`
-		Name        string         `json:"name" db:"name"`
-		Description string         `json:"description" db:"description"`
-		Carousel    pq.StringArray `json:"carousel" db:"carousel" swaggertype:"array,string"`
-		Tags        pq.StringArray `json:"tags" db:"tags" swaggertype:"array,string"`
-		Icon        string         `json:"icon" db:"icon"`
-		StartTime   time.Time      `json:"start_time" db:"start_time"`
-		AddressText string         `json:"address_text" db:"address_text"`
-		AddressLng  float64        `json:"address_lng" db:"address_lng"`
-		AddressLat  float64        `json:"address_lat" db:"address_lat"`
-		IsDeleted   bool           `json:"-" db:"is_deleted"`
-	}
-
-	ReviewEvent struct {
-		ID         uuid.UUID `json:"_id" db:"id"`
-		OwnerID    uuid.UUID `json:"owner_id" db:"owner_id"`
-		EventID    uuid.UUID `json:"event_id" db:"event_id"`
-		ReviewText string    `json:"review_text" db:"review_text"`
-		Stars      float64   `json:"stars" db:"stars"`
-		CreatedAt  time.Time `json:"created_at" db:"created_at"`
-		IsDeleted  bool      `json:"-" db:"is_deleted"`
+		ID            uuid.UUID      `json:"_id" db:"id"`
+		CompanyID     *uuid.UUID     `json:"company_id,omitempty" db:"company_id"`
+		Name          string         `json:"name" db:"name"`
+		Description   string         `json:"description" db:"description"`
+		Translations  Translations   `json:"translations" db:"translations" swaggertype:"object"`
+		Carousel      pq.StringArray `json:"carousel" db:"carousel" swaggertype:"array,string"`
+		TagIDs        pq.StringArray `json:"tag_ids" db:"tag_ids" swaggertype:"array,string"`
+		Icon          string         `json:"icon" db:"icon"`
+		StartTime     time.Time      `json:"start_time" db:"start_time"`
+		AddressText   string         `json:"address_text" db:"address_text"`
+		AddressLng    float64        `json:"address_lng" db:"address_lng"`
+		AddressLat    float64        `json:"address_lat" db:"address_lat"`
+		Address       `json:"address"`
+		CityID        *uuid.UUID `json:"city_id" db:"city_id"`
+		IsDeleted     bool       `json:"is_deleted" db:"is_deleted"`
+		RatingSummary `json:"rating"`
+		TrendingScore float64 `json:"trending_score" db:"trending_score"`
 	}
 )
 
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LocalityKindCity   = "city"
+	LocalityKindRegion = "region"
+)
+
+// kmPerDegree длина градуса широты в километрах
+const kmPerDegree = 111.2
+
+type (
+	// Address структурированный адрес места или события
+	Address struct {
+		Country    string `json:"country" db:"address_country"`
+		Region     string `json:"region" db:"address_region"`
+		Locality   string `json:"locality" db:"address_locality"`
+		Street     string `json:"street" db:"address_street"`
+		Building   string `json:"building" db:"address_building"`
+		PostalCode string `json:"postal_code" db:"address_postal_code"`
+	}
+
+	// GeoCandidate вариант адреса, найденный геокодером
+	GeoCandidate struct {
+		Address `json:"address"`
+		Name    string  `json:"name"`
+		Text    string  `json:"text"`
+		Lng     float64 `json:"lng"`
+		Lat     float64 `json:"lat"`
+	}
+
+	// GeoPoint координаты точки. В базе хранится как POINT(lng, lat)
+	GeoPoint struct {
+		Lat float64 `json:"lat" binding:"latitude"`
+		Lng float64 `json:"lng" binding:"longitude"`
+	}
+
+	// Polygon граница города или региона. В базе хранится как POLYGON, точки - как (lng, lat)
+	Polygon []GeoPoint
+
+	// Locality город или регион из справочника. Места и события привязываются к нему по границе boundary,
+	// а если ее нет - по кругу радиусом radius_km вокруг center. Записи без границ (например, добавленные
+	// при выборе пользователем точки на карте) в привязке не участвуют.
+	Locality struct {
+		ID        uuid.UUID  `json:"_id" db:"id"`
+		ParentID  *uuid.UUID `json:"parent_id" db:"parent_id"`
+		Kind      string     `json:"kind" db:"kind" enums:"city,region"`
+		Name      string     `json:"name" db:"name"`
+		Region    string     `json:"region" db:"region"`
+		Country   string     `json:"country" db:"country"`
+		Center    GeoPoint   `json:"center" db:"center"`
+		RadiusKm  float64    `json:"radius_km" db:"radius_km"`
+		Boundary  Polygon    `json:"boundary" db:"boundary"`
+		CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	}
+
+	// LocalityLanding подборка для главной страницы города
+	LocalityLanding struct {
+		Locality       *Locality      `json:"locality"`
+		TopPlaces      []Place        `json:"top_places"`
+		UpcomingEvents []Event        `json:"upcoming_events"`
+		FeaturedRoutes []RouteWithGeo `json:"featured_routes"`
+	}
+)
+
+func (p GeoPoint) Value() (driver.Value, error) {
+	return fmt.Sprintf("(%f,%f)", p.Lng, p.Lat), nil
+}
+
+func (p *GeoPoint) Scan(src any) error {
+	var value string
+	switch src := src.(type) {
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return fmt.Errorf("unsupported point type %T", src)
+	}
+
+	if _, err := fmt.Sscanf(value, "(%g,%g)", &p.Lng, &p.Lat); err != nil {
+		return fmt.Errorf("parse point %q: %w", value, err)
+	}
+
+	return nil
+}
+
+func (p Polygon) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+
+	points := make([]string, len(p))
+	for i, point := range p {
+		points[i] = fmt.Sprintf("(%f,%f)", point.Lng, point.Lat)
+	}
+
+	return "(" + strings.Join(points, ",") + ")", nil
+}
+
+func (p *Polygon) Scan(src any) error {
+	var value string
+	switch src := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return fmt.Errorf("unsupported polygon type %T", src)
+	}
+
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "(("), "))")
+
+	polygon := make(Polygon, 0)
+	for _, point := range strings.Split(value, "),(") {
+		var geoPoint GeoPoint
+		if _, err := fmt.Sscanf(point, "%g,%g", &geoPoint.Lng, &geoPoint.Lat); err != nil {
+			return fmt.Errorf("parse polygon %q: %w", value, err)
+		}
+
+		polygon = append(polygon, geoPoint)
+	}
+
+	*p = polygon
+	return nil
+}
+
+// Bounds возвращает углы прямоугольника, в который вписаны границы населенного пункта, ok = false - если границ нет.
+func (l *Locality) Bounds() (low, high GeoPoint, ok bool) {
+	if len(l.Boundary) != 0 {
+		low, high = l.Boundary[0], l.Boundary[0]
+		for _, point := range l.Boundary[1:] {
+			low.Lat, low.Lng = math.Min(low.Lat, point.Lat), math.Min(low.Lng, point.Lng)
+			high.Lat, high.Lng = math.Max(high.Lat, point.Lat), math.Max(high.Lng, point.Lng)
+		}
+
+		return low, high, true
+	}
+	if l.RadiusKm <= 0 {
+		return low, high, false
+	}
+
+	dLat := l.RadiusKm / kmPerDegree
+	dLng := 180.0
+	if cos := math.Cos(l.Center.Lat * math.Pi / 180); cos > dLat/180 {
+		dLng = math.Min(dLat/cos, 180)
+	}
+
+	low = GeoPoint{Lat: l.Center.Lat - dLat, Lng: l.Center.Lng - dLng}
+	high = GeoPoint{Lat: l.Center.Lat + dLat, Lng: l.Center.Lng + dLng}
+
+	return low, high, true
+}
+
+// NewLocality выделяет из адреса населенный пункт, а если его нет - регион.
+func NewLocality(address Address, center GeoPoint) (Locality, bool) {
+	locality := Locality{
+		Kind:    LocalityKindCity,
+		Name:    address.Locality,
+		Region:  address.Region,
+		Country: address.Country,
+		Center:  center,
+	}
+
+	if locality.Name == "" {
+		locality.Kind = LocalityKindRegion
+		locality.Name = address.Region
+	}
+
+	return locality, locality.Name != ""
+}
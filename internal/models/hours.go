@@ -0,0 +1,220 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	HoursTimeLayout = "15:04"
+	HoursDateLayout = "2006-01-02"
+
+	DefaultPlaceTimezone = "Europe/Moscow"
+)
+
+// Удобства мест
+const (
+	AmenityWiFi          = "wifi"
+	AmenityParking       = "parking"
+	AmenityToilet        = "toilet"
+	AmenityCafe          = "cafe"
+	AmenityCloakroom     = "cloakroom"
+	AmenityCardPayment   = "card_payment"
+	AmenityChildFriendly = "child_friendly"
+	AmenityPetFriendly   = "pet_friendly"
+)
+
+// Доступность мест для людей с инвалидностью
+const (
+	AccessibilityWheelchair       = "wheelchair"
+	AccessibilityStepFree         = "step_free"
+	AccessibilityAccessibleToilet = "accessible_toilet"
+	AccessibilityHearingLoop      = "hearing_loop"
+	AccessibilityGuideDogs        = "guide_dogs"
+)
+
+type (
+	// TimeRange интервал работы в течение дня в формате 15:04. Если Close не позже Open,
+	// интервал заканчивается на следующий день: 22:00-02:00 - ночной режим, 00:00-00:00 - круглосуточно.
+	TimeRange struct {
+		Open  string `json:"open" binding:"required,datetime=15:04"`
+		Close string `json:"close" binding:"required,datetime=15:04"`
+	}
+
+	// WeeklyHours часы работы места по дням недели. Пустой день - выходной
+	WeeklyHours struct {
+		Mon []TimeRange `json:"mon" binding:"dive"`
+		Tue []TimeRange `json:"tue" binding:"dive"`
+		Wed []TimeRange `json:"wed" binding:"dive"`
+		Thu []TimeRange `json:"thu" binding:"dive"`
+		Fri []TimeRange `json:"fri" binding:"dive"`
+		Sat []TimeRange `json:"sat" binding:"dive"`
+		Sun []TimeRange `json:"sun" binding:"dive"`
+	}
+
+	// HoursException часы работы в конкретную дату (праздник, санитарный день), заменяют обычное расписание
+	HoursException struct {
+		Date   string      `json:"date" binding:"required,datetime=2006-01-02"`
+		Closed bool        `json:"closed"`
+		Hours  []TimeRange `json:"hours" binding:"dive"`
+		Note   string      `json:"note" binding:"max=255"`
+	}
+
+	HoursExceptions []HoursException
+
+	// DaySchedule расписание места на дату с учетом исключений
+	DaySchedule struct {
+		Date      string      `json:"date"`
+		Closed    bool        `json:"closed"`
+		Hours     []TimeRange `json:"hours"`
+		Note      string      `json:"note"`
+		Exception bool        `json:"exception"`
+	}
+
+	// PlaceHours расписание места на диапазон дат. OpenNow - nil, если часы работы неизвестны
+	PlaceHours struct {
+		Timezone string        `json:"timezone"`
+		OpenNow  *bool         `json:"open_now"`
+		Days     []DaySchedule `json:"days"`
+	}
+)
+
+func (h WeeklyHours) Value() (driver.Value, error) {
+	value, err := json.Marshal(h)
+	return string(value), err
+}
+
+func (h *WeeklyHours) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(src), h)
+	case []byte:
+		return json.Unmarshal(src, h)
+	default:
+		return fmt.Errorf("unsupported opening hours type %T", src)
+	}
+}
+
+// Day возвращает интервалы работы в день недели weekday.
+func (h WeeklyHours) Day(weekday time.Weekday) []TimeRange {
+	return [...][]TimeRange{h.Sun, h.Mon, h.Tue, h.Wed, h.Thu, h.Fri, h.Sat}[weekday]
+}
+
+func (e HoursExceptions) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+
+	value, err := json.Marshal(e)
+	return string(value), err
+}
+
+func (e *HoursExceptions) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(src), e)
+	case []byte:
+		return json.Unmarshal(src, e)
+	default:
+		return fmt.Errorf("unsupported hours exceptions type %T", src)
+	}
+}
+
+// locations загруженные часовые поясы по имени: time.LoadLocation каждый раз читает базу поясов с диска
+var locations sync.Map
+
+// loadLocation возвращает часовой пояс name, загружая его только при первом обращении.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// Location возвращает часовой пояс места. Неизвестный пояс заменяется поясом по умолчанию.
+func (p *Place) Location() *time.Location {
+	if p.Timezone != "" {
+		if loc, err := loadLocation(p.Timezone); err == nil {
+			return loc
+		}
+	}
+
+	loc, _ := loadLocation(DefaultPlaceTimezone)
+	return loc
+}
+
+// ScheduleOn возвращает расписание места на дату date (учитываются только год, месяц и день).
+// Второй результат false, если часы работы на эту дату неизвестны.
+func (p *Place) ScheduleOn(date time.Time) (DaySchedule, bool) {
+	day := DaySchedule{Date: date.Format(HoursDateLayout), Hours: make([]TimeRange, 0)}
+
+	for _, exception := range p.HoursExceptions {
+		if exception.Date == day.Date {
+			day.Exception = true
+			day.Closed = exception.Closed || len(exception.Hours) == 0
+			day.Note = exception.Note
+			if !day.Closed {
+				day.Hours = exception.Hours
+			}
+
+			return day, true
+		}
+	}
+
+	if p.OpeningHours == nil {
+		return day, false
+	}
+
+	if hours := p.OpeningHours.Day(date.Weekday()); len(hours) > 0 {
+		day.Hours = hours
+	} else {
+		day.Closed = true
+	}
+
+	return day, true
+}
+
+// OpenAt проверяет, открыто ли место в момент t по местному времени места. nil - часы работы неизвестны.
+func (p *Place) OpenAt(t time.Time) *bool {
+	local := t.In(p.Location())
+	minute := local.Hour()*60 + local.Minute()
+
+	open := false
+	// Ночные интервалы предыдущего дня продолжаются после полуночи
+	yesterday, _ := p.ScheduleOn(local.AddDate(0, 0, -1))
+	for _, hours := range yesterday.Hours {
+		if from, to := hours.minutes(); to <= from && minute < to {
+			open = true
+		}
+	}
+
+	today, known := p.ScheduleOn(local)
+	if !known && !open {
+		return nil
+	}
+
+	for _, hours := range today.Hours {
+		if from, to := hours.minutes(); minute >= from && (to <= from || minute < to) {
+			open = true
+		}
+	}
+
+	return &open
+}
+
+func (r TimeRange) minutes() (int, int) {
+	open, _ := time.Parse(HoursTimeLayout, r.Open)
+	closeAt, _ := time.Parse(HoursTimeLayout, r.Close)
+
+	return open.Hour()*60 + open.Minute(), closeAt.Hour()*60 + closeAt.Minute()
+}
@@ -0,0 +1,233 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// 2024-06-07 - пятница
+var (
+	friday   = time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+	saturday = friday.AddDate(0, 0, 1)
+	monday   = friday.AddDate(0, 0, 3)
+	tuesday  = friday.AddDate(0, 0, 4)
+)
+
+func at(day time.Time, clock string) time.Time {
+	t, err := time.Parse(HoursTimeLayout, clock)
+	if err != nil {
+		panic(err)
+	}
+
+	return day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+}
+
+func TestPlaceOpenAt(t *testing.T) {
+	overnight := &WeeklyHours{Fri: []TimeRange{{Open: "22:00", Close: "02:00"}}}
+	aroundTheClock := &WeeklyHours{Mon: []TimeRange{{Open: "00:00", Close: "00:00"}}}
+
+	tests := []struct {
+		name       string
+		place      Place
+		at         time.Time
+		wantKnown  bool
+		wantIsOpen bool
+	}{
+		{
+			name:  "unknown hours",
+			place: Place{},
+			at:    at(friday, "12:00"),
+		},
+		{
+			name:       "before overnight range",
+			place:      Place{OpeningHours: overnight},
+			at:         at(friday, "21:59"),
+			wantKnown:  true,
+			wantIsOpen: false,
+		},
+		{
+			name:       "overnight range before midnight",
+			place:      Place{OpeningHours: overnight},
+			at:         at(friday, "23:00"),
+			wantKnown:  true,
+			wantIsOpen: true,
+		},
+		{
+			name:       "overnight range after midnight",
+			place:      Place{OpeningHours: overnight},
+			at:         at(saturday, "01:59"),
+			wantKnown:  true,
+			wantIsOpen: true,
+		},
+		{
+			name:       "overnight range closes next day",
+			place:      Place{OpeningHours: overnight},
+			at:         at(saturday, "02:00"),
+			wantKnown:  true,
+			wantIsOpen: false,
+		},
+		{
+			name:       "around the clock at midnight",
+			place:      Place{OpeningHours: aroundTheClock},
+			at:         at(monday, "00:00"),
+			wantKnown:  true,
+			wantIsOpen: true,
+		},
+		{
+			name:       "around the clock before midnight",
+			place:      Place{OpeningHours: aroundTheClock},
+			at:         at(monday, "23:59"),
+			wantKnown:  true,
+			wantIsOpen: true,
+		},
+		{
+			name:       "around the clock does not spill into next day",
+			place:      Place{OpeningHours: aroundTheClock},
+			at:         at(tuesday, "00:30"),
+			wantKnown:  true,
+			wantIsOpen: false,
+		},
+		{
+			name: "closed exception cancels previous overnight range",
+			place: Place{
+				OpeningHours:    overnight,
+				HoursExceptions: HoursExceptions{{Date: "2024-06-07", Closed: true}},
+			},
+			at:         at(saturday, "01:00"),
+			wantKnown:  true,
+			wantIsOpen: false,
+		},
+		{
+			name: "exception hours replace previous overnight range",
+			place: Place{
+				OpeningHours:    overnight,
+				HoursExceptions: HoursExceptions{{Date: "2024-06-07", Hours: []TimeRange{{Open: "20:00", Close: "23:00"}}}},
+			},
+			at:         at(saturday, "01:00"),
+			wantKnown:  true,
+			wantIsOpen: false,
+		},
+		{
+			name: "overnight exception continues after midnight",
+			place: Place{
+				HoursExceptions: HoursExceptions{{Date: "2024-06-07", Hours: []TimeRange{{Open: "23:00", Close: "03:00"}}}},
+			},
+			at:         at(saturday, "02:30"),
+			wantKnown:  true,
+			wantIsOpen: true,
+		},
+		{
+			name:       "local time of place",
+			place:      Place{Timezone: DefaultPlaceTimezone, OpeningHours: overnight},
+			at:         at(friday, "19:30"),
+			wantKnown:  true,
+			wantIsOpen: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.place.Timezone == "" {
+				tt.place.Timezone = "UTC"
+			}
+
+			open := tt.place.OpenAt(tt.at)
+			if (open != nil) != tt.wantKnown {
+				t.Fatalf("OpenAt() = %v, want known %t", open, tt.wantKnown)
+			}
+			if open != nil && *open != tt.wantIsOpen {
+				t.Errorf("OpenAt() = %t, want %t", *open, tt.wantIsOpen)
+			}
+		})
+	}
+}
+
+func TestPlaceScheduleOn(t *testing.T) {
+	weekly := &WeeklyHours{Fri: []TimeRange{{Open: "10:00", Close: "18:00"}}}
+
+	tests := []struct {
+		name      string
+		place     Place
+		date      time.Time
+		want      DaySchedule
+		wantKnown bool
+	}{
+		{
+			name:  "unknown hours",
+			place: Place{},
+			date:  friday,
+			want:  DaySchedule{Date: "2024-06-07", Hours: []TimeRange{}},
+		},
+		{
+			name:      "weekly hours",
+			place:     Place{OpeningHours: weekly},
+			date:      friday,
+			want:      DaySchedule{Date: "2024-06-07", Hours: weekly.Fri},
+			wantKnown: true,
+		},
+		{
+			name:      "day off",
+			place:     Place{OpeningHours: weekly},
+			date:      saturday,
+			want:      DaySchedule{Date: "2024-06-08", Closed: true, Hours: []TimeRange{}},
+			wantKnown: true,
+		},
+		{
+			name: "closed exception",
+			place: Place{
+				OpeningHours:    weekly,
+				HoursExceptions: HoursExceptions{{Date: "2024-06-07", Closed: true, Hours: weekly.Fri, Note: "Санитарный день"}},
+			},
+			date:      friday,
+			want:      DaySchedule{Date: "2024-06-07", Closed: true, Hours: []TimeRange{}, Note: "Санитарный день", Exception: true},
+			wantKnown: true,
+		},
+		{
+			name: "exception without hours is closed",
+			place: Place{
+				OpeningHours:    weekly,
+				HoursExceptions: HoursExceptions{{Date: "2024-06-07"}},
+			},
+			date:      friday,
+			want:      DaySchedule{Date: "2024-06-07", Closed: true, Hours: []TimeRange{}, Exception: true},
+			wantKnown: true,
+		},
+		{
+			name: "exception hours without weekly hours",
+			place: Place{
+				HoursExceptions: HoursExceptions{{Date: "2024-06-08", Hours: []TimeRange{{Open: "12:00", Close: "16:00"}}}},
+			},
+			date:      saturday,
+			want:      DaySchedule{Date: "2024-06-08", Hours: []TimeRange{{Open: "12:00", Close: "16:00"}}, Exception: true},
+			wantKnown: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, known := tt.place.ScheduleOn(tt.date)
+			if known != tt.wantKnown {
+				t.Fatalf("ScheduleOn() known = %t, want %t", known, tt.wantKnown)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScheduleOn() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceLocation(t *testing.T) {
+	place := Place{Timezone: "Asia/Yekaterinburg"}
+	if loc := place.Location(); loc.String() != "Asia/Yekaterinburg" {
+		t.Errorf("Location() = %s, want Asia/Yekaterinburg", loc)
+	}
+	if place.Location() != place.Location() {
+		t.Error("Location() is not cached")
+	}
+
+	unknown := Place{Timezone: "Mars/Olympus"}
+	if loc := unknown.Location(); loc.String() != DefaultPlaceTimezone {
+		t.Errorf("Location() = %s, want %s", loc, DefaultPlaceTimezone)
+	}
+}
@@ -1,31 +1,50 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
 type (
 	Place struct {
-		ID          uuid.UUID      `json:"_id" db:"id"`
-		Name        string         `json:"name" db:"name"`
-		Description string         `json:"description" db:"description"`
-		Carousel    pq.StringArray `json:"carousel" db:"carousel" swaggertype:"array,string"`
-		AddressText string         `json:"address_text" db:"address_text"`
-		AddressLng  float64        `json:"address_lng" db:"address_lng"`
-		AddressLat  float64        `json:"address_lat" db:"address_lat"`
-		IsDeleted   bool           `json:"is_deleted" db:"is_deleted"`
+		ID           uuid.UUID      `json:"_id" db:"id"`
+		CompanyID    *uuid.UUID     `json:"company_id" db:"company_id"`
+		Name         string         `json:"name" db:"name"`
+		Description  string         `json:"description" db:"description"`
+		Translations Translations   `json:"translations" db:"translations" swaggertype:"object"`
+		Carousel     pq.StringArray `json:"carousel" db:"carousel" swaggertype:"array,string"`
+		TagIDs       pq.StringArray `json:"tag_ids" db:"tag_ids" swaggertype:"array,string"`
+		AddressText  string         `json:"address_text" db:"address_text"`
+		AddressLng   float64        `json:"address_lng" db:"address_lng"`
+		AddressLat   float64        `json:"address_lat" db:"address_lat"`
+		Address      `json:"address"`
+		CityID       *uuid.UUID `json:"city_id" db:"city_id"`
+		IsDeleted    bool       `json:"is_deleted" db:"is_deleted"`
+		// MergedInto место, в которое объединен этот дубль
+		MergedInto    *uuid.UUID `json:"merged_into,omitempty" db:"merged_into"`
+		RatingSummary `json:"rating"`
+		TrendingScore float64 `json:"trending_score" db:"trending_score"`
+
+		// OpeningHours - nil, если часы работы неизвестны. OpenNow вычисляется при чтении из базы
+		Timezone        string          `json:"timezone" db:"timezone"`
+		OpeningHours    *WeeklyHours    `json:"opening_hours" db:"opening_hours"`
+		HoursExceptions HoursExceptions `json:"hours_exceptions" db:"hours_exceptions"`
+		OpenNow         *bool           `json:"open_now" db:"-"`
+
+		// Диапазон цен в рублях, nil - неизвестно
+		PriceMin      *int           `json:"price_min" db:"price_min"`
+		PriceMax      *int           `json:"price_max" db:"price_max"`
+		Accessibility pq.StringArray `json:"accessibility" db:"accessibility" swaggertype:"array,string"`
+		Amenities     pq.StringArray `json:"amenities" db:"amenities" swaggertype:"array,string"`
 	}
 
-	ReviewPlace struct {
-		ID         uuid.UUID `json:"_id" db:"id"`
-		OwnerID    uuid.UUID `json:"owner_id" db:"owner_id"`
-		PlaceID    uuid.UUID `json:"place_id" db:"place_id"`
-		ReviewText string    `json:"review_text" db:"review_text"`
-		CreatedAt  time.Time `json:"created_at" db:"created_at"`
-		Stars      float64   `json:"stars" db:"stars"`
-		IsDeleted  bool      `json:"is_deleted" db:"is_deleted"`
+	// PlaceDuplicate пара мест с похожими названиями рядом друг с другом. Score объединяет сходство названий
+	// и близость: 1 - одинаковые названия в одной точке
+	PlaceDuplicate struct {
+		Place          Place   `json:"place"`
+		Duplicate      Place   `json:"duplicate"`
+		NameSimilarity float64 `json:"name_similarity"`
+		DistanceM      float64 `json:"distance_m"`
+		Score          float64 `json:"score"`
 	}
 )
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	InteractionBookmark = "bookmark"
+	InteractionCheckin  = "checkin"
+	InteractionReview   = "review"
+)
+
+const (
+	ReasonLiked       = "liked"
+	ReasonNearby      = "nearby"
+	ReasonHighlyRated = "highly_rated"
+	ReasonStartsSoon  = "starts_soon"
+)
+
+type (
+	// RecommendationCandidate место, событие или маршрут с признаками, по которым считается рекомендация.
+	// Tags - slug тегов. У маршрута координаты - центр его мест и событий, а теги - теги его мест и событий.
+	RecommendationCandidate struct {
+		EntityType   string         `json:"entity_type" db:"entity_type" enums:"place,event,route"`
+		EntityID     uuid.UUID      `json:"entity_id" db:"entity_id"`
+		Name         string         `json:"name" db:"name"`
+		Translations Translations   `json:"-" db:"translations"`
+		Tags         pq.StringArray `json:"tags" db:"tags" swaggertype:"array,string"`
+		Lat          *float64       `json:"lat" db:"lat"`
+		Lng          *float64       `json:"lng" db:"lng"`
+		RatingAvg    float64        `json:"rating_avg" db:"rating_avg"`
+		RatingCount  int            `json:"rating_count" db:"rating_count"`
+		StartsAt     *time.Time     `json:"starts_at" db:"starts_at"`
+		ActiveAt     *time.Time     `json:"-" db:"active_at"`
+	}
+
+	// UserInteraction закладка, отметка о посещении или отзыв пользователя вместе с признаками объекта
+	UserInteraction struct {
+		Kind       string         `db:"kind"`
+		EntityType string         `db:"entity_type"`
+		EntityID   uuid.UUID      `db:"entity_id"`
+		Name       string         `db:"name"`
+		Tags       pq.StringArray `db:"tags"`
+		Stars      *float64       `db:"stars"`
+		CreatedAt  time.Time      `db:"created_at"`
+	}
+
+	RecommendationReason struct {
+		Kind string `json:"kind" enums:"liked,nearby,highly_rated,starts_soon"`
+		Text string `json:"text"`
+		// RefID объект, на который похожа рекомендация (для kind = liked)
+		RefID   *uuid.UUID `json:"ref_id,omitempty"`
+		RefType string     `json:"ref_type,omitempty"`
+	}
+
+	Recommendation struct {
+		RecommendationCandidate
+		Score       float64                `json:"score"`
+		Explanation string                 `json:"explanation"`
+		Reasons     []RecommendationReason `json:"reasons"`
+	}
+)
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReviewsSortRecent  = "recent"
+	ReviewsSortHelpful = "helpful"
+	ReviewsSortStars   = "stars"
+)
+
+type (
+	Review struct {
+		ID         uuid.UUID `json:"_id" db:"id"`
+		EntityType string    `json:"entity_type" db:"entity_type"`
+		EntityID   uuid.UUID `json:"entity_id" db:"entity_id"`
+		OwnerID    uuid.UUID `json:"owner_id" db:"owner_id"`
+		ReviewText string    `json:"review_text" db:"review_text"`
+		Stars      float64   `json:"stars" db:"stars"`
+		CreatedAt  time.Time `json:"created_at" db:"created_at"`
+		IsDeleted  bool      `json:"-" db:"is_deleted"`
+	}
+
+	ReviewReply struct {
+		ID        uuid.UUID `json:"_id" db:"id"`
+		ReviewID  uuid.UUID `json:"review_id" db:"review_id"`
+		CompanyID uuid.UUID `json:"company_id" db:"company_id"`
+		OwnerID   uuid.UUID `json:"owner_id" db:"owner_id"`
+		ReplyText string    `json:"reply_text" db:"reply_text"`
+		CreatedAt time.Time `json:"created_at" db:"created_at"`
+		UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	}
+
+	ReviewVote struct {
+		ID        uuid.UUID `json:"_id" db:"id"`
+		ReviewID  uuid.UUID `json:"review_id" db:"review_id"`
+		UserID    uuid.UUID `json:"user_id" db:"user_id"`
+		CreatedAt time.Time `json:"created_at" db:"created_at"`
+	}
+
+	ReviewMeta struct {
+		HelpfulCount int          `json:"helpful_count" db:"helpful_count"`
+		Author       *UserSummary `json:"author" db:"-"`
+		Reply        *ReviewReply `json:"reply" db:"-"`
+		Photos       []Blob       `json:"photos" db:"-"`
+	}
+
+	ReviewWithMeta struct {
+		Review
+		ReviewMeta
+	}
+)
+
+func (r *ReviewReply) IsNil() bool {
+	return r.ID.ID() == 0
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleAdmin         = "admin"
+	RoleModerator     = "moderator"
+	RoleContentEditor = "content_editor"
+	RoleCompanyMember = "company_member"
+	RoleUser          = "user"
+)
+
+const (
+	PermissionPlaceCreate = "place.create"
+	PermissionPlaceEdit   = "place.edit"
+	PermissionPlaceDelete = "place.delete"
+	// PermissionPlaceMerge позволяет просматривать вероятные дубли мест и объединять их
+	PermissionPlaceMerge = "place.merge"
+	// PermissionPlaceClaimReview позволяет рассматривать заявки компаний на владение местами
+	PermissionPlaceClaimReview = "place.claim.review"
+
+	PermissionEventCreate = "event.create"
+	PermissionEventEdit   = "event.edit"
+	PermissionEventDelete = "event.delete"
+
+	PermissionRouteCreate = "route.create"
+	PermissionRouteEdit   = "route.edit"
+	PermissionRouteDelete = "route.delete"
+
+	// PermissionContentManage позволяет управлять событиями и маршрутами без компании и чужих компаний
+	PermissionContentManage = "content.manage"
+	PermissionDeletedView   = "deleted.view"
+
+	PermissionReviewCreate   = "review.create"
+	PermissionReviewReply    = "review.reply"
+	PermissionReviewModerate = "review.moderate"
+
+	PermissionCompanyCreate  = "company.create"
+	PermissionCompanyApprove = "company.approve"
+	// PermissionCompanyEdit позволяет владельцу редактировать свою компанию
+	PermissionCompanyEdit = "company.edit"
+
+	// PermissionEditSuggest позволяет предлагать правки чужих мест, событий, маршрутов и компаний
+	PermissionEditSuggest = "edit.suggest"
+	// PermissionSuggestionReview позволяет принимать и отклонять предложенные правки любых сущностей
+	PermissionSuggestionReview = "suggestion.review"
+	// PermissionHistoryManage позволяет просматривать историю изменений сущностей и откатывать их
+	PermissionHistoryManage = "history.manage"
+
+	PermissionAchievementManage = "achievement.manage"
+	PermissionUploadCreate      = "upload.create"
+	PermissionRoleManage        = "role.manage"
+	PermissionAPIKeyManage      = "apikey.manage"
+	PermissionLocalityManage    = "locality.manage"
+	PermissionTagManage         = "tag.manage"
+)
+
+const (
+	RoleActionGrant  = "grant"
+	RoleActionRevoke = "revoke"
+)
+
+var userPermissions = []string{
+	PermissionEditSuggest,
+	PermissionReviewCreate,
+	PermissionCompanyCreate,
+	PermissionCompanyEdit,
+	PermissionUploadCreate,
+}
+
+var contentPermissions = []string{
+	PermissionPlaceCreate,
+	PermissionPlaceEdit,
+	PermissionPlaceDelete,
+	PermissionPlaceMerge,
+	PermissionEventCreate,
+	PermissionEventEdit,
+	PermissionEventDelete,
+	PermissionRouteCreate,
+	PermissionRouteEdit,
+	PermissionRouteDelete,
+	PermissionContentManage,
+	PermissionSuggestionReview,
+}
+
+// RolePermissions набор разрешений каждой роли. Роль user есть у всех пользователей.
+var RolePermissions = map[string][]string{
+	RoleUser: userPermissions,
+	RoleCompanyMember: {
+		PermissionPlaceCreate,
+		PermissionPlaceEdit,
+		PermissionEventCreate,
+		PermissionEventEdit,
+		PermissionEventDelete,
+		PermissionRouteCreate,
+		PermissionRouteEdit,
+		PermissionRouteDelete,
+		PermissionReviewReply,
+	},
+	RoleContentEditor: contentPermissions,
+	RoleModerator: {
+		PermissionReviewModerate,
+		PermissionCompanyApprove,
+		PermissionPlaceClaimReview,
+		PermissionSuggestionReview,
+		PermissionHistoryManage,
+		PermissionDeletedView,
+	},
+	RoleAdmin: append(append([]string{
+		PermissionReviewReply,
+		PermissionReviewModerate,
+		PermissionCompanyApprove,
+		PermissionPlaceClaimReview,
+		PermissionHistoryManage,
+		PermissionDeletedView,
+		PermissionAchievementManage,
+		PermissionRoleManage,
+		PermissionAPIKeyManage,
+		PermissionLocalityManage,
+		PermissionTagManage,
+	}, userPermissions...), contentPermissions...),
+}
+
+type (
+	Permissions map[string]bool
+
+	RoleInfo struct {
+		Role        string   `json:"role"`
+		Permissions []string `json:"permissions"`
+	}
+
+	RoleAudit struct {
+		ID        uuid.UUID  `json:"_id" db:"id"`
+		ActorID   *uuid.UUID `json:"actor_id" db:"actor_id"`
+		UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+		Role      string     `json:"role" db:"role"`
+		Action    string     `json:"action" db:"action"`
+		CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	}
+)
+
+// NewPermissions собирает разрешения всех ролей пользователя, включая базовую роль user.
+func NewPermissions(roles []string) Permissions {
+	permissions := make(Permissions)
+	for _, role := range append([]string{RoleUser}, roles...) {
+		for _, permission := range RolePermissions[role] {
+			permissions[permission] = true
+		}
+	}
+
+	return permissions
+}
+
+func (p Permissions) Has(permission string) bool {
+	return p[permission]
+}
+
+// Intersect оставляет только разрешения, присутствующие в обоих наборах.
+func (p Permissions) Intersect(other Permissions) Permissions {
+	permissions := make(Permissions)
+	for permission := range p {
+		if other.Has(permission) {
+			permissions[permission] = true
+		}
+	}
+
+	return permissions
+}
+
+func IsValidRole(role string) bool {
+	_, ok := RolePermissions[role]
+	return ok && role != RoleUser
+}
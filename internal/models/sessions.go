@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Session struct {
+		ID          uuid.UUID  `json:"_id" db:"id"`
+		UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+		VkID        int64      `json:"vk_id" db:"vk_id"`
+		RefreshJTI  uuid.UUID  `json:"-" db:"refresh_jti"`
+		UserAgent   string     `json:"user_agent" db:"user_agent"`
+		IP          string     `json:"ip" db:"ip"`
+		CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+		RefreshedAt time.Time  `json:"refreshed_at" db:"refreshed_at"`
+		ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+		RevokedAt   *time.Time `json:"revoked_at" db:"revoked_at"`
+		IsCurrent   bool       `json:"is_current" db:"-"`
+	}
+
+	SessionTokens struct {
+		SessionID        uuid.UUID `json:"session_id"`
+		TokenType        string    `json:"token_type"`
+		AccessToken      string    `json:"access_token"`
+		AccessExpiresAt  time.Time `json:"access_expires_at"`
+		RefreshToken     string    `json:"refresh_token"`
+		RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	}
+)
+
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
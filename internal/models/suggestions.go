@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SuggestionStatusPending  = "pending"
+	SuggestionStatusAccepted = "accepted"
+	SuggestionStatusRejected = "rejected"
+)
+
+// EditSuggestion изменение, предложенное пользователем без права редактировать сущность.
+// Changes содержит поля запроса редактирования и применяется при принятии.
+type EditSuggestion struct {
+	ID            uuid.UUID       `json:"_id" db:"id"`
+	EntityType    string          `json:"entity_type" db:"entity_type" enums:"place,event,route,company"`
+	EntityID      uuid.UUID       `json:"entity_id" db:"entity_id"`
+	UserID        uuid.UUID       `json:"user_id" db:"user_id"`
+	Changes       json.RawMessage `json:"changes" db:"changes" swaggertype:"object"`
+	Comment       string          `json:"comment" db:"comment"`
+	Status        string          `json:"status" db:"status" enums:"pending,accepted,rejected"`
+	ReviewerID    *uuid.UUID      `json:"reviewer_id" db:"reviewer_id"`
+	ReviewComment string          `json:"review_comment" db:"review_comment"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	ReviewedAt    *time.Time      `json:"reviewed_at" db:"reviewed_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type (
+	// Tag тег мест и событий. Aliases - прежние slug тега после переименований и слияний
+	Tag struct {
+		ID        uuid.UUID      `json:"_id" db:"id"`
+		ParentID  *uuid.UUID     `json:"parent_id" db:"parent_id"`
+		Slug      string         `json:"slug" db:"slug"`
+		Labels    TagLabels      `json:"labels" db:"labels" swaggertype:"object,string"`
+		Icon      string         `json:"icon" db:"icon"`
+		Aliases   pq.StringArray `json:"aliases" db:"aliases" swaggertype:"array,string"`
+		CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	}
+
+	// TagLabels названия тега по кодам языков
+	TagLabels map[string]string
+)
+
+func (l TagLabels) Value() (driver.Value, error) {
+	if l == nil {
+		return "{}", nil
+	}
+
+	value, err := json.Marshal(l)
+	return string(value), err
+}
+
+func (l *TagLabels) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(src), l)
+	case []byte:
+		return json.Unmarshal(src, l)
+	default:
+		return fmt.Errorf("unsupported labels type %T", src)
+	}
+}
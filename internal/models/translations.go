@@ -0,0 +1,107 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// Translation перевод текстовых полей сущности на один язык. Пустое поле не переведено
+	Translation struct {
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// Translations переводы сущности по кодам языков: {"en": {"name": "Hermitage"}}.
+	// Исходные name и description написаны на языке по умолчанию, его переводов нет
+	Translations map[string]Translation
+)
+
+// Localize возвращает name и description на первом языке из languages, на который переведено поле.
+// Поля, не переведенные ни на один из языков, остаются исходными.
+func (t Translations) Localize(languages []string, name, description string) (string, string) {
+	localizedName, localizedDescription := false, false
+	for _, language := range languages {
+		translation, ok := t[language]
+		if !ok {
+			continue
+		}
+
+		if !localizedName && translation.Name != "" {
+			name, localizedName = translation.Name, true
+		}
+		if !localizedDescription && translation.Description != "" {
+			description, localizedDescription = translation.Description, true
+		}
+	}
+
+	return name, description
+}
+
+// Merge дополняет переводы значениями из changes. Язык с пустыми name и description удаляется.
+func (t Translations) Merge(changes Translations) Translations {
+	merged := make(Translations, len(t)+len(changes))
+	for language, translation := range t {
+		merged[language] = translation
+	}
+
+	for language, translation := range changes {
+		if translation.Name == "" && translation.Description == "" {
+			delete(merged, language)
+			continue
+		}
+
+		merged[language] = translation
+	}
+
+	return merged
+}
+
+func (t Translations) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+
+	value, err := json.Marshal(t)
+	return string(value), err
+}
+
+func (t *Translations) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(src), t)
+	case []byte:
+		return json.Unmarshal(src, t)
+	default:
+		return fmt.Errorf("unsupported translations type %T", src)
+	}
+}
+
+func (p *Place) Localize(languages []string) {
+	p.Name, p.Description = p.Translations.Localize(languages, p.Name, p.Description)
+}
+
+func (e *Event) Localize(languages []string) {
+	e.Name, e.Description = e.Translations.Localize(languages, e.Name, e.Description)
+}
+
+func (r *Route) Localize(languages []string) {
+	r.Name, r.Description = r.Translations.Localize(languages, r.Name, r.Description)
+}
+
+func (c *Company) Localize(languages []string) {
+	c.Name, c.Description = c.Translations.Localize(languages, c.Name, c.Description)
+}
+
+func (a *Achievements) Localize(languages []string) {
+	a.Name, a.Description = a.Translations.Localize(languages, a.Name, a.Description)
+}
+
+func (i *TrendingItem) Localize(languages []string) {
+	i.Name, _ = i.Translations.Localize(languages, i.Name, "")
+}
+
+func (c *RecommendationCandidate) Localize(languages []string) {
+	c.Name, _ = c.Translations.Localize(languages, c.Name, "")
+}
@@ -0,0 +1,14 @@
+package models
+
+import "github.com/google/uuid"
+
+// TrendingItem место, событие или маршрут из выдачи "популярное сейчас"
+type TrendingItem struct {
+	EntityType    string       `json:"entity_type" db:"entity_type" enums:"place,event,route"`
+	EntityID      uuid.UUID    `json:"entity_id" db:"entity_id"`
+	Name          string       `json:"name" db:"name"`
+	Translations  Translations `json:"-" db:"translations"`
+	TrendingScore float64      `json:"trending_score" db:"trending_score"`
+	RatingAvg     float64      `json:"rating_avg" db:"rating_avg"`
+	RatingCount   int          `json:"rating_count" db:"rating_count"`
+}
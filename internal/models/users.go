@@ -7,17 +7,45 @@ import (
 
 type (
 	User struct {
-		ID               uuid.UUID `json:"_id" db:"id"`
-		VkID             int64     `json:"vk_id" db:"vk_id"`
-		IsAdmin          bool      `json:"is_admin,omitempty" db:"is_admin"`
-		PassedOnboarding bool      `json:"passed_onboarding" db:"passed_onboarding"`
-		SelectedGeo      string    `json:"selected_geo" db:"selected_geo"`
+		ID                 uuid.UUID  `json:"_id" db:"id"`
+		VkID               int64      `json:"vk_id" db:"vk_id"`
+		PassedOnboarding   bool       `json:"passed_onboarding" db:"passed_onboarding"`
+		SelectedGeo        *GeoPoint  `json:"selected_geo" db:"selected_geo"`
+		SelectedLocalityID *uuid.UUID `json:"selected_locality_id" db:"selected_locality_id"`
+		HomeCityID         *uuid.UUID `json:"home_city_id" db:"home_city_id"`
+		FirstSeenAt        time.Time  `json:"first_seen_at" db:"first_seen_at"`
+		// LastSeenAt обновляется не чаще раза в USER_CACHE_TTL, запросы по ключу API его не меняют
+		LastSeenAt time.Time `json:"last_seen_at" db:"last_seen_at"`
+		// Platform и Language из параметров запуска последнего обращения через VK
+		Platform string `json:"platform" db:"platform"`
+		Language string `json:"language" db:"language"`
+	}
+
+	// UserChanges изменения профиля пользователя. Нулевые поля не меняют сохраненные значения,
+	// SelectedGeo заменяет выбранную точку вместе с ее населенным пунктом SelectedLocalityID.
+	UserChanges struct {
+		PassedOnboarding   bool
+		SelectedGeo        *GeoPoint
+		SelectedLocalityID *uuid.UUID
+		HomeCityID         *uuid.UUID
+	}
+
+	UserSummary struct {
+		ID           uuid.UUID `json:"_id" db:"id"`
+		VkID         int64     `json:"vk_id" db:"vk_id"`
+		ReviewsCount int       `json:"reviews_count" db:"reviews_count"`
 	}
 
 	UserGetMeResponse struct {
 		*User
-		CurrentGeo string `json:"current_geo"`
-		GeoText    string `json:"geo_text"`
+		SelectedLocality *Locality `json:"selected_locality"`
+		HomeCity         *Locality `json:"home_city"`
+		CurrentGeo       *GeoPoint `json:"current_geo"`
+		// MapCenter центр карты по умолчанию: выбранная точка, центр домашнего города или местоположение по IP
+		MapCenter   *GeoPoint `json:"map_center"`
+		GeoText     string    `json:"geo_text"`
+		Roles       []string  `json:"roles"`
+		Permissions []string  `json:"permissions"`
 	}
 
 	UserAchievementsRel struct {
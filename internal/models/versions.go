@@ -0,0 +1,163 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Действия, которыми создаются версии сущностей
+const (
+	VersionActionInitial    = "initial"
+	VersionActionCreate     = "create"
+	VersionActionEdit       = "edit"
+	VersionActionSuggestion = "suggestion"
+	VersionActionRevert     = "revert"
+	VersionActionMerge      = "merge"
+)
+
+// snapshotExcludedFields поля, которые не относятся к содержимому сущности: идентификатор, владелец,
+// статус удаления и вычисляемые значения. Они не попадают в историю и не меняются при откате.
+var snapshotExcludedFields = []string{"_id", "company_id", "user_id", "is_released", "is_deleted", "merged_into", "rating", "trending_score", "open_now"}
+
+type (
+	// EntitySnapshot редактируемые поля сущности в JSON-представлении
+	EntitySnapshot map[string]any
+
+	// EntityVersion версия сущности. Первая версия сущностей, созданных до появления истории, имеет действие initial
+	EntityVersion struct {
+		ID            uuid.UUID      `json:"_id" db:"id"`
+		EntityType    string         `json:"entity_type" db:"entity_type" enums:"place,event,route,company"`
+		EntityID      uuid.UUID      `json:"entity_id" db:"entity_id"`
+		Version       int            `json:"version" db:"version"`
+		Action        string         `json:"action" db:"action" enums:"initial,create,edit,suggestion,revert,merge"`
+		UserID        *uuid.UUID     `json:"user_id" db:"user_id"`
+		SuggestionID  *uuid.UUID     `json:"suggestion_id" db:"suggestion_id"`
+		Snapshot      EntitySnapshot `json:"snapshot" db:"snapshot" swaggertype:"object"`
+		ChangedFields pq.StringArray `json:"changed_fields" db:"changed_fields" swaggertype:"array,string"`
+		CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	}
+
+	// EntityChange изменение сущности, которое сохраняется в одной транзакции со своей версией
+	EntityChange struct {
+		// Entity сохраняемая сущность: *Place, *Event, *RouteWithGeo или *Company
+		Entity any
+		// Version версия со снимком сущности после изменения
+		Version EntityVersion
+		// Before снимок сущности до изменения
+		Before EntitySnapshot
+	}
+
+	// FieldChange изменение одного поля между версиями
+	FieldChange struct {
+		Field string `json:"field"`
+		From  any    `json:"from"`
+		To    any    `json:"to"`
+	}
+
+	// VersionDiff разница между двумя версиями сущности
+	VersionDiff struct {
+		EntityType  string        `json:"entity_type"`
+		EntityID    uuid.UUID     `json:"entity_id"`
+		FromVersion int           `json:"from_version"`
+		ToVersion   int           `json:"to_version"`
+		Changes     []FieldChange `json:"changes"`
+	}
+)
+
+// NewEntitySnapshot снимает редактируемые поля сущности по ее JSON-представлению.
+func NewEntitySnapshot(entity any) (EntitySnapshot, error) {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot EntitySnapshot
+	if err = json.Unmarshal(value, &snapshot); err != nil {
+		return nil, err
+	}
+
+	for _, field := range snapshotExcludedFields {
+		delete(snapshot, field)
+	}
+
+	return snapshot, nil
+}
+
+// Diff возвращает поля, отличающиеся в snapshot от previous, в алфавитном порядке.
+func (s EntitySnapshot) Diff(previous EntitySnapshot) []FieldChange {
+	fields := make(map[string]bool, len(s))
+	for field := range s {
+		fields[field] = true
+	}
+	for field := range previous {
+		fields[field] = true
+	}
+
+	changes := make([]FieldChange, 0)
+	for field := range fields {
+		if !reflect.DeepEqual(previous[field], s[field]) {
+			changes = append(changes, FieldChange{Field: field, From: previous[field], To: s[field]})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+// ChangedFields возвращает названия полей, отличающихся от previous.
+func (s EntitySnapshot) ChangedFields(previous EntitySnapshot) pq.StringArray {
+	changes := s.Diff(previous)
+
+	fields := make(pq.StringArray, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+
+	return fields
+}
+
+// Apply переносит значения снимка в сущность entity (указатель на структуру).
+// Поля сущности, отсутствующие в снимке, не меняются.
+func (s EntitySnapshot) Apply(entity any) error {
+	current, err := NewEntitySnapshot(entity)
+	if err != nil {
+		return err
+	}
+
+	for field, value := range s {
+		current[field] = value
+	}
+
+	value, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(value, entity)
+}
+
+func (s EntitySnapshot) Value() (driver.Value, error) {
+	value, err := json.Marshal(s)
+	return string(value), err
+}
+
+func (s *EntitySnapshot) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(src), s)
+	case []byte:
+		return json.Unmarshal(src, s)
+	default:
+		return fmt.Errorf("unsupported entity snapshot type %T", src)
+	}
+}
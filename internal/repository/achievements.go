@@ -10,11 +10,12 @@ import (
 func (p *Pg) NewAchievement(ctx context.Context, achievement models.Achievements) (*models.Achievements, error) {
 	id, err := p.db.ExecContextWithReturnID(
 		ctx,
-		"INSERT INTO achievements (name, description, icon, coins) VALUES ($1, $2, $3, $4)",
+		"INSERT INTO achievements (name, description, icon, coins, translations) VALUES ($1, $2, $3, $4, $5)",
 		achievement.Name,
 		achievement.Description,
 		achievement.Icon,
 		achievement.Coins,
+		achievement.Translations,
 	)
 	if err != nil {
 		return nil, err
@@ -41,11 +42,12 @@ func (p *Pg) GetAllAchievements(ctx context.Context) ([]models.Achievements, err
 func (p *Pg) SaveAchievement(ctx context.Context, achievement *models.Achievements) error {
 	_, err := p.db.ExecContext(
 		ctx,
-		"UPDATE achievements SET name = $1, description = $2, icon = $3, coins = $4 WHERE id = $5",
+		"UPDATE achievements SET name = $1, description = $2, icon = $3, coins = $4, translations = $5 WHERE id = $6",
 		achievement.Name,
 		achievement.Description,
 		achievement.Icon,
 		achievement.Coins,
+		achievement.Translations,
 		achievement.ID,
 	)
 
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+const apiKeysQuery = `
+	SELECT k.*, u.vk_id
+	FROM api_keys k
+	JOIN users u ON u.id = k.user_id
+`
+
+func (p *Pg) NewAPIKey(ctx context.Context, key models.APIKey) (*models.APIKey, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		`INSERT INTO api_keys (user_id, created_by, name, prefix, key_hash, scopes, company_id, daily_quota)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		key.UserID,
+		key.CreatedBy,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.CompanyID,
+		key.DailyQuota,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key.ID = id
+	key.CreatedAt = time.Now()
+	return &key, nil
+}
+
+func (p *Pg) SaveAPIKey(ctx context.Context, key *models.APIKey) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"UPDATE api_keys SET name = $1, scopes = $2, company_id = $3, daily_quota = $4 WHERE id = $5",
+		key.Name,
+		key.Scopes,
+		key.CompanyID,
+		key.DailyQuota,
+		key.ID,
+	)
+
+	return err
+}
+
+func (p *Pg) GetAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	var key models.APIKey
+	err := p.db.GetContext(ctx, &key, apiKeysQuery+"WHERE k.id = $1", id)
+
+	return &key, err
+}
+
+// GetAPIKeyByPrefix читает ключ с мастера, чтобы отозванный ключ переставал действовать сразу, не дожидаясь реплики.
+func (p *Pg) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := p.db.GetMaster().GetContext(ctx, &key, apiKeysQuery+"WHERE k.prefix = $1", prefix)
+
+	return &key, err
+}
+
+func (p *Pg) GetAllAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	keys := make([]models.APIKey, 0)
+	err := p.db.SelectContext(ctx, &keys, apiKeysQuery+"ORDER BY k.created_at DESC")
+
+	return keys, err
+}
+
+// RevokeAPIKey отзывает ключ. Возвращает false, если ключ уже был отозван.
+func (p *Pg) RevokeAPIKey(ctx context.Context, id uuid.UUID) (bool, error) {
+	result, err := p.db.ExecContext(ctx, "UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL", id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// UseAPIKey учитывает запрос по ключу одним запросом к мастеру. Возвращает false, если дневная квота уже исчерпана.
+func (p *Pg) UseAPIKey(ctx context.Context, id uuid.UUID, dailyQuota int) (bool, error) {
+	var used bool
+	err := p.db.GetMaster().GetContext(
+		ctx,
+		&used,
+		`WITH usage AS (
+			INSERT INTO api_keys_usage (key_id, day, requests) VALUES ($1, CURRENT_DATE, 1)
+			ON CONFLICT (key_id, day) DO UPDATE SET requests = api_keys_usage.requests + 1
+			WHERE $2 = 0 OR api_keys_usage.requests < $2
+			RETURNING key_id
+		), counter AS (
+			UPDATE api_keys SET requests_count = requests_count + 1, last_used_at = NOW()
+			WHERE id IN (SELECT key_id FROM usage)
+		)
+		SELECT EXISTS (SELECT 1 FROM usage)`,
+		id,
+		dailyQuota,
+	)
+
+	return used, err
+}
+
+// GetAPIKeyUsage возвращает количество запросов по ключу за последние days дней.
+func (p *Pg) GetAPIKeyUsage(ctx context.Context, id uuid.UUID, days int) ([]models.APIKeyUsage, error) {
+	usage := make([]models.APIKeyUsage, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&usage,
+		"SELECT day, requests FROM api_keys_usage WHERE key_id = $1 AND day > CURRENT_DATE - $2::int ORDER BY day DESC",
+		id,
+		days,
+	)
+
+	return usage, err
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func (p *Pg) NewBlob(ctx context.Context, blob models.Blob) (*models.Blob, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		`
+			INSERT INTO blobs (owner_id, key, url, thumbnail_key, thumbnail_url, content_type, size, width, height, created_at) 
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`,
+		blob.OwnerID,
+		blob.Key,
+		blob.URL,
+		blob.ThumbnailKey,
+		blob.ThumbnailURL,
+		blob.ContentType,
+		blob.Size,
+		blob.Width,
+		blob.Height,
+		blob.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	blob.ID = id
+	return &blob, nil
+}
+
+func (p *Pg) GetBlob(ctx context.Context, id uuid.UUID) (*models.Blob, error) {
+	var blob models.Blob
+	err := p.db.GetContext(ctx, &blob, "SELECT * FROM blobs WHERE id = $1", id)
+
+	return &blob, err
+}
+
+func (p *Pg) GetBlobByKey(ctx context.Context, key string) (*models.Blob, error) {
+	var blob models.Blob
+	err := p.db.GetContext(ctx, &blob, "SELECT * FROM blobs WHERE key = $1 OR thumbnail_key = $1", key)
+
+	return &blob, err
+}
+
+func (p *Pg) GetBlobsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Blob, error) {
+	var blobs []models.Blob
+	err := p.db.SelectContext(ctx, &blobs, "SELECT * FROM blobs WHERE id = ANY($1::uuid[])", uuidsToArray(ids))
+
+	return blobs, err
+}
+
+type reviewPhoto struct {
+	models.Blob
+	ReviewID uuid.UUID `db:"review_id"`
+}
+
+func (p *Pg) getReviewsPhotos(ctx context.Context, reviewIDs []uuid.UUID) ([]reviewPhoto, error) {
+	var photos []reviewPhoto
+	err := p.db.SelectContext(
+		ctx,
+		&photos,
+		`
+			SELECT b.*, rp.review_id FROM reviews_photos rp
+				JOIN blobs b ON b.id = rp.blob_id
+				WHERE rp.review_id = ANY($1::uuid[])
+				ORDER BY rp.position
+		`,
+		uuidsToArray(reviewIDs),
+	)
+
+	return photos, err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// NewBookmark добавляет закладку. Повторная закладка на тот же объект не создает новую запись.
+func (p *Pg) NewBookmark(ctx context.Context, bookmark models.Bookmark) (*models.Bookmark, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		`INSERT INTO bookmarks (user_id, entity_type, entity_id) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, entity_type, entity_id) DO UPDATE SET user_id = EXCLUDED.user_id`,
+		bookmark.UserID,
+		bookmark.EntityType,
+		bookmark.EntityID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark.ID = id
+	bookmark.CreatedAt = time.Now()
+	return &bookmark, nil
+}
+
+func (p *Pg) DeleteBookmark(ctx context.Context, userID uuid.UUID, entityType string, entityID uuid.UUID) (bool, error) {
+	result, err := p.db.ExecContext(
+		ctx,
+		"DELETE FROM bookmarks WHERE user_id = $1 AND entity_type = $2 AND entity_id = $3",
+		userID,
+		entityType,
+		entityID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected != 0, err
+}
+
+func (p *Pg) GetUserBookmarks(ctx context.Context, userID uuid.UUID) ([]models.Bookmark, error) {
+	bookmarks := make([]models.Bookmark, 0)
+	err := p.db.SelectContext(ctx, &bookmarks, "SELECT * FROM bookmarks WHERE user_id = $1 ORDER BY created_at DESC", userID)
+
+	return bookmarks, err
+}
+
+func (p *Pg) NewCheckin(ctx context.Context, checkin models.Checkin) (*models.Checkin, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		"INSERT INTO checkins (user_id, entity_type, entity_id) VALUES ($1, $2, $3)",
+		checkin.UserID,
+		checkin.EntityType,
+		checkin.EntityID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	checkin.ID = id
+	checkin.CreatedAt = time.Now()
+	return &checkin, nil
+}
+
+func (p *Pg) GetUserCheckins(ctx context.Context, userID uuid.UUID) ([]models.Checkin, error) {
+	checkins := make([]models.Checkin, 0)
+	err := p.db.SelectContext(ctx, &checkins, "SELECT * FROM checkins WHERE user_id = $1 ORDER BY created_at DESC", userID)
+
+	return checkins, err
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func (p *Pg) NewPlaceClaim(ctx context.Context, claim models.PlaceClaim) (*models.PlaceClaim, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		"INSERT INTO place_claims (place_id, company_id, user_id, evidence, attachments) VALUES ($1, $2, $3, $4, $5)",
+		claim.PlaceID,
+		claim.CompanyID,
+		claim.UserID,
+		claim.Evidence,
+		claim.Attachments,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claim.ID = id
+	claim.Status = models.ClaimStatusPending
+	claim.CreatedAt = time.Now()
+	return &claim, nil
+}
+
+func (p *Pg) GetPlaceClaim(ctx context.Context, id uuid.UUID) (*models.PlaceClaim, error) {
+	var claim models.PlaceClaim
+	err := p.db.GetContext(ctx, &claim, "SELECT * FROM place_claims WHERE id = $1", id)
+
+	return &claim, err
+}
+
+// GetPlaceClaims возвращает заявки на владение местами, старые первыми. Пустой status и nil companyID не ограничивают выборку.
+func (p *Pg) GetPlaceClaims(ctx context.Context, status string, companyID *uuid.UUID) ([]models.PlaceClaim, error) {
+	claims := make([]models.PlaceClaim, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&claims,
+		`SELECT * FROM place_claims
+		WHERE ($1 = '' OR status = $1) AND ($2::uuid IS NULL OR company_id = $2)
+		ORDER BY created_at`,
+		status,
+		companyID,
+	)
+
+	return claims, err
+}
+
+// ReviewPlaceClaim одобряет или отклоняет заявку, если она еще не рассмотрена. При одобрении компания становится
+// владельцем места, а остальные заявки на это место отклоняются. Возвращает false, если заявка уже рассмотрена.
+func (p *Pg) ReviewPlaceClaim(ctx context.Context, claim *models.PlaceClaim, status string, reviewerID uuid.UUID, comment string) (bool, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE place_claims SET status = $1, reviewer_id = $2, review_comment = $3, reviewed_at = NOW()
+		WHERE id = $4 AND status = 'pending'`,
+		status,
+		reviewerID,
+		comment,
+		claim.ID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	if status == models.ClaimStatusApproved {
+		if _, err = tx.ExecContext(ctx, "UPDATE places SET company_id = $1 WHERE id = $2", claim.CompanyID, claim.PlaceID); err != nil {
+			return false, err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE place_claims SET status = 'rejected', reviewer_id = $1, review_comment = 'Place is owned by another company', reviewed_at = NOW()
+			WHERE place_id = $2 AND status = 'pending'`,
+			reviewerID,
+			claim.PlaceID,
+		)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	now := time.Now()
+	claim.Status = status
+	claim.ReviewerID = &reviewerID
+	claim.ReviewComment = comment
+	claim.ReviewedAt = &now
+	return true, tx.Commit()
+}
@@ -7,14 +7,17 @@ import (
 	"github.com/google/uuid"
 )
 
-func (p *Pg) NewCompany(ctx context.Context, company models.Company) (*models.Company, error) {
-	id, err := p.db.ExecContextWithReturnID(
+// NewCompany создает компанию вместе с ее первой версией version.
+func (p *Pg) NewCompany(ctx context.Context, company models.Company, version models.EntityVersion) (*models.Company, error) {
+	id, err := p.createEntity(
 		ctx,
-		"INSERT INTO companies (user_id, name, description, photo_card) VALUES ($1, $2, $3, $4)",
+		version,
+		"INSERT INTO companies (user_id, name, description, photo_card, translations) VALUES ($1, $2, $3, $4, $5)",
 		company.UserID,
 		company.Name,
 		company.Description,
 		company.PhotoCard,
+		company.Translations,
 	)
 	if err != nil {
 		return nil, err
@@ -67,13 +70,18 @@ func (p *Pg) GetCompaniesByVkID(ctx context.Context, vkID int64) ([]companyWithR
 }
 
 func (p *Pg) SaveCompany(ctx context.Context, company *models.Company) error {
-	_, err := p.db.ExecContext(
+	return saveCompany(ctx, p.db, company)
+}
+
+func saveCompany(ctx context.Context, db execer, company *models.Company) error {
+	_, err := db.ExecContext(
 		ctx,
-		"UPDATE companies SET is_released = $1, name = $2, description = $3, photo_card = $4  WHERE id = $5",
+		"UPDATE companies SET is_released = $1, name = $2, description = $3, photo_card = $4, translations = $5 WHERE id = $6",
 		company.IsReleased,
 		company.Name,
 		company.Description,
 		company.PhotoCard,
+		company.Translations,
 		company.ID,
 	)
 
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// AddCompanyMember добавляет сотрудника компании и в той же транзакции выдает ему роль company_member
+// от имени actorID. Возвращает false, если сотрудник уже добавлен.
+func (p *Pg) AddCompanyMember(ctx context.Context, actorID *uuid.UUID, companyID uuid.UUID, userID uuid.UUID) (bool, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO companies_members (company_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		companyID,
+		userID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	// Роль выдается и уже добавленному сотруднику, если ее не было
+	if _, err = grantRole(ctx, tx, actorID, userID, models.RoleCompanyMember); err != nil {
+		return false, err
+	}
+
+	return affected > 0, tx.Commit()
+}
+
+// RemoveCompanyMember удаляет сотрудника компании и в той же транзакции отзывает роль company_member
+// от имени actorID, если он больше не состоит ни в одной компании. Возвращает false, если сотрудника не было.
+func (p *Pg) RemoveCompanyMember(ctx context.Context, actorID *uuid.UUID, companyID uuid.UUID, userID uuid.UUID) (bool, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM companies_members WHERE company_id = $1 AND user_id = $2",
+		companyID,
+		userID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	var hasMemberships bool
+	if err = tx.GetContext(
+		ctx,
+		&hasMemberships,
+		"SELECT EXISTS (SELECT 1 FROM companies_members WHERE user_id = $1)",
+		userID,
+	); err != nil {
+		return false, err
+	}
+
+	if !hasMemberships {
+		if _, err = revokeRole(ctx, tx, actorID, userID, models.RoleCompanyMember); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+func (p *Pg) GetCompanyMembers(ctx context.Context, companyID uuid.UUID) ([]models.CompanyMember, error) {
+	members := make([]models.CompanyMember, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&members,
+		`SELECT m.company_id, m.user_id, u.vk_id, m.created_at
+		FROM companies_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.company_id = $1
+		ORDER BY m.created_at`,
+		companyID,
+	)
+
+	return members, err
+}
+
+// IsCompanyMember проверяет, что пользователь владеет компанией или является ее сотрудником.
+func (p *Pg) IsCompanyMember(ctx context.Context, companyID uuid.UUID, userID uuid.UUID) (bool, error) {
+	var isMember bool
+	err := p.db.GetContext(
+		ctx,
+		&isMember,
+		`SELECT EXISTS (SELECT 1 FROM companies WHERE id = $1 AND user_id = $2)
+			OR EXISTS (SELECT 1 FROM companies_members WHERE company_id = $1 AND user_id = $2)`,
+		companyID,
+		userID,
+	)
+
+	return isMember, err
+}
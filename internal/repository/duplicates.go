@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type placeDuplicatePair struct {
+	PlaceID        uuid.UUID `db:"place_id"`
+	DuplicateID    uuid.UUID `db:"duplicate_id"`
+	NameSimilarity float64   `db:"name_similarity"`
+	DistanceKm     float64   `db:"distance_km"`
+	Score          float64   `db:"score"`
+}
+
+// GetPlaceDuplicates возвращает пары мест, названия которых похожи не меньше чем на minSimilarity (от 0.3 до 1,
+// сходство триграмм pg_trgm), а расстояние между ними не больше maxDistanceKm. Пары, отмеченные как не дубли,
+// пропускаются. Сначала возвращаются самые вероятные дубли.
+func (p *Pg) GetPlaceDuplicates(ctx context.Context, minSimilarity, maxDistanceKm float64, cityID *uuid.UUID, limit int) ([]models.PlaceDuplicate, error) {
+	pairs := make([]placeDuplicatePair, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&pairs,
+		// Оператор % использует индекс по триграммам и отсекает пары со сходством меньше 0.3
+		`SELECT *, 0.7 * name_similarity + 0.3 * (1 - distance_km / $2) AS score FROM (
+			SELECT a.id AS place_id, b.id AS duplicate_id,
+				similarity(lower(a.name), lower(b.name)) AS name_similarity,
+				distance_km(a.address_lat, a.address_lng, b.address_lat, b.address_lng) AS distance_km
+			FROM places a
+			JOIN places b ON a.id < b.id AND lower(a.name) % lower(b.name)
+			WHERE a.is_deleted = false AND b.is_deleted = false
+				AND ($3::uuid IS NULL OR a.city_id IN (SELECT id FROM localities WHERE id = $3 OR parent_id = $3))
+		) pairs
+		WHERE name_similarity >= $1 AND distance_km <= $2 AND NOT EXISTS (
+			SELECT 1 FROM place_duplicate_dismissals d WHERE d.place_id = pairs.place_id AND d.duplicate_id = pairs.duplicate_id
+		)
+		ORDER BY score DESC
+		LIMIT $4`,
+		minSimilarity,
+		maxDistanceKm,
+		cityID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(pairs)*2)
+	for _, pair := range pairs {
+		ids = append(ids, pair.PlaceID, pair.DuplicateID)
+	}
+
+	places := make([]models.Place, 0, len(ids))
+	if err = p.db.SelectContext(ctx, &places, "SELECT * FROM places WHERE id::text = ANY($1)", uuidsToArray(ids)); err != nil {
+		return nil, err
+	}
+
+	placesByID := make(map[uuid.UUID]models.Place, len(places))
+	for _, place := range withOpenNow(places) {
+		placesByID[place.ID] = place
+	}
+
+	duplicates := make([]models.PlaceDuplicate, 0, len(pairs))
+	for _, pair := range pairs {
+		duplicates = append(duplicates, models.PlaceDuplicate{
+			Place:          placesByID[pair.PlaceID],
+			Duplicate:      placesByID[pair.DuplicateID],
+			NameSimilarity: pair.NameSimilarity,
+			DistanceM:      pair.DistanceKm * 1000,
+			Score:          pair.Score,
+		})
+	}
+
+	return duplicates, nil
+}
+
+// DismissPlaceDuplicate отмечает пару мест как не дубли, чтобы она больше не попадала в список.
+func (p *Pg) DismissPlaceDuplicate(ctx context.Context, placeID, duplicateID, userID uuid.UUID) error {
+	if bytes.Compare(placeID[:], duplicateID[:]) > 0 {
+		placeID, duplicateID = duplicateID, placeID
+	}
+
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO place_duplicate_dismissals (place_id, duplicate_id, user_id) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		placeID,
+		duplicateID,
+		userID,
+	)
+
+	return err
+}
+
+// MergePlaces объединяет дубль duplicateID с местом placeID: переносит отзывы, места в маршрутах, закладки,
+// посещения и просмотры, помечает дубль удаленным и запоминает, куда перенаправлять запросы к нему.
+// Отзывы и закладки пользователей, у которых они уже есть у оставшегося места, остаются у дубля.
+// В истории обоих мест сохраняется версия объединения от имени userID.
+func (p *Pg) MergePlaces(ctx context.Context, placeID, duplicateID, userID uuid.UUID) error {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Блокировки в одном порядке, чтобы встречные объединения не взаимоблокировались
+	first, second := placeID, duplicateID
+	if bytes.Compare(first[:], second[:]) > 0 {
+		first, second = second, first
+	}
+	if err = lockEntity(ctx, tx, "places", first, false); err != nil {
+		return err
+	}
+	if err = lockEntity(ctx, tx, "places", second, false); err != nil {
+		return err
+	}
+
+	// Данные дубля ($2) переносятся на оставшееся место ($1)
+	moves := []string{
+		`UPDATE reviews SET entity_id = $1 WHERE entity_type = 'place' AND entity_id = $2 AND owner_id NOT IN (
+			SELECT owner_id FROM reviews WHERE entity_type = 'place' AND entity_id = $1
+		)`,
+		`UPDATE routes SET places = CASE
+			WHEN $1::text = ANY(places) THEN array_remove(places, $2::text)
+			ELSE array_replace(places, $2::text, $1::text)
+		END WHERE $2::text = ANY(places)`,
+		`UPDATE bookmarks SET entity_id = $1 WHERE entity_type = 'place' AND entity_id = $2 AND user_id NOT IN (
+			SELECT user_id FROM bookmarks WHERE entity_type = 'place' AND entity_id = $1
+		)`,
+		`UPDATE checkins SET entity_id = $1 WHERE entity_type = 'place' AND entity_id = $2`,
+		`INSERT INTO entity_views (entity_type, entity_id, day, views)
+			SELECT entity_type, $1::uuid, day, views FROM entity_views WHERE entity_type = 'place' AND entity_id = $2
+		ON CONFLICT (entity_type, entity_id, day) DO UPDATE SET views = entity_views.views + EXCLUDED.views`,
+		// Ранее объединенные с дублем места перенаправляются сразу на оставшееся место
+		`UPDATE places SET merged_into = $1 WHERE merged_into = $2`,
+		`UPDATE places SET is_deleted = true, merged_into = $1 WHERE id = $2`,
+	}
+	for _, query := range moves {
+		if _, err = tx.ExecContext(ctx, query, placeID, duplicateID); err != nil {
+			return err
+		}
+	}
+
+	// Что не удалось перенести, у дубля ($1) скрывается
+	cleanups := []string{
+		"UPDATE reviews SET is_deleted = true WHERE entity_type = 'place' AND entity_id = $1",
+		"DELETE FROM bookmarks WHERE entity_type = 'place' AND entity_id = $1",
+		"DELETE FROM entity_views WHERE entity_type = 'place' AND entity_id = $1",
+		`UPDATE place_claims SET status = 'rejected', review_comment = 'Place is merged into another place', reviewed_at = NOW()
+		WHERE place_id = $1 AND status = 'pending'`,
+	}
+	for _, query := range cleanups {
+		if _, err = tx.ExecContext(ctx, query, duplicateID); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range []uuid.UUID{placeID, duplicateID} {
+		if err = updateRatingSummary(ctx, tx, "places", models.EntityTypePlace, id); err != nil {
+			return err
+		}
+
+		if err = recordPlaceMerge(ctx, tx, id, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// recordPlaceMerge сохраняет версию объединения места. Содержимое места при объединении не меняется,
+// поэтому текущий снимок служит и исходной версией, если истории у места еще нет.
+func recordPlaceMerge(ctx context.Context, tx *sqlx.Tx, placeID, userID uuid.UUID) error {
+	var place models.Place
+	if err := tx.GetContext(ctx, &place, "SELECT * FROM places WHERE id = $1", placeID); err != nil {
+		return err
+	}
+
+	snapshot, err := models.NewEntitySnapshot(&place)
+	if err != nil {
+		return err
+	}
+
+	_, err = newEntityVersion(ctx, tx, models.EntityVersion{
+		EntityType: models.EntityTypePlace,
+		EntityID:   placeID,
+		Action:     models.VersionActionMerge,
+		UserID:     &userID,
+		Snapshot:   snapshot,
+	}, snapshot)
+
+	return err
+}
+
+// GetPlaceMergedInto возвращает место, в которое объединен дубль, или nil, если место не объединялось.
+func (p *Pg) GetPlaceMergedInto(ctx context.Context, placeID uuid.UUID) (*uuid.UUID, error) {
+	var mergedInto *uuid.UUID
+	err := p.db.GetContext(ctx, &mergedInto, "SELECT merged_into FROM places WHERE id = $1", placeID)
+
+	return mergedInto, err
+}
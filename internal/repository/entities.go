@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var entityTables = map[string]string{
+	models.EntityTypePlace: "places",
+	models.EntityTypeEvent: "events",
+	models.EntityTypeRoute: "routes",
+}
+
+func entityTable(entityType string) (string, error) {
+	table, ok := entityTables[entityType]
+	if !ok {
+		return "", fmt.Errorf("unknown entity type %q", entityType)
+	}
+
+	return table, nil
+}
+
+// execer выполняет изменяющие запросы на мастере или внутри транзакции
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// deletedFilter возвращает условие отбора по is_deleted для публичных и административных выборок.
+func deletedFilter(includeDeleted bool) string {
+	if includeDeleted {
+		return "TRUE"
+	}
+
+	return "is_deleted = false"
+}
+
+// GetEntityCompanyID возвращает компанию, которой принадлежит сущность, или nil, если владельца нет.
+func (p *Pg) GetEntityCompanyID(ctx context.Context, entityType string, entityID uuid.UUID, includeDeleted bool) (*uuid.UUID, error) {
+	table, err := entityTable(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	var companyID *uuid.UUID
+	err = p.db.GetContext(ctx, &companyID, fmt.Sprintf("SELECT company_id FROM %s WHERE id = $1 AND %s", table, deletedFilter(includeDeleted)), entityID)
+
+	return companyID, err
+}
+
+// lockEntity блокирует строку сущности до конца транзакции,
+// чтобы параллельные отзывы не перезаписали сводку оценок друг друга.
+func lockEntity(ctx context.Context, tx *sqlx.Tx, table string, entityID uuid.UUID, includeDeleted bool) error {
+	var id uuid.UUID
+	return tx.GetContext(ctx, &id, fmt.Sprintf("SELECT id FROM %s WHERE id = $1 AND %s FOR UPDATE", table, deletedFilter(includeDeleted)), entityID)
+}
+
+// SetEntityDeleted помечает сущность удаленной или восстанавливает ее.
+// Возвращает false, если сущность уже находится в нужном состоянии.
+func (p *Pg) SetEntityDeleted(ctx context.Context, entityType string, entityID uuid.UUID, deleted bool) (bool, error) {
+	table, err := entityTable(entityType)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := p.db.ExecContext(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET is_deleted = $1 WHERE id = $2 AND is_deleted = $3", table),
+		deleted,
+		entityID,
+		!deleted,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
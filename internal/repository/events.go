@@ -8,21 +8,34 @@ import (
 	"github.com/google/uuid"
 )
 
-func (p *Pg) NewEvent(ctx context.Context, event models.Event) (*models.Event, error) {
-	id, err := p.db.ExecContextWithReturnID(
+// NewEvent создает событие вместе с ее первой версией version.
+func (p *Pg) NewEvent(ctx context.Context, event models.Event, version models.EntityVersion) (*models.Event, error) {
+	id, err := p.createEntity(
 		ctx,
-		"INSERT INTO events (company_id, name, description, carousel, tags, icon, start_time, address_text, address_lng, address_lat, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+		version,
+		`INSERT INTO events (
+			company_id, name, description, carousel, tag_ids, icon, start_time, address_text, address_lng, address_lat, is_deleted,
+			address_country, address_region, address_locality, address_street, address_building, address_postal_code, city_id, translations
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
 		event.CompanyID,
 		event.Name,
 		event.Description,
 		event.Carousel,
-		event.Tags,
+		event.TagIDs,
 		event.Icon,
 		event.StartTime,
 		event.AddressText,
 		event.AddressLng,
 		event.AddressLat,
 		event.IsDeleted,
+		event.Country,
+		event.Region,
+		event.Locality,
+		event.Street,
+		event.Building,
+		event.PostalCode,
+		event.CityID,
+		event.Translations,
 	)
 	if err != nil {
 		return nil, err
@@ -32,104 +45,108 @@ func (p *Pg) NewEvent(ctx context.Context, event models.Event) (*models.Event, e
 	return &event, nil
 }
 
-func (p *Pg) GetEvent(ctx context.Context, id uuid.UUID) (*models.Event, error) {
+func (p *Pg) GetEvent(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Event, error) {
 	var event models.Event
-	err := p.db.GetContext(ctx, &event, "SELECT * FROM events WHERE id = $1", id)
+	err := p.db.GetContext(ctx, &event, "SELECT * FROM events WHERE id = $1 AND "+deletedFilter(includeDeleted), id)
 
 	return &event, err
 }
 
-func (p *Pg) SearchEvents(ctx context.Context, q string) ([]models.Event, error) {
+func (p *Pg) SearchEvents(ctx context.Context, q string, filter models.ListFilter) ([]models.Event, error) {
 	q = fmt.Sprintf("%%%s%%", q)
+	where, args := listFilter(filter, 2)
 
 	var events []models.Event
 	err := p.db.SelectContext(
 		ctx,
 		&events,
 		`SELECT * FROM events
-				WHERE 
+				WHERE is_deleted = false AND (
 					LOWER(name) LIKE LOWER($1) OR
+					LOWER(translated_names(translations)) LIKE LOWER($1) OR
 					LOWER(description) LIKE LOWER($1) OR
-					LOWER(address_text) LIKE LOWER($1);
-				`,
-		q,
+					LOWER(address_text) LIKE LOWER($1)
+				) AND `+where,
+		append([]any{q}, args...)...,
 	)
 
 	return events, err
 }
 
-func (p *Pg) GetAllEvents(ctx context.Context) ([]models.Event, error) {
+func (p *Pg) GetAllEvents(ctx context.Context, includeDeleted bool, filter models.ListFilter) ([]models.Event, error) {
+	where, args := listFilter(filter, 1)
+
 	var events []models.Event
-	err := p.db.SelectContext(ctx, &events, "SELECT * FROM events")
+	err := p.db.SelectContext(ctx, &events, "SELECT * FROM events WHERE "+deletedFilter(includeDeleted)+" AND "+where, args...)
 
 	return events, err
 }
 
-func (p *Pg) GetAllEventsByCompanyID(ctx context.Context, companyID uuid.UUID) ([]models.Event, error) {
+func (p *Pg) GetAllEventsByCompanyID(ctx context.Context, companyID uuid.UUID, filter models.ListFilter) ([]models.Event, error) {
+	where, args := listFilter(filter, 2)
+
 	var events []models.Event
-	err := p.db.SelectContext(ctx, &events, "SELECT * FROM events WHERE company_id = $1", companyID)
+	err := p.db.SelectContext(ctx, &events, "SELECT * FROM events WHERE company_id = $1 AND is_deleted = false AND "+where, append([]any{companyID}, args...)...)
 
 	return events, err
 }
 
-func (p *Pg) SaveEvent(ctx context.Context, event *models.Event) error {
-	_, err := p.db.ExecContext(
+// GetEventsNearby возвращает предстоящие события в радиусе radiusKm от точки, ближайшие первыми.
+func (p *Pg) GetEventsNearby(ctx context.Context, center models.GeoPoint, radiusKm float64, limit int, cityID *uuid.UUID) ([]models.Event, error) {
+	events := make([]models.Event, 0)
+	err := p.db.SelectContext(
 		ctx,
-		`
-			UPDATE events 
-				SET name = $1, description = $2, carousel = $3, tags = $4,
-				    icon = $5, start_time = $6, address_text = $7, 
-				    address_lng = $8, address_lat = $9, is_deleted = $10  
-				WHERE id = $11
-		`,
-		event.Name, event.Description, event.Carousel, event.Tags,
-		event.Icon, event.StartTime, event.AddressText,
-		event.AddressLng, event.AddressLat, event.IsDeleted,
-		event.ID,
+		&events,
+		"SELECT * FROM events WHERE is_deleted = false AND start_time >= NOW() AND "+cityFilter(5)+" AND "+distanceKm+" <= $3 ORDER BY "+distanceKm+" LIMIT $4",
+		center.Lat,
+		center.Lng,
+		radiusKm,
+		limit,
+		cityID,
 	)
 
-	return err
+	return events, err
 }
 
-func (p *Pg) NewReviewEvent(ctx context.Context, reviewEvent models.ReviewEvent) (*models.ReviewEvent, error) {
-	id, err := p.db.ExecContextWithReturnID(
+// GetUpcomingEvents возвращает ближайшие по времени предстоящие события города.
+func (p *Pg) GetUpcomingEvents(ctx context.Context, cityID uuid.UUID, limit int) ([]models.Event, error) {
+	events := make([]models.Event, 0)
+	err := p.db.SelectContext(
 		ctx,
-		"INSERT INTO reviews_events (owner_id, event_id, review_text, stars, created_at, is_deleted) VALUES ($1, $2, $3, $4, $5, $6)",
-		reviewEvent.OwnerID,
-		reviewEvent.EventID,
-		reviewEvent.ReviewText,
-		reviewEvent.Stars,
-		reviewEvent.CreatedAt,
-		reviewEvent.IsDeleted,
+		&events,
+		"SELECT * FROM events WHERE is_deleted = false AND start_time >= NOW() AND "+cityFilter(1)+" ORDER BY start_time LIMIT $2",
+		cityID,
+		limit,
 	)
-	if err != nil {
-		return nil, err
-	}
 
-	reviewEvent.ID = id
-	return &reviewEvent, nil
+	return events, err
+}
+
+func (p *Pg) SaveEvent(ctx context.Context, event *models.Event) error {
+	return saveEvent(ctx, p.db, event)
 }
 
-func (p *Pg) SaveReviewEvent(ctx context.Context, reviewEvent *models.ReviewEvent) error {
-	_, err := p.db.ExecContext(
+func saveEvent(ctx context.Context, db execer, event *models.Event) error {
+	_, err := db.ExecContext(
 		ctx,
-		`UPDATE reviews_events SET review_text = $1, stars = $2 WHERE owner_id = $3 AND event_id = $4`,
-		reviewEvent.ReviewText, reviewEvent.Stars, reviewEvent.OwnerID, reviewEvent.EventID,
+		`
+			UPDATE events 
+				SET name = $1, description = $2, carousel = $3, tag_ids = $4,
+				    icon = $5, start_time = $6, address_text = $7, 
+				    address_lng = $8, address_lat = $9, is_deleted = $10,
+				    address_country = $11, address_region = $12, address_locality = $13,
+				    address_street = $14, address_building = $15, address_postal_code = $16,
+				    city_id = $17, translations = $18
+				WHERE id = $19
+		`,
+		event.Name, event.Description, event.Carousel, event.TagIDs,
+		event.Icon, event.StartTime, event.AddressText,
+		event.AddressLng, event.AddressLat, event.IsDeleted,
+		event.Country, event.Region, event.Locality,
+		event.Street, event.Building, event.PostalCode,
+		event.CityID, event.Translations,
+		event.ID,
 	)
 
 	return err
 }
-
-func (p *Pg) GetReviewEvent(ctx context.Context, ownerID uuid.UUID, eventID uuid.UUID) (*models.ReviewEvent, error) {
-	var reviewEvent models.ReviewEvent
-	err := p.db.GetContext(ctx, &reviewEvent, "SELECT * FROM reviews_events WHERE owner_id = $1 AND event_id = $2 AND is_deleted = false", ownerID, eventID)
-
-	return &reviewEvent, err
-}
-
-func (p *Pg) GetReviewsEvent(ctx context.Context, eventID uuid.UUID) ([]models.ReviewEvent, error) {
-	var reviewEvent []models.ReviewEvent
-	err := p.db.SelectContext(ctx, &reviewEvent, "SELECT * FROM reviews_events WHERE event_id = $1 AND is_deleted = false", eventID)
-
-	return reviewEvent, err
-}
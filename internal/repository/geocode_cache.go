@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+func (p *Pg) GetGeocode(ctx context.Context, key string, maxAge time.Duration) (string, error) {
+	var address string
+	err := p.db.GetContext(
+		ctx,
+		&address,
+		"SELECT address FROM geocode_cache WHERE key = $1 AND updated_at > $2",
+		key,
+		time.Now().Add(-maxAge),
+	)
+
+	return address, err
+}
+
+func (p *Pg) SaveGeocode(ctx context.Context, key, address string) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		`INSERT INTO geocode_cache (key, address) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET address = EXCLUDED.address, updated_at = NOW()`,
+		key,
+		address,
+	)
+
+	return err
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// UpsertLocality добавляет населенный пункт или регион в справочник и возвращает его идентификатор.
+// Если запись уже есть, она не меняется, а в ответе остаются переданные поля.
+func (p *Pg) UpsertLocality(ctx context.Context, locality models.Locality) (*models.Locality, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		`INSERT INTO localities (kind, name, region, country, center) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (kind, country, region, name) DO UPDATE SET kind = EXCLUDED.kind`,
+		locality.Kind,
+		locality.Name,
+		locality.Region,
+		locality.Country,
+		locality.Center,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	locality.ID = id
+	locality.CreatedAt = time.Now()
+	return &locality, nil
+}
+
+func (p *Pg) GetLocality(ctx context.Context, id uuid.UUID) (*models.Locality, error) {
+	var locality models.Locality
+	err := p.db.GetContext(ctx, &locality, "SELECT * FROM localities WHERE id = $1", id)
+
+	return &locality, err
+}
+
+// distanceKm SQL-выражение расстояния в километрах от точки ($1 - широта, $2 - долгота) до адреса записи.
+const distanceKm = "distance_km($1, $2, address_lat, address_lng)"
+
+// cityFilter возвращает условие принадлежности места или события городу из параметра $n. Для региона подходят
+// и записи его городов. Если параметр NULL, условие выполняется для всех записей.
+func cityFilter(n int) string {
+	return fmt.Sprintf("($%[1]d::uuid IS NULL OR city_id IN (SELECT id FROM localities WHERE id = $%[1]d OR parent_id = $%[1]d))", n)
+}
+
+// routeCityFilter то же, что cityFilter, для маршрутов: маршрут относится к городу, если в нем есть место или событие этого города.
+func routeCityFilter(n int) string {
+	return fmt.Sprintf(`($%[1]d::uuid IS NULL OR EXISTS (
+		SELECT 1 FROM places WHERE id::text = ANY(routes.places) AND %[2]s
+		UNION ALL
+		SELECT 1 FROM events WHERE id::text = ANY(routes.events) AND %[2]s
+	))`, n, cityFilter(n))
+}
+
+func (p *Pg) NewLocality(ctx context.Context, locality models.Locality) (*models.Locality, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		"INSERT INTO localities (parent_id, kind, name, region, country, center, radius_km, boundary) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		locality.ParentID,
+		locality.Kind,
+		locality.Name,
+		locality.Region,
+		locality.Country,
+		locality.Center,
+		locality.RadiusKm,
+		locality.Boundary,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	locality.ID = id
+	locality.CreatedAt = time.Now()
+	return &locality, nil
+}
+
+func (p *Pg) SaveLocality(ctx context.Context, locality *models.Locality) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"UPDATE localities SET parent_id = $1, kind = $2, name = $3, region = $4, country = $5, center = $6, radius_km = $7, boundary = $8 WHERE id = $9",
+		locality.ParentID,
+		locality.Kind,
+		locality.Name,
+		locality.Region,
+		locality.Country,
+		locality.Center,
+		locality.RadiusKm,
+		locality.Boundary,
+		locality.ID,
+	)
+
+	return err
+}
+
+// GetLocalities возвращает справочник. Пустой kind - города и регионы вместе.
+func (p *Pg) GetLocalities(ctx context.Context, kind string) ([]models.Locality, error) {
+	localities := make([]models.Locality, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&localities,
+		"SELECT * FROM localities WHERE ($1 = '' OR kind = $1) ORDER BY country, region, name",
+		kind,
+	)
+
+	return localities, err
+}
+
+// GetLocalityAt возвращает город (или регион), в границы которого попадает точка, nil - если такого нет.
+func (p *Pg) GetLocalityAt(ctx context.Context, point models.GeoPoint) (*uuid.UUID, error) {
+	var id *uuid.UUID
+	err := p.db.GetContext(ctx, &id, "SELECT locality_at($1, $2)", point.Lat, point.Lng)
+
+	return id, err
+}
+
+// ReassignLocalities заново привязывает к городам места и события, на привязку которых могло повлиять
+// изменение границ locality: уже привязанные к нему и попадающие в прямоугольник его новых границ.
+func (p *Pg) ReassignLocalities(ctx context.Context, locality *models.Locality) error {
+	// Без границ locality в привязке не участвует: достаточно отвязать то, что было привязано к старым границам
+	low, high, bounded := locality.Bounds()
+
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"places", "events"} {
+		_, err = tx.ExecContext(
+			ctx,
+			fmt.Sprintf(`UPDATE %s SET city_id = locality_at(address_lat, address_lng)
+			WHERE city_id = $1 OR ($2 AND address_lat BETWEEN $3 AND $4 AND address_lng BETWEEN $5 AND $6)`, table),
+			locality.ID,
+			bounded,
+			low.Lat,
+			high.Lat,
+			low.Lng,
+			high.Lng,
+		)
+		if err != nil {
+			return fmt.Errorf("reassign %s: %w", table, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -3,15 +3,22 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ShpullRequest/backend/internal/models"
 	"github.com/google/uuid"
 )
 
-func (p *Pg) NewPlace(ctx context.Context, place models.Place) (*models.Place, error) {
-	id, err := p.db.ExecContextWithReturnID(
+// NewPlace создает место вместе с ее первой версией version.
+func (p *Pg) NewPlace(ctx context.Context, place models.Place, version models.EntityVersion) (*models.Place, error) {
+	id, err := p.createEntity(
 		ctx,
-		"INSERT INTO places (name, description, carousel, address_text, address_lng, address_lat, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		version,
+		`INSERT INTO places (
+			name, description, carousel, address_text, address_lng, address_lat, is_deleted,
+			address_country, address_region, address_locality, address_street, address_building, address_postal_code, city_id, tag_ids,
+			timezone, opening_hours, hours_exceptions, price_min, price_max, accessibility, amenities, company_id, translations
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)`,
 		place.Name,
 		place.Description,
 		place.Carousel,
@@ -19,104 +26,151 @@ func (p *Pg) NewPlace(ctx context.Context, place models.Place) (*models.Place, e
 		place.AddressLng,
 		place.AddressLat,
 		place.IsDeleted,
+		place.Country,
+		place.Region,
+		place.Locality,
+		place.Street,
+		place.Building,
+		place.PostalCode,
+		place.CityID,
+		place.TagIDs,
+		place.Timezone,
+		place.OpeningHours,
+		place.HoursExceptions,
+		place.PriceMin,
+		place.PriceMax,
+		place.Accessibility,
+		place.Amenities,
+		place.CompanyID,
+		place.Translations,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	place.ID = id
+	place.OpenNow = place.OpenAt(time.Now())
 	return &place, nil
 }
 
-func (p *Pg) GetPlace(ctx context.Context, id uuid.UUID) (*models.Place, error) {
+func (p *Pg) GetPlace(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Place, error) {
 	var place models.Place
-	err := p.db.GetContext(ctx, &place, "SELECT * FROM places WHERE id = $1", id)
+	err := p.db.GetContext(ctx, &place, "SELECT * FROM places WHERE id = $1 AND "+deletedFilter(includeDeleted), id)
+	place.OpenNow = place.OpenAt(time.Now())
 
 	return &place, err
 }
 
-func (p *Pg) SearchPlace(ctx context.Context, q string) ([]models.Place, error) {
+func (p *Pg) SearchPlace(ctx context.Context, q string, filter models.ListFilter) ([]models.Place, error) {
 	q = fmt.Sprintf("%%%s%%", q)
+	where, args := listFilter(filter, 2)
 
 	var places []models.Place
 	err := p.db.SelectContext(
 		ctx,
 		&places,
 		`SELECT * FROM places
-				WHERE 
+				WHERE is_deleted = false AND (
 					LOWER(name) LIKE LOWER($1) OR
+					LOWER(translated_names(translations)) LIKE LOWER($1) OR
 					LOWER(description) LIKE LOWER($1) OR
-					LOWER(address_text) LIKE LOWER($1);
-				`,
-		q,
+					LOWER(address_text) LIKE LOWER($1)
+				) AND `+where,
+		append([]any{q}, args...)...,
 	)
 
-	return places, err
+	return withOpenNow(places), err
 }
 
-func (p *Pg) GetAllPlaces(ctx context.Context) ([]models.Place, error) {
+func (p *Pg) GetAllPlaces(ctx context.Context, includeDeleted bool, filter models.ListFilter) ([]models.Place, error) {
+	where, args := listFilter(filter, 1)
+
 	var places []models.Place
-	err := p.db.SelectContext(ctx, &places, "SELECT * FROM places")
+	err := p.db.SelectContext(ctx, &places, "SELECT * FROM places WHERE "+deletedFilter(includeDeleted)+" AND "+where, args...)
 
-	return places, err
+	return withOpenNow(places), err
 }
 
-func (p *Pg) SavePlace(ctx context.Context, place *models.Place) error {
-	_, err := p.db.ExecContext(
+// GetPlacesNearby возвращает места в радиусе radiusKm от точки, ближайшие первыми.
+func (p *Pg) GetPlacesNearby(ctx context.Context, center models.GeoPoint, radiusKm float64, limit int, cityID *uuid.UUID) ([]models.Place, error) {
+	places := make([]models.Place, 0)
+	err := p.db.SelectContext(
 		ctx,
-		"UPDATE places SET name = $1, description = $2, carousel = $3, address_text = $4, address_lng = $5, address_lat = $6, is_deleted = $7 WHERE id = $8",
-		place.Name,
-		place.Description,
-		place.Carousel,
-		place.AddressText,
-		place.AddressLng,
-		place.AddressLat,
-		place.IsDeleted,
-		place.ID,
+		&places,
+		"SELECT * FROM places WHERE is_deleted = false AND "+cityFilter(5)+" AND "+distanceKm+" <= $3 ORDER BY "+distanceKm+" LIMIT $4",
+		center.Lat,
+		center.Lng,
+		radiusKm,
+		limit,
+		cityID,
 	)
 
-	return err
+	return withOpenNow(places), err
 }
 
-func (p *Pg) NewReviewPlace(ctx context.Context, reviewPlace models.ReviewPlace) (*models.ReviewPlace, error) {
-	id, err := p.db.ExecContextWithReturnID(
+// GetTopPlaces возвращает лучшие по рейтингу места города.
+func (p *Pg) GetTopPlaces(ctx context.Context, cityID uuid.UUID, limit int) ([]models.Place, error) {
+	places := make([]models.Place, 0)
+	err := p.db.SelectContext(
 		ctx,
-		"INSERT INTO reviews_places (owner_id, place_id, review_text, stars, created_at, is_deleted) VALUES ($1, $2, $3, $4, $5, $6)",
-		reviewPlace.OwnerID,
-		reviewPlace.PlaceID,
-		reviewPlace.ReviewText,
-		reviewPlace.Stars,
-		reviewPlace.CreatedAt,
-		reviewPlace.IsDeleted,
+		&places,
+		"SELECT * FROM places WHERE is_deleted = false AND "+cityFilter(1)+" ORDER BY rating_avg DESC, rating_count DESC LIMIT $2",
+		cityID,
+		limit,
 	)
-	if err != nil {
-		return nil, err
-	}
 
-	reviewPlace.ID = id
-	return &reviewPlace, nil
+	return withOpenNow(places), err
+}
+
+func (p *Pg) SavePlace(ctx context.Context, place *models.Place) error {
+	return savePlace(ctx, p.db, place)
 }
 
-func (p *Pg) SaveReviewPlace(ctx context.Context, reviewPlace *models.ReviewEvent) error {
-	_, err := p.db.ExecContext(
+func savePlace(ctx context.Context, db execer, place *models.Place) error {
+	_, err := db.ExecContext(
 		ctx,
-		`UPDATE reviews_places SET review_text = $1, stars = $2 WHERE owner_id = $3 AND place_id = $4`,
-		reviewPlace.ReviewText, reviewPlace.Stars, reviewPlace.OwnerID, reviewPlace.EventID,
+		`UPDATE places SET name = $1, description = $2, carousel = $3, address_text = $4, address_lng = $5, address_lat = $6, is_deleted = $7,
+			address_country = $8, address_region = $9, address_locality = $10, address_street = $11, address_building = $12, address_postal_code = $13,
+			city_id = $14, tag_ids = $15, timezone = $16, opening_hours = $17, hours_exceptions = $18,
+			price_min = $19, price_max = $20, accessibility = $21, amenities = $22, company_id = $23, translations = $24
+		WHERE id = $25`,
+		place.Name,
+		place.Description,
+		place.Carousel,
+		place.AddressText,
+		place.AddressLng,
+		place.AddressLat,
+		place.IsDeleted,
+		place.Country,
+		place.Region,
+		place.Locality,
+		place.Street,
+		place.Building,
+		place.PostalCode,
+		place.CityID,
+		place.TagIDs,
+		place.Timezone,
+		place.OpeningHours,
+		place.HoursExceptions,
+		place.PriceMin,
+		place.PriceMax,
+		place.Accessibility,
+		place.Amenities,
+		place.CompanyID,
+		place.Translations,
+		place.ID,
 	)
+	place.OpenNow = place.OpenAt(time.Now())
 
 	return err
 }
 
-func (p *Pg) GetReviewPlace(ctx context.Context, ownerID uuid.UUID, placeID uuid.UUID) (*models.ReviewEvent, error) {
-	var reviewEvent models.ReviewEvent
-	err := p.db.GetContext(ctx, &reviewEvent, "SELECT * FROM reviews_places WHERE owner_id = $1 AND place_id = $2 AND is_deleted = false", ownerID, placeID)
-
-	return &reviewEvent, err
-}
-
-func (p *Pg) GetReviewsPlace(ctx context.Context, placeID uuid.UUID) ([]models.ReviewPlace, error) {
-	var reviewsPlace []models.ReviewPlace
-	err := p.db.SelectContext(ctx, &reviewsPlace, "SELECT * FROM reviews_places WHERE place_id = $1", placeID)
+// withOpenNow заполняет вычисляемое поле OpenNow мест на текущий момент.
+func withOpenNow(places []models.Place) []models.Place {
+	now := time.Now()
+	for i := range places {
+		places[i].OpenNow = places[i].OpenAt(now)
+	}
 
-	return reviewsPlace, err
+	return places
 }
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShpullRequest/backend/pkg/ratelimit"
+	"go.uber.org/zap"
+)
+
+// TakeRateLimitToken списывает токен из корзины, хранящейся в таблице rate_limits.
+// Строка блокируется на время транзакции, поэтому лимит соблюдается всеми репликами.
+func (p *Pg) TakeRateLimitToken(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Result, error) {
+	now := time.Now()
+
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	defer tx.Rollback()
+
+	initial := ratelimit.NewBucket(policy, now)
+	if _, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO rate_limits (key, tokens, updated_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		key,
+		initial.Tokens,
+		initial.UpdatedAt,
+	); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	var bucket ratelimit.Bucket
+	if err = tx.QueryRowxContext(
+		ctx,
+		"SELECT tokens, updated_at FROM rate_limits WHERE key = $1 FOR UPDATE",
+		key,
+	).Scan(&bucket.Tokens, &bucket.UpdatedAt); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	bucket, result := ratelimit.Take(bucket, policy, now)
+	if _, err = tx.ExecContext(
+		ctx,
+		"UPDATE rate_limits SET tokens = $2, updated_at = $3 WHERE key = $1",
+		key,
+		bucket.Tokens,
+		bucket.UpdatedAt,
+	); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return result, tx.Commit()
+}
+
+// SweepRateLimits удаляет корзины, которые не менялись дольше maxPeriod: они уже наполнились
+// и не отличаются от новых.
+func (p *Pg) SweepRateLimits(ctx context.Context, maxPeriod time.Duration) (int64, error) {
+	result, err := p.db.ExecContext(ctx, "DELETE FROM rate_limits WHERE updated_at < $1", time.Now().Add(-maxPeriod))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// WatchRateLimits удаляет наполнившиеся корзины каждые interval, пока не отменен ctx.
+func (p *Pg) WatchRateLimits(ctx context.Context, interval, maxPeriod time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := p.SweepRateLimits(ctx, maxPeriod); err != nil {
+			p.logger.Error("Error sweep rate limits", zap.Error(err))
+		}
+	}
+}
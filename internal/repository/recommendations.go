@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// entityFeatures возвращает признаки мест, событий и маршрутов для рекомендаций: переводы названия, координаты, slug тегов,
+// рейтинг, время начала события и время последнего отзыва. Признаки считаются только для строк places, events и routes -
+// выборок из одноименных таблиц, отфильтрованных заранее, чтобы не считать их для всего каталога.
+func entityFeatures(places, events, routes string) string {
+	return `
+	SELECT 'place' AS entity_type, id AS entity_id, name, translations, ARRAY(SELECT slug FROM tags WHERE id = ANY(places.tag_ids))::text[] AS tags,
+		address_lat AS lat, address_lng AS lng, rating_avg, rating_count, NULL::timestamptz AS starts_at,
+		(SELECT MAX(created_at) FROM reviews WHERE entity_type = 'place' AND entity_id = places.id AND is_deleted = false) AS active_at,
+		is_deleted, city_id
+	FROM ` + places + `
+	UNION ALL
+	SELECT 'event', id, name, translations, ARRAY(SELECT slug FROM tags WHERE id = ANY(events.tag_ids))::text[],
+		address_lat, address_lng, rating_avg, rating_count, start_time,
+		(SELECT MAX(created_at) FROM reviews WHERE entity_type = 'event' AND entity_id = events.id AND is_deleted = false),
+		is_deleted OR start_time < NOW(), city_id
+	FROM ` + events + `
+	UNION ALL
+	SELECT 'route', routes.id, routes.name, routes.translations,
+		ARRAY(SELECT slug FROM tags WHERE id IN (
+			SELECT unnest(tag_ids) FROM places WHERE id::text = ANY(routes.places)
+			UNION
+			SELECT unnest(tag_ids) FROM events WHERE id::text = ANY(routes.events)
+		))::text[],
+		geo.lat, geo.lng, routes.rating_avg, routes.rating_count, NULL,
+		(SELECT MAX(created_at) FROM reviews WHERE entity_type = 'route' AND entity_id = routes.id AND is_deleted = false),
+		routes.is_deleted, geo.city_id
+	FROM ` + routes + `
+	LEFT JOIN LATERAL (
+		SELECT AVG(address_lat) AS lat, AVG(address_lng) AS lng, MIN(city_id::text)::uuid AS city_id FROM (
+			SELECT address_lat, address_lng, city_id FROM places WHERE id::text = ANY(routes.places)
+			UNION ALL
+			SELECT address_lat, address_lng, city_id FROM events WHERE id::text = ANY(routes.events)
+		) AS points
+	) AS geo ON TRUE
+`
+}
+
+// GetRecommendationCandidates возвращает до perType лучших по рейтингу объектов каждого типа,
+// из событий с одинаковым рейтингом - ближайшие по времени.
+// Прошедшие события в кандидаты не попадают.
+func (p *Pg) GetRecommendationCandidates(ctx context.Context, cityID *uuid.UUID, perType int) ([]models.RecommendationCandidate, error) {
+	candidates := make([]models.RecommendationCandidate, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&candidates,
+		`SELECT entity_type, entity_id, name, translations, tags, lat, lng, rating_avg, rating_count, starts_at, active_at
+		FROM (`+entityFeatures(
+			`(SELECT * FROM places WHERE is_deleted = false AND `+cityFilter(1)+`
+				ORDER BY rating_avg * rating_count DESC, id LIMIT $2) AS places`,
+			`(SELECT * FROM events WHERE is_deleted = false AND start_time >= NOW() AND `+cityFilter(1)+`
+				ORDER BY rating_avg * rating_count DESC, start_time, id LIMIT $2) AS events`,
+			`(SELECT * FROM routes WHERE is_deleted = false AND `+routeCityFilter(1)+`
+				ORDER BY rating_avg * rating_count DESC, id LIMIT $2) AS routes`,
+		)+`) AS features`,
+		cityID,
+		perType,
+	)
+
+	return candidates, err
+}
+
+// GetUserInteractions возвращает закладки, отметки о посещении и отзывы пользователя вместе с признаками объектов.
+func (p *Pg) GetUserInteractions(ctx context.Context, userID uuid.UUID) ([]models.UserInteraction, error) {
+	interactions := make([]models.UserInteraction, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&interactions,
+		`WITH interactions AS (
+			SELECT 'bookmark' AS kind, entity_type, entity_id, NULL::double precision AS stars, created_at FROM bookmarks WHERE user_id = $1
+			UNION ALL
+			SELECT 'checkin', entity_type, entity_id, NULL, created_at FROM checkins WHERE user_id = $1
+			UNION ALL
+			SELECT 'review', entity_type, entity_id, stars, created_at FROM reviews WHERE owner_id = $1 AND is_deleted = false
+		)
+		SELECT interactions.kind, interactions.entity_type, interactions.entity_id, features.name, features.tags,
+			interactions.stars, interactions.created_at
+		FROM interactions
+		JOIN (`+entityFeatures(
+			"(SELECT * FROM places WHERE id IN (SELECT entity_id FROM interactions WHERE entity_type = 'place')) AS places",
+			"(SELECT * FROM events WHERE id IN (SELECT entity_id FROM interactions WHERE entity_type = 'event')) AS events",
+			"(SELECT * FROM routes WHERE id IN (SELECT entity_id FROM interactions WHERE entity_type = 'route')) AS routes",
+		)+`) AS features
+			ON features.entity_type = interactions.entity_type AND features.entity_id = interactions.entity_id`,
+		userID,
+	)
+
+	return interactions, err
+}
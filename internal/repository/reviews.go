@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const reviewsWithMetaQuery = `
+	SELECT r.*, (SELECT COUNT(*) FROM reviews_votes v WHERE v.review_id = r.id) AS helpful_count
+		FROM reviews r
+		WHERE r.entity_type = $1 AND r.entity_id = $2 AND r.is_deleted = false
+		ORDER BY %s
+`
+
+func reviewsOrderBy(sort string) string {
+	switch sort {
+	case models.ReviewsSortHelpful:
+		return "helpful_count DESC, r.created_at DESC"
+	case models.ReviewsSortStars:
+		return "r.stars DESC, r.created_at DESC"
+	default:
+		return "r.created_at DESC"
+	}
+}
+
+// NewReview создает отзыв вместе с фотографиями и пересчитывает сводку оценок сущности.
+// Если сущность не найдена, возвращается sql.ErrNoRows.
+func (p *Pg) NewReview(ctx context.Context, review models.Review, photoIDs []uuid.UUID) (*models.Review, error) {
+	table, err := entityTable(review.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err = lockEntity(ctx, tx, table, review.EntityID, false); err != nil {
+		return nil, err
+	}
+
+	if err = tx.GetContext(
+		ctx,
+		&review.ID,
+		"INSERT INTO reviews (entity_type, entity_id, owner_id, review_text, stars, created_at, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+		review.EntityType,
+		review.EntityID,
+		review.OwnerID,
+		review.ReviewText,
+		review.Stars,
+		review.CreatedAt,
+		review.IsDeleted,
+	); err != nil {
+		return nil, err
+	}
+
+	for position, blobID := range photoIDs {
+		if _, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO reviews_photos (review_id, blob_id, position) VALUES ($1, $2, $3)",
+			review.ID,
+			blobID,
+			position,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = updateRatingSummary(ctx, tx, table, review.EntityType, review.EntityID); err != nil {
+		return nil, err
+	}
+
+	return &review, tx.Commit()
+}
+
+// SaveReview сохраняет текст и оценку отзыва и пересчитывает сводку оценок сущности.
+func (p *Pg) SaveReview(ctx context.Context, review *models.Review) error {
+	table, err := entityTable(review.EntityType)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = lockEntity(ctx, tx, table, review.EntityID, false); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(
+		ctx,
+		"UPDATE reviews SET review_text = $1, stars = $2 WHERE id = $3",
+		review.ReviewText,
+		review.Stars,
+		review.ID,
+	); err != nil {
+		return err
+	}
+
+	if err = updateRatingSummary(ctx, tx, table, review.EntityType, review.EntityID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteReview помечает отзыв удаленным и пересчитывает сводку оценок сущности.
+func (p *Pg) DeleteReview(ctx context.Context, reviewID uuid.UUID) error {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var review models.Review
+	if err = tx.GetContext(ctx, &review, "SELECT * FROM reviews WHERE id = $1 AND is_deleted = false", reviewID); err != nil {
+		return err
+	}
+
+	table, err := entityTable(review.EntityType)
+	if err != nil {
+		return err
+	}
+
+	if err = lockEntity(ctx, tx, table, review.EntityID, true); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE reviews SET is_deleted = true WHERE id = $1", review.ID); err != nil {
+		return err
+	}
+
+	if err = updateRatingSummary(ctx, tx, table, review.EntityType, review.EntityID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *Pg) GetReview(ctx context.Context, entityType string, entityID uuid.UUID, ownerID uuid.UUID) (*models.Review, error) {
+	var review models.Review
+	err := p.db.GetContext(
+		ctx,
+		&review,
+		"SELECT * FROM reviews WHERE entity_type = $1 AND entity_id = $2 AND owner_id = $3 AND is_deleted = false",
+		entityType,
+		entityID,
+		ownerID,
+	)
+
+	return &review, err
+}
+
+func (p *Pg) GetReviews(ctx context.Context, entityType string, entityID uuid.UUID, sort string) ([]models.ReviewWithMeta, error) {
+	var reviews []models.ReviewWithMeta
+	err := p.db.SelectContext(ctx, &reviews, fmt.Sprintf(reviewsWithMetaQuery, reviewsOrderBy(sort)), entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, p.fillReviewsMeta(ctx, reviews)
+}
+
+func (p *Pg) GetReviewOwnerID(ctx context.Context, entityType string, entityID uuid.UUID, reviewID uuid.UUID) (uuid.UUID, error) {
+	var ownerID uuid.UUID
+	err := p.db.GetContext(
+		ctx,
+		&ownerID,
+		"SELECT owner_id FROM reviews WHERE id = $1 AND entity_type = $2 AND entity_id = $3 AND is_deleted = false",
+		reviewID,
+		entityType,
+		entityID,
+	)
+
+	return ownerID, err
+}
+
+func updateRatingSummary(ctx context.Context, tx *sqlx.Tx, table string, entityType string, entityID uuid.UUID) error {
+	summary := models.RatingSummary{Histogram: pq.Int64Array{0, 0, 0, 0, 0}}
+
+	err := tx.GetContext(
+		ctx,
+		&summary,
+		"SELECT rating_avg, rating_count, rating_histogram FROM reviews_aggregates WHERE entity_type = $1 AND entity_id = $2",
+		entityType,
+		entityID,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET rating_avg = $1, rating_count = $2, rating_histogram = $3 WHERE id = $4", table),
+		summary.Average,
+		summary.Count,
+		summary.Histogram,
+		entityID,
+	)
+
+	return err
+}
+
+func (p *Pg) NewReviewReply(ctx context.Context, reply models.ReviewReply) (*models.ReviewReply, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		"INSERT INTO reviews_replies (review_id, company_id, owner_id, reply_text, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		reply.ReviewID,
+		reply.CompanyID,
+		reply.OwnerID,
+		reply.ReplyText,
+		reply.CreatedAt,
+		reply.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reply.ID = id
+	return &reply, nil
+}
+
+func (p *Pg) GetReviewReply(ctx context.Context, reviewID uuid.UUID) (*models.ReviewReply, error) {
+	var reply models.ReviewReply
+	err := p.db.GetContext(ctx, &reply, "SELECT * FROM reviews_replies WHERE review_id = $1", reviewID)
+
+	return &reply, err
+}
+
+func (p *Pg) SaveReviewReply(ctx context.Context, reply *models.ReviewReply) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"UPDATE reviews_replies SET owner_id = $1, reply_text = $2, updated_at = $3 WHERE id = $4",
+		reply.OwnerID,
+		reply.ReplyText,
+		reply.UpdatedAt,
+		reply.ID,
+	)
+
+	return err
+}
+
+func (p *Pg) NewReviewVote(ctx context.Context, vote models.ReviewVote) (*models.ReviewVote, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		"INSERT INTO reviews_votes (review_id, user_id, created_at) VALUES ($1, $2, $3)",
+		vote.ReviewID,
+		vote.UserID,
+		vote.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vote.ID = id
+	return &vote, nil
+}
+
+func (p *Pg) DeleteReviewVote(ctx context.Context, reviewID uuid.UUID, userID uuid.UUID) (bool, error) {
+	result, err := p.db.ExecContext(ctx, "DELETE FROM reviews_votes WHERE review_id = $1 AND user_id = $2", reviewID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (p *Pg) GetUsersSummary(ctx context.Context, ids []uuid.UUID) ([]models.UserSummary, error) {
+	var users []models.UserSummary
+	err := p.db.SelectContext(
+		ctx,
+		&users,
+		`
+			SELECT u.id, u.vk_id,
+				(SELECT COUNT(*) FROM reviews WHERE owner_id = u.id AND is_deleted = false) AS reviews_count
+			FROM users u
+			WHERE u.id = ANY($1::uuid[])
+		`,
+		uuidsToArray(ids),
+	)
+
+	return users, err
+}
+
+func (p *Pg) getReviewsReplies(ctx context.Context, reviewIDs []uuid.UUID) ([]models.ReviewReply, error) {
+	var replies []models.ReviewReply
+	err := p.db.SelectContext(ctx, &replies, "SELECT * FROM reviews_replies WHERE review_id = ANY($1::uuid[])", uuidsToArray(reviewIDs))
+
+	return replies, err
+}
+
+// fillReviewsMeta подставляет в отзывы краткий профиль автора, ответ компании и фотографии.
+func (p *Pg) fillReviewsMeta(ctx context.Context, reviews []models.ReviewWithMeta) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	ownerIDs := make([]uuid.UUID, len(reviews))
+	reviewIDs := make([]uuid.UUID, len(reviews))
+	for i := range reviews {
+		ownerIDs[i] = reviews[i].OwnerID
+		reviewIDs[i] = reviews[i].ID
+	}
+
+	users, err := p.GetUsersSummary(ctx, ownerIDs)
+	if err != nil {
+		return err
+	}
+
+	usersByID := make(map[uuid.UUID]*models.UserSummary, len(users))
+	for i := range users {
+		usersByID[users[i].ID] = &users[i]
+	}
+
+	replies, err := p.getReviewsReplies(ctx, reviewIDs)
+	if err != nil {
+		return err
+	}
+
+	repliesByReviewID := make(map[uuid.UUID]*models.ReviewReply, len(replies))
+	for i := range replies {
+		repliesByReviewID[replies[i].ReviewID] = &replies[i]
+	}
+
+	photos, err := p.getReviewsPhotos(ctx, reviewIDs)
+	if err != nil {
+		return err
+	}
+
+	photosByReviewID := make(map[uuid.UUID][]models.Blob, len(photos))
+	for _, photo := range photos {
+		photosByReviewID[photo.ReviewID] = append(photosByReviewID[photo.ReviewID], photo.Blob)
+	}
+
+	for i := range reviews {
+		reviews[i].Author = usersByID[reviews[i].OwnerID]
+		reviews[i].Reply = repliesByReviewID[reviews[i].ID]
+		reviews[i].Photos = photosByReviewID[reviews[i].ID]
+	}
+
+	return nil
+}
+
+func uuidsToArray(ids []uuid.UUID) pq.StringArray {
+	array := make(pq.StringArray, 0, len(ids))
+	for _, id := range ids {
+		array = append(array, id.String())
+	}
+
+	return array
+}
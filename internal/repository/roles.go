@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func (p *Pg) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	roles := make([]string, 0)
+	err := p.db.SelectContext(ctx, &roles, "SELECT role FROM users_roles WHERE user_id = $1 ORDER BY role", userID)
+
+	return roles, err
+}
+
+// GrantRole выдает роль пользователю и записывает изменение в журнал.
+// Возвращает false, если роль уже была выдана.
+func (p *Pg) GrantRole(ctx context.Context, actorID *uuid.UUID, userID uuid.UUID, role string) (bool, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	granted, err := grantRole(ctx, tx, actorID, userID, role)
+	if err != nil || !granted {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func grantRole(ctx context.Context, tx *sqlx.Tx, actorID *uuid.UUID, userID uuid.UUID, role string) (bool, error) {
+	result, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO users_roles (user_id, role, granted_by) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		userID,
+		role,
+		actorID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	if _, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO roles_audit (actor_id, user_id, role, action) VALUES ($1, $2, $3, $4)",
+		actorID,
+		userID,
+		role,
+		models.RoleActionGrant,
+	); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RevokeRole отзывает роль у пользователя и записывает изменение в журнал.
+// Возвращает false, если роли у пользователя не было.
+func (p *Pg) RevokeRole(ctx context.Context, actorID *uuid.UUID, userID uuid.UUID, role string) (bool, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	revoked, err := revokeRole(ctx, tx, actorID, userID, role)
+	if err != nil || !revoked {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func revokeRole(ctx context.Context, tx *sqlx.Tx, actorID *uuid.UUID, userID uuid.UUID, role string) (bool, error) {
+	result, err := tx.ExecContext(ctx, "DELETE FROM users_roles WHERE user_id = $1 AND role = $2", userID, role)
+	if err != nil {
+		return false, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	if _, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO roles_audit (actor_id, user_id, role, action) VALUES ($1, $2, $3, $4)",
+		actorID,
+		userID,
+		role,
+		models.RoleActionRevoke,
+	); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (p *Pg) GetRolesAudit(ctx context.Context, userID *uuid.UUID) ([]models.RoleAudit, error) {
+	var audit []models.RoleAudit
+	err := p.db.SelectContext(
+		ctx,
+		&audit,
+		"SELECT * FROM roles_audit WHERE $1::uuid IS NULL OR user_id = $1 ORDER BY created_at DESC",
+		userID,
+	)
+
+	return audit, err
+}
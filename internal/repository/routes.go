@@ -7,16 +7,19 @@ import (
 	"github.com/google/uuid"
 )
 
-func (p *Pg) NewRoute(ctx context.Context, route models.Route) (*models.Route, error) {
-	id, err := p.db.ExecContextWithReturnID(
+// NewRoute создает маршрут вместе с ее первой версией version.
+func (p *Pg) NewRoute(ctx context.Context, route models.Route, version models.EntityVersion) (*models.Route, error) {
+	id, err := p.createEntity(
 		ctx,
-		"INSERT INTO routes (company_id, name, description, places, events, is_deleted) VALUES ($1, $2, $3, $4, $5, $6)",
+		version,
+		"INSERT INTO routes (company_id, name, description, places, events, is_deleted, translations) VALUES ($1, $2, $3, $4, $5, $6, $7)",
 		route.CompanyID,
 		route.Name,
 		route.Description,
 		route.Places,
 		route.Events,
 		route.IsDeleted,
+		route.Translations,
 	)
 	if err != nil {
 		return nil, err
@@ -26,108 +29,88 @@ func (p *Pg) NewRoute(ctx context.Context, route models.Route) (*models.Route, e
 	return &route, nil
 }
 
-func (p *Pg) GetRoute(ctx context.Context, id uuid.UUID) (*models.RouteWithGeo, error) {
+func (p *Pg) GetRoute(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.RouteWithGeo, error) {
 	var route models.Route
-	err := p.db.GetContext(ctx, &route, "SELECT * FROM routes WHERE id = $1", id)
+	err := p.db.GetContext(ctx, &route, "SELECT * FROM routes WHERE id = $1 AND "+deletedFilter(includeDeleted), id)
 
 	routeWithGeo := p.routeToRouteWithGeo(ctx, route)
 	return &routeWithGeo, err
 }
 
-func (p *Pg) SearchRoutes(ctx context.Context, q string) ([]models.RouteWithGeo, error) {
+func (p *Pg) SearchRoutes(ctx context.Context, q string, filter models.ListFilter) ([]models.RouteWithGeo, error) {
 	q = fmt.Sprintf("%%%s%%", q)
+	where, args := routeListFilter(filter, 2)
 
 	var routes []models.Route
 	err := p.db.SelectContext(
 		ctx,
 		&routes,
 		`SELECT * FROM routes
-				WHERE 
+				WHERE is_deleted = false AND (
 					LOWER(name) LIKE LOWER($1) OR
+					LOWER(translated_names(translations)) LIKE LOWER($1) OR
 					LOWER(description) LIKE LOWER($1)
-				`,
-		q,
+				) AND `+where,
+		append([]any{q}, args...)...,
 	)
 
 	return p.sliceRouteToSliceRouteWithGeo(ctx, routes), err
 }
 
-func (p *Pg) GetAllRoutesByCompanyID(ctx context.Context, companyID uuid.UUID) ([]models.RouteWithGeo, error) {
+func (p *Pg) GetAllRoutesByCompanyID(ctx context.Context, companyID uuid.UUID, filter models.ListFilter) ([]models.RouteWithGeo, error) {
+	where, args := routeListFilter(filter, 2)
+
 	var routes []models.Route
-	err := p.db.SelectContext(ctx, &routes, "SELECT * FROM routes WHERE company_id = $1", companyID)
+	err := p.db.SelectContext(ctx, &routes, "SELECT * FROM routes WHERE company_id = $1 AND is_deleted = false AND "+where, append([]any{companyID}, args...)...)
 
 	return p.sliceRouteToSliceRouteWithGeo(ctx, routes), err
 }
 
-func (p *Pg) GetAllRoutes(ctx context.Context) ([]models.RouteWithGeo, error) {
+func (p *Pg) GetAllRoutes(ctx context.Context, includeDeleted bool, filter models.ListFilter) ([]models.RouteWithGeo, error) {
+	where, args := routeListFilter(filter, 1)
+
 	var routes []models.Route
-	err := p.db.SelectContext(ctx, &routes, "SELECT * FROM routes WHERE is_deleted = false")
+	err := p.db.SelectContext(ctx, &routes, "SELECT * FROM routes WHERE "+deletedFilter(includeDeleted)+" AND "+where, args...)
 
 	return p.sliceRouteToSliceRouteWithGeo(ctx, routes), err
 }
 
-func (p *Pg) SaveRoute(ctx context.Context, routeWithGeo *models.RouteWithGeo) error {
-	route := routeWithGeo.Route
-
-	_, err := p.db.ExecContext(
+// GetFeaturedRoutes возвращает лучшие по рейтингу маршруты, проходящие через город.
+func (p *Pg) GetFeaturedRoutes(ctx context.Context, cityID uuid.UUID, limit int) ([]models.RouteWithGeo, error) {
+	var routes []models.Route
+	err := p.db.SelectContext(
 		ctx,
-		`
-			UPDATE routes 
-				SET name = $1, description = $2, events = $3, places = $4 
-				WHERE id = $5
-		`,
-		route.Name, route.Description, route.Events, route.Places,
-		route.ID,
+		&routes,
+		"SELECT * FROM routes WHERE is_deleted = false AND "+routeCityFilter(1)+" ORDER BY rating_avg DESC, rating_count DESC LIMIT $2",
+		cityID,
+		limit,
 	)
 
-	routeWithGeo.Geo = p.routeToRouteWithGeo(ctx, route).Geo
-
-	return err
+	return p.sliceRouteToSliceRouteWithGeo(ctx, routes), err
 }
 
-func (p *Pg) NewReviewRoute(ctx context.Context, reviewRoute models.ReviewRoute) (*models.ReviewRoute, error) {
-	id, err := p.db.ExecContextWithReturnID(
-		ctx,
-		"INSERT INTO reviews_routes (owner_id, route_id, review_text, stars, created_at, is_deleted) VALUES ($1, $2, $3, $4, $5, $6)",
-		reviewRoute.OwnerID,
-		reviewRoute.RouteID,
-		reviewRoute.ReviewText,
-		reviewRoute.Stars,
-		reviewRoute.CreatedAt,
-		reviewRoute.IsDeleted,
-	)
-	if err != nil {
-		return nil, err
-	}
+func (p *Pg) SaveRoute(ctx context.Context, routeWithGeo *models.RouteWithGeo) error {
+	err := saveRoute(ctx, p.db, &routeWithGeo.Route)
+	routeWithGeo.Geo = p.routeToRouteWithGeo(ctx, routeWithGeo.Route).Geo
 
-	reviewRoute.ID = id
-	return &reviewRoute, nil
+	return err
 }
 
-func (p *Pg) SaveReviewRoute(ctx context.Context, reviewEvent *models.ReviewRoute) error {
-	_, err := p.db.ExecContext(
+func saveRoute(ctx context.Context, db execer, route *models.Route) error {
+	_, err := db.ExecContext(
 		ctx,
-		`UPDATE reviews_routes SET review_text = $1, stars = $2 WHERE owner_id = $3 AND route_id = $4`,
-		reviewEvent.ReviewText, reviewEvent.Stars, reviewEvent.OwnerID, reviewEvent.RouteID,
+		`
+			UPDATE routes 
+				SET name = $1, description = $2, events = $3, places = $4, translations = $5
+				WHERE id = $6
+		`,
+		route.Name, route.Description, route.Events, route.Places, route.Translations,
+		route.ID,
 	)
 
 	return err
 }
 
-func (p *Pg) GetReviewRoute(ctx context.Context, ownerID uuid.UUID, routeID uuid.UUID) (*models.ReviewRoute, error) {
-	var reviewRoute models.ReviewRoute
-	err := p.db.GetContext(ctx, &reviewRoute, "SELECT * FROM reviews_routes WHERE owner_id = $1 AND route_id = $2 AND is_deleted = false", ownerID, routeID)
-
-	return &reviewRoute, err
-}
-
-func (p *Pg) GetReviewsRoute(ctx context.Context, routeID uuid.UUID) ([]models.ReviewRoute, error) {
-	var reviewRoute []models.ReviewRoute
-	err := p.db.SelectContext(ctx, &reviewRoute, "SELECT * FROM reviews_routes WHERE route_id = $1 AND is_deleted = false", routeID)
-
-	return reviewRoute, err
-}
-
 func (p *Pg) sliceRouteToSliceRouteWithGeo(ctx context.Context, routes []models.Route) []models.RouteWithGeo {
 	var routesWithGeo []models.RouteWithGeo
 
@@ -147,7 +130,7 @@ func (p *Pg) routeToRouteWithGeo(ctx context.Context, route models.Route) models
 			continue
 		}
 
-		event, err := p.GetEvent(ctx, eID)
+		event, err := p.GetEvent(ctx, eID, false)
 		if err != nil {
 			continue
 		}
@@ -164,7 +147,7 @@ func (p *Pg) routeToRouteWithGeo(ctx context.Context, route models.Route) models
 			continue
 		}
 
-		place, err := p.GetPlace(ctx, pID)
+		place, err := p.GetPlace(ctx, pID, false)
 		if err != nil {
 			continue
 		}
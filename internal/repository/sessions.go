@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+const sessionsQuery = `
+	SELECT s.*, u.vk_id
+	FROM sessions s
+	JOIN users u ON u.id = s.user_id
+`
+
+func (p *Pg) NewSession(ctx context.Context, session models.Session) (*models.Session, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		"INSERT INTO sessions (user_id, refresh_jti, user_agent, ip, expires_at) VALUES ($1, $2, $3, $4, $5)",
+		session.UserID,
+		session.RefreshJTI,
+		session.UserAgent,
+		session.IP,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	session.ID = id
+	session.CreatedAt = time.Now()
+	session.RefreshedAt = session.CreatedAt
+	return &session, nil
+}
+
+// GetSession читает сессию с мастера, чтобы только что выданный токен принимался сразу,
+// а отозванная сессия переставала действовать, не дожидаясь реплики.
+func (p *Pg) GetSession(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+	var session models.Session
+	err := p.db.GetMaster().GetContext(ctx, &session, sessionsQuery+"WHERE s.id = $1", id)
+
+	return &session, err
+}
+
+// GetUserSessions возвращает действующие сессии пользователя, начиная с последней обновленной.
+func (p *Pg) GetUserSessions(ctx context.Context, userID uuid.UUID) ([]models.Session, error) {
+	sessions := make([]models.Session, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&sessions,
+		sessionsQuery+"WHERE s.user_id = $1 AND s.revoked_at IS NULL AND s.expires_at > NOW() ORDER BY s.refreshed_at DESC",
+		userID,
+	)
+
+	return sessions, err
+}
+
+// RotateSessionRefresh заменяет jti токена обновления и продлевает сессию.
+// Возвращает false, если сессия отозвана, истекла или токен уже был использован.
+func (p *Pg) RotateSessionRefresh(ctx context.Context, id, oldJTI, newJTI uuid.UUID, expiresAt time.Time) (bool, error) {
+	result, err := p.db.ExecContext(
+		ctx,
+		`UPDATE sessions SET refresh_jti = $3, expires_at = $4, refreshed_at = NOW()
+		WHERE id = $1 AND refresh_jti = $2 AND revoked_at IS NULL AND expires_at > NOW()`,
+		id,
+		oldJTI,
+		newJTI,
+		expiresAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// RevokeSession отзывает сессию пользователя. Возвращает false, если сессия не найдена или уже отозвана.
+func (p *Pg) RevokeSession(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	result, err := p.db.ExecContext(
+		ctx,
+		"UPDATE sessions SET revoked_at = NOW() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL",
+		id,
+		userID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func (p *Pg) NewEditSuggestion(ctx context.Context, suggestion models.EditSuggestion) (*models.EditSuggestion, error) {
+	id, err := p.db.ExecContextWithReturnID(
+		ctx,
+		"INSERT INTO edit_suggestions (entity_type, entity_id, user_id, changes, comment) VALUES ($1, $2, $3, $4, $5)",
+		suggestion.EntityType,
+		suggestion.EntityID,
+		suggestion.UserID,
+		string(suggestion.Changes),
+		suggestion.Comment,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion.ID = id
+	suggestion.Status = models.SuggestionStatusPending
+	suggestion.CreatedAt = time.Now()
+	return &suggestion, nil
+}
+
+func (p *Pg) GetEditSuggestion(ctx context.Context, id uuid.UUID) (*models.EditSuggestion, error) {
+	var suggestion models.EditSuggestion
+	err := p.db.GetContext(ctx, &suggestion, "SELECT * FROM edit_suggestions WHERE id = $1", id)
+
+	return &suggestion, err
+}
+
+// GetEditSuggestions возвращает предложенные изменения, старые первыми. Пустые параметры и nil companyID
+// не ограничивают выборку, companyID оставляет изменения самой компании и принадлежащих ей сущностей.
+func (p *Pg) GetEditSuggestions(ctx context.Context, status, entityType string, companyID *uuid.UUID) ([]models.EditSuggestion, error) {
+	suggestions := make([]models.EditSuggestion, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&suggestions,
+		`SELECT * FROM edit_suggestions
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR entity_type = $2) AND (
+			$3::uuid IS NULL
+			OR (entity_type = 'company' AND entity_id = $3)
+			OR (entity_type = 'place' AND entity_id IN (SELECT id FROM places WHERE company_id = $3))
+			OR (entity_type = 'event' AND entity_id IN (SELECT id FROM events WHERE company_id = $3))
+			OR (entity_type = 'route' AND entity_id IN (SELECT id FROM routes WHERE company_id = $3))
+		)
+		ORDER BY created_at`,
+		status,
+		entityType,
+		companyID,
+	)
+
+	return suggestions, err
+}
+
+// ReviewEditSuggestion отмечает предложенное изменение принятым или отклоненным. Изменение сначала занимается
+// обновлением статуса, и только затем в той же транзакции update применяется к заблокированной сущности,
+// которая сохраняется вместе с версией, поэтому параллельное рассмотрение не применит изменение дважды.
+// Для отклонения update равен nil. Возвращает false, если изменение уже рассмотрено.
+func (p *Pg) ReviewEditSuggestion(ctx context.Context, suggestion *models.EditSuggestion, status string, reviewerID uuid.UUID, comment string, update EntityUpdate) (bool, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE edit_suggestions SET status = $1, reviewer_id = $2, review_comment = $3, reviewed_at = NOW()
+		WHERE id = $4 AND status = 'pending'`,
+		status,
+		reviewerID,
+		comment,
+		suggestion.ID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	if update != nil {
+		if _, err = updateEntity(ctx, tx, suggestion.EntityType, suggestion.EntityID, update); err != nil {
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	suggestion.Status = status
+	suggestion.ReviewerID = &reviewerID
+	suggestion.ReviewComment = comment
+	suggestion.ReviewedAt = &now
+	return true, nil
+}
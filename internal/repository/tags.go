@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const tagColumns = "tags.*, ARRAY(SELECT slug FROM tag_aliases WHERE tag_id = tags.id ORDER BY slug) AS aliases"
+
+// tagFilter возвращает условие наличия у места или события тега из параметра $n или одного из его дочерних тегов.
+// Если параметр NULL, условие выполняется для всех записей.
+func tagFilter(n int) string {
+	return fmt.Sprintf("($%[1]d::uuid IS NULL OR tag_ids && ARRAY(SELECT id FROM tags WHERE id = $%[1]d OR parent_id = $%[1]d))", n)
+}
+
+// routeTagFilter то же, что tagFilter, для маршрутов: подходит маршрут, в котором есть место или событие с тегом.
+func routeTagFilter(n int) string {
+	return fmt.Sprintf(`($%[1]d::uuid IS NULL OR EXISTS (
+		SELECT 1 FROM places WHERE id::text = ANY(routes.places) AND %[2]s
+		UNION ALL
+		SELECT 1 FROM events WHERE id::text = ANY(routes.events) AND %[2]s
+	))`, n, tagFilter(n))
+}
+
+// listFilter возвращает условия и порядок списка мест или событий с параметрами начиная с $n и значения этих параметров.
+func listFilter(filter models.ListFilter, n int) (string, []any) {
+	return cityFilter(n) + " AND " + tagFilter(n+1) + orderBy(filter.Sort), []any{filter.CityID, filter.TagID}
+}
+
+// routeListFilter то же, что listFilter, для маршрутов.
+func routeListFilter(filter models.ListFilter, n int) (string, []any) {
+	return routeCityFilter(n) + " AND " + routeTagFilter(n+1) + orderBy(filter.Sort), []any{filter.CityID, filter.TagID}
+}
+
+func (p *Pg) NewTag(ctx context.Context, tag models.Tag) (*models.Tag, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = tx.GetContext(
+		ctx,
+		&tag.ID,
+		"INSERT INTO tags (parent_id, slug, labels, icon) VALUES ($1, $2, $3, $4) RETURNING id",
+		tag.ParentID,
+		tag.Slug,
+		tag.Labels,
+		tag.Icon,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Slug нового тега больше не ведет к тегу, у которого он был прежним
+	if _, err = tx.ExecContext(ctx, "DELETE FROM tag_aliases WHERE slug = $1", tag.Slug); err != nil {
+		return nil, err
+	}
+
+	tag.Aliases = make(pq.StringArray, 0)
+	tag.CreatedAt = time.Now()
+	return &tag, tx.Commit()
+}
+
+// SaveTag сохраняет тег. Если slug изменился, прежний slug остается синонимом тега.
+func (p *Pg) SaveTag(ctx context.Context, tag *models.Tag, previousSlug string) error {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE tags SET parent_id = $1, slug = $2, labels = $3, icon = $4 WHERE id = $5",
+		tag.ParentID,
+		tag.Slug,
+		tag.Labels,
+		tag.Icon,
+		tag.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if previousSlug != tag.Slug {
+		if _, err = tx.ExecContext(ctx, "DELETE FROM tag_aliases WHERE slug = $1", tag.Slug); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO tag_aliases (slug, tag_id) VALUES ($1, $2) ON CONFLICT (slug) DO UPDATE SET tag_id = EXCLUDED.tag_id",
+			previousSlug,
+			tag.ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetTags возвращает справочник: сначала категории верхнего уровня, затем дочерние теги.
+func (p *Pg) GetTags(ctx context.Context) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0)
+	err := p.db.SelectContext(ctx, &tags, "SELECT "+tagColumns+" FROM tags ORDER BY parent_id NULLS FIRST, slug")
+
+	return tags, err
+}
+
+func (p *Pg) GetTag(ctx context.Context, id uuid.UUID) (*models.Tag, error) {
+	var tag models.Tag
+	err := p.db.GetContext(ctx, &tag, "SELECT "+tagColumns+" FROM tags WHERE id = $1", id)
+
+	return &tag, err
+}
+
+// GetTagBySlug ищет тег по текущему или прежнему slug.
+func (p *Pg) GetTagBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	var tag models.Tag
+	err := p.db.GetContext(
+		ctx,
+		&tag,
+		"SELECT "+tagColumns+" FROM tags WHERE slug = $1 OR id = (SELECT tag_id FROM tag_aliases WHERE slug = $1) ORDER BY slug = $1 DESC LIMIT 1",
+		slug,
+	)
+
+	return &tag, err
+}
+
+// CountTags возвращает, сколько тегов из ids существует.
+func (p *Pg) CountTags(ctx context.Context, ids []uuid.UUID) (int, error) {
+	var count int
+	err := p.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM tags WHERE id = ANY($1::uuid[])", pq.Array(ids))
+
+	return count, err
+}
+
+// HasChildTags проверяет, есть ли у тега дочерние теги.
+func (p *Pg) HasChildTags(ctx context.Context, id uuid.UUID) (bool, error) {
+	var exists bool
+	err := p.db.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM tags WHERE parent_id = $1)", id)
+
+	return exists, err
+}
+
+// MergeTags переносит места, события, дочерние теги и синонимы тега sourceID в targetID и удаляет sourceID.
+// Slug удаленного тега становится синонимом targetID, недостающие названия на других языках берутся из sourceID.
+func (p *Pg) MergeTags(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var ids []uuid.UUID
+	if err = tx.SelectContext(ctx, &ids, "SELECT id FROM tags WHERE id IN ($1, $2) FOR UPDATE", sourceID, targetID); err != nil {
+		return err
+	}
+	if len(ids) != 2 {
+		return sql.ErrNoRows
+	}
+
+	for _, table := range []string{"places", "events"} {
+		_, err = tx.ExecContext(
+			ctx,
+			fmt.Sprintf(`UPDATE %s SET tag_ids = ARRAY(SELECT DISTINCT unnest(array_replace(tag_ids, $1, $2)))
+			WHERE tag_ids @> ARRAY[$1::uuid]`, table),
+			sourceID,
+			targetID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	queries := []string{
+		// Если targetID был дочерним тегом sourceID, он занимает место sourceID в иерархии
+		"UPDATE tags SET parent_id = (SELECT parent_id FROM tags WHERE id = $1) WHERE id = $2 AND parent_id = $1",
+		"UPDATE tags SET parent_id = $2 WHERE parent_id = $1",
+		"UPDATE tags SET labels = source.labels || tags.labels FROM tags AS source WHERE source.id = $1 AND tags.id = $2",
+		"UPDATE tag_aliases SET tag_id = $2 WHERE tag_id = $1",
+		"INSERT INTO tag_aliases (slug, tag_id) SELECT slug, $2 FROM tags WHERE id = $1 ON CONFLICT (slug) DO UPDATE SET tag_id = EXCLUDED.tag_id",
+		"DELETE FROM tags WHERE id = $1",
+	}
+	for _, query := range queries {
+		if _, err = tx.ExecContext(ctx, query, sourceID, targetID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Веса сигналов популярности: просмотр - самый слабый сигнал, отзыв - самый сильный
+const (
+	trendingViewWeight     = 1
+	trendingBookmarkWeight = 3
+	trendingRSVPWeight     = 4
+	trendingCheckinWeight  = 5
+	trendingReviewWeight   = 6
+)
+
+// trendingHorizon сигналы старше стольких периодов полураспада не учитываются: их вклад меньше 0,5%
+const trendingHorizon = 8
+
+// trendingLockKey ключ advisory-блокировки, чтобы пересчет не выполнялся несколькими репликами одновременно
+const trendingLockKey = 41
+
+// Просмотры хранятся по дням и считаются сделанными в середине дня
+var trendingSignals = fmt.Sprintf(`
+	SELECT entity_type, entity_id, views * %d AS weight, LEAST(NOW(), day + INTERVAL '12 hours') AS at FROM entity_views
+	UNION ALL
+	SELECT entity_type, entity_id, %d, created_at FROM bookmarks
+	UNION ALL
+	SELECT 'event', event_id, %d, created_at FROM event_rsvps
+	UNION ALL
+	SELECT entity_type, entity_id, %d, created_at FROM checkins
+	UNION ALL
+	SELECT entity_type, entity_id, %d, created_at FROM reviews WHERE is_deleted = false`,
+	trendingViewWeight,
+	trendingBookmarkWeight,
+	trendingRSVPWeight,
+	trendingCheckinWeight,
+	trendingReviewWeight,
+)
+
+// orderBy возвращает ORDER BY для порядка списка из models.SortTrending и models.SortRating.
+func orderBy(sort string) string {
+	switch sort {
+	case models.SortTrending:
+		return " ORDER BY trending_score DESC, id"
+	case models.SortRating:
+		return " ORDER BY rating_avg DESC, rating_count DESC, id"
+	default:
+		return ""
+	}
+}
+
+// RecordView увеличивает счетчик просмотров объекта за текущий день, если viewer еще не смотрел его сегодня.
+func (p *Pg) RecordView(ctx context.Context, entityType string, entityID uuid.UUID, viewer string) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		`WITH viewer AS (
+			INSERT INTO entity_viewers (entity_type, entity_id, day, viewer) VALUES ($1, $2, CURRENT_DATE, $3)
+			ON CONFLICT DO NOTHING
+			RETURNING day
+		)
+		INSERT INTO entity_views (entity_type, entity_id, day, views) SELECT $1, $2, day, 1 FROM viewer
+		ON CONFLICT (entity_type, entity_id, day) DO UPDATE SET views = entity_views.views + 1`,
+		entityType,
+		entityID,
+		viewer,
+	)
+
+	return err
+}
+
+// NewRSVP отмечает, что пользователь пойдет на событие. Повторная отметка ничего не меняет.
+func (p *Pg) NewRSVP(ctx context.Context, userID, eventID uuid.UUID) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO event_rsvps (user_id, event_id) VALUES ($1, $2) ON CONFLICT (user_id, event_id) DO NOTHING",
+		userID,
+		eventID,
+	)
+
+	return err
+}
+
+func (p *Pg) DeleteRSVP(ctx context.Context, userID, eventID uuid.UUID) (bool, error) {
+	result, err := p.db.ExecContext(ctx, "DELETE FROM event_rsvps WHERE user_id = $1 AND event_id = $2", userID, eventID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected != 0, err
+}
+
+// GetTrending возвращает самые популярные сейчас объекты. Пустой entityType - объекты всех типов.
+// Прошедшие события не возвращаются.
+func (p *Pg) GetTrending(ctx context.Context, entityType string, cityID *uuid.UUID, limit int) ([]models.TrendingItem, error) {
+	items := make([]models.TrendingItem, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&items,
+		`SELECT * FROM (
+			SELECT 'place' AS entity_type, id AS entity_id, name, translations, trending_score, rating_avg, rating_count FROM places
+				WHERE is_deleted = false AND `+cityFilter(2)+`
+			UNION ALL
+			SELECT 'event', id, name, translations, trending_score, rating_avg, rating_count FROM events
+				WHERE is_deleted = false AND start_time >= NOW() AND `+cityFilter(2)+`
+			UNION ALL
+			SELECT 'route', id, name, translations, trending_score, rating_avg, rating_count FROM routes
+				WHERE is_deleted = false AND `+routeCityFilter(2)+`
+		) AS trending
+		WHERE ($1 = '' OR entity_type = $1) AND trending_score > 0
+		ORDER BY trending_score DESC, entity_id
+		LIMIT $3`,
+		entityType,
+		cityID,
+		limit,
+	)
+
+	return items, err
+}
+
+// RefreshTrendingScores пересчитывает популярность мест, событий и маршрутов: сумму весов просмотров, закладок,
+// отметок "пойду", посещений и отзывов, где вклад каждого сигнала вдвое уменьшается за halfLife.
+// Если пересчет уже выполняет другая реплика, функция ничего не делает.
+func (p *Pg) RefreshTrendingScores(ctx context.Context, halfLife time.Duration) error {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err = tx.GetContext(ctx, &locked, "SELECT pg_try_advisory_xact_lock($1)", trendingLockKey); err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	for entityType, table := range entityTables {
+		_, err = tx.ExecContext(
+			ctx,
+			fmt.Sprintf(`WITH scores AS (
+				SELECT entity_id, SUM(weight * EXP(-LN(2) * EXTRACT(EPOCH FROM NOW() - at) / $2::float8)) AS score
+				FROM (%s) AS signals
+				WHERE entity_type = $1 AND at > NOW() - make_interval(secs => $2::float8 * %d)
+				GROUP BY entity_id
+			)
+			UPDATE %[3]s SET trending_score = COALESCE(scores.score, 0)
+			FROM %[3]s AS entity LEFT JOIN scores ON scores.entity_id = entity.id
+			WHERE %[3]s.id = entity.id AND %[3]s.trending_score <> COALESCE(scores.score, 0)`, trendingSignals, trendingHorizon, table),
+			entityType,
+			halfLife.Seconds(),
+		)
+		if err != nil {
+			return fmt.Errorf("refresh %s: %w", table, err)
+		}
+	}
+
+	// Зрители нужны только для учета просмотров текущего дня
+	if _, err = tx.ExecContext(ctx, "DELETE FROM entity_viewers WHERE day < CURRENT_DATE"); err != nil {
+		return fmt.Errorf("delete entity viewers: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// WatchTrendingScores пересчитывает популярность каждые interval, пока не отменен ctx.
+func (p *Pg) WatchTrendingScores(ctx context.Context, interval, halfLife time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.RefreshTrendingScores(ctx, halfLife); err != nil {
+			p.logger.Error("Error refresh trending scores", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
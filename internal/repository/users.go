@@ -6,34 +6,31 @@ import (
 	"github.com/google/uuid"
 )
 
-func (p *Pg) NewUser(ctx context.Context, user models.User) (*models.User, error) {
-	id, err := p.db.ExecContextWithReturnID(
+// UpdateUser меняет только переданные в changes поля пользователя, чтобы не затереть изменения,
+// сделанные другим экземпляром, и возвращает пользователя после изменения.
+func (p *Pg) UpdateUser(ctx context.Context, id uuid.UUID, changes models.UserChanges) (*models.User, error) {
+	var user models.User
+	err := p.db.GetMaster().GetContext(
 		ctx,
-		"INSERT INTO users (vk_id, passed_onboarding, selected_geo) VALUES ($1, $2, $3)",
-		user.VkID,
-		user.PassedOnboarding,
-		user.SelectedGeo,
+		&user,
+		`UPDATE users SET
+			passed_onboarding = passed_onboarding OR $1,
+			selected_geo = CASE WHEN $2 THEN $3 ELSE selected_geo END,
+			selected_locality_id = CASE WHEN $2 THEN $4 ELSE selected_locality_id END,
+			home_city_id = COALESCE($5, home_city_id)
+		WHERE id = $6
+		RETURNING *`,
+		changes.PassedOnboarding,
+		changes.SelectedGeo != nil,
+		changes.SelectedGeo,
+		changes.SelectedLocalityID,
+		changes.HomeCityID,
+		id,
 	)
-	if err != nil {
-		return nil, err
-	}
 
-	user.ID = id
 	return &user, err
 }
 
-func (p *Pg) SaveUser(ctx context.Context, user *models.User) error {
-	_, err := p.db.ExecContext(
-		ctx,
-		"UPDATE users SET passed_onboarding = $1, selected_geo = $2 WHERE id = $3",
-		user.PassedOnboarding,
-		user.SelectedGeo,
-		user.ID,
-	)
-
-	return err
-}
-
 func (p *Pg) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	var user models.User
 	err := p.db.GetContext(ctx, &user, "SELECT * FROM users WHERE id = $1", id)
@@ -47,3 +44,24 @@ func (p *Pg) GetUserByVkID(ctx context.Context, vkID int64) (*models.User, error
 
 	return &user, err
 }
+
+// UpsertUser создает пользователя при первом обращении и обновляет время последнего обращения.
+// Пустые platform и language (сессии и ключи API) не затирают сохраненные значения.
+func (p *Pg) UpsertUser(ctx context.Context, vkID int64, platform, language string) (*models.User, error) {
+	var user models.User
+	err := p.db.GetMaster().GetContext(
+		ctx,
+		&user,
+		`INSERT INTO users (vk_id, platform, language) VALUES ($1, $2, $3)
+		ON CONFLICT (vk_id) DO UPDATE SET
+			last_seen_at = now(),
+			platform = COALESCE(NULLIF(EXCLUDED.platform, ''), users.platform),
+			language = COALESCE(NULLIF(EXCLUDED.language, ''), users.language)
+		RETURNING *`,
+		vkID,
+		platform,
+		language,
+	)
+
+	return &user, err
+}
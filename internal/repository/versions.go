@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// EntityUpdate применяет изменение к сущности, прочитанной с мастера под блокировкой, и возвращает его вместе
+// с версией. entity - *models.Place, *models.Event, *models.RouteWithGeo или *models.Company.
+type EntityUpdate func(entity any) (*models.EntityChange, error)
+
+// UpdateEntity изменяет сущность и сохраняет ее версию в одной транзакции. Строка блокируется и читается заново
+// с мастера, поэтому параллельные правки не затирают друг друга, а версия описывает именно это изменение.
+// Возвращает сохраненную сущность.
+func (p *Pg) UpdateEntity(ctx context.Context, entityType string, entityID uuid.UUID, update EntityUpdate) (any, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	entity, err := updateEntity(ctx, tx, entityType, entityID, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	p.afterEntitySaved(ctx, entity)
+	return entity, nil
+}
+
+// updateEntity блокирует и читает сущность внутри транзакции, применяет к ней update и сохраняет вместе с версией.
+func updateEntity(ctx context.Context, tx *sqlx.Tx, entityType string, entityID uuid.UUID, update EntityUpdate) (any, error) {
+	entity, err := getEntityForUpdate(ctx, tx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := update(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = saveEntityChange(ctx, tx, *change); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// getEntityForUpdate читает неудаленную сущность с блокировкой строки до конца транзакции.
+func getEntityForUpdate(ctx context.Context, tx *sqlx.Tx, entityType string, entityID uuid.UUID) (any, error) {
+	switch entityType {
+	case models.EntityTypePlace:
+		var place models.Place
+		if err := tx.GetContext(ctx, &place, "SELECT * FROM places WHERE id = $1 AND is_deleted = false FOR UPDATE", entityID); err != nil {
+			return nil, err
+		}
+
+		place.OpenNow = place.OpenAt(time.Now())
+		return &place, nil
+	case models.EntityTypeEvent:
+		var event models.Event
+		err := tx.GetContext(ctx, &event, "SELECT * FROM events WHERE id = $1 AND is_deleted = false FOR UPDATE", entityID)
+
+		return &event, err
+	case models.EntityTypeRoute:
+		var route models.RouteWithGeo
+		err := tx.GetContext(ctx, &route.Route, "SELECT * FROM routes WHERE id = $1 AND is_deleted = false FOR UPDATE", entityID)
+
+		return &route, err
+	case models.EntityTypeCompany:
+		var company models.Company
+		err := tx.GetContext(ctx, &company, "SELECT * FROM companies WHERE id = $1 FOR UPDATE", entityID)
+
+		return &company, err
+	}
+
+	return nil, fmt.Errorf("unsupported versioned entity type %q", entityType)
+}
+
+// createEntity вставляет сущность запросом insert и в той же транзакции сохраняет ее первую версию, чтобы
+// созданная сущность не осталась без истории. version.Snapshot - снимок создаваемой сущности.
+func (p *Pg) createEntity(ctx context.Context, version models.EntityVersion, insert string, args ...any) (uuid.UUID, error) {
+	tx, err := p.db.BeginMaster(ctx, nil)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	if err = tx.QueryRowxContext(ctx, insert+" RETURNING id", args...).Scan(&id); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	version.EntityID = id
+	if _, err = newEntityVersion(ctx, tx, version, nil); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	return id, tx.Commit()
+}
+
+// saveEntityChange сохраняет сущность и ее версию внутри транзакции.
+func saveEntityChange(ctx context.Context, tx *sqlx.Tx, change models.EntityChange) error {
+	var err error
+	switch entity := change.Entity.(type) {
+	case *models.Place:
+		err = savePlace(ctx, tx, entity)
+	case *models.Event:
+		err = saveEvent(ctx, tx, entity)
+	case *models.RouteWithGeo:
+		err = saveRoute(ctx, tx, &entity.Route)
+	case *models.Company:
+		err = saveCompany(ctx, tx, entity)
+	default:
+		err = fmt.Errorf("unsupported versioned entity %T", change.Entity)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = newEntityVersion(ctx, tx, change.Version, change.Before)
+	return err
+}
+
+// afterEntitySaved обновляет вычисляемые поля сущности после фиксации транзакции.
+func (p *Pg) afterEntitySaved(ctx context.Context, entity any) {
+	if route, ok := entity.(*models.RouteWithGeo); ok {
+		route.Geo = p.routeToRouteWithGeo(ctx, route.Route).Geo
+	}
+}
+
+// newEntityVersion сохраняет следующую версию сущности внутри транзакции. Версия без измененных полей
+// не сохраняется, кроме объединения мест: оно меняет только служебные поля, но должно остаться в истории.
+func newEntityVersion(ctx context.Context, tx *sqlx.Tx, version models.EntityVersion, before models.EntitySnapshot) (*models.EntityVersion, error) {
+	// Номера версий одной сущности выдаются последовательно
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2::text))", version.EntityType, version.EntityID); err != nil {
+		return nil, err
+	}
+
+	var previous models.EntityVersion
+	err := tx.GetContext(
+		ctx,
+		&previous,
+		"SELECT * FROM entity_versions WHERE entity_type = $1 AND entity_id = $2 ORDER BY version DESC LIMIT 1",
+		version.EntityType,
+		version.EntityID,
+	)
+	if errors.Is(err, sql.ErrNoRows) && before != nil {
+		previous = models.EntityVersion{
+			EntityType:    version.EntityType,
+			EntityID:      version.EntityID,
+			Version:       1,
+			Action:        models.VersionActionInitial,
+			Snapshot:      before,
+			ChangedFields: before.ChangedFields(nil),
+		}
+		err = insertEntityVersion(ctx, tx, &previous)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	version.Version = previous.Version + 1
+	version.ChangedFields = version.Snapshot.ChangedFields(previous.Snapshot)
+	if previous.Version > 0 && len(version.ChangedFields) == 0 && version.Action != models.VersionActionMerge {
+		return nil, nil
+	}
+
+	if err = insertEntityVersion(ctx, tx, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+func insertEntityVersion(ctx context.Context, tx *sqlx.Tx, version *models.EntityVersion) error {
+	return tx.QueryRowxContext(
+		ctx,
+		`INSERT INTO entity_versions (entity_type, entity_id, version, action, user_id, suggestion_id, snapshot, changed_fields)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`,
+		version.EntityType,
+		version.EntityID,
+		version.Version,
+		version.Action,
+		version.UserID,
+		version.SuggestionID,
+		version.Snapshot,
+		version.ChangedFields,
+	).Scan(&version.ID, &version.CreatedAt)
+}
+
+// GetEntityVersions возвращает историю сущности, новые версии первыми.
+func (p *Pg) GetEntityVersions(ctx context.Context, entityType string, entityID uuid.UUID) ([]models.EntityVersion, error) {
+	versions := make([]models.EntityVersion, 0)
+	err := p.db.SelectContext(
+		ctx,
+		&versions,
+		"SELECT * FROM entity_versions WHERE entity_type = $1 AND entity_id = $2 ORDER BY version DESC",
+		entityType,
+		entityID,
+	)
+
+	return versions, err
+}
+
+// GetEntityVersion возвращает версию сущности по номеру, 0 - последнюю версию.
+func (p *Pg) GetEntityVersion(ctx context.Context, entityType string, entityID uuid.UUID, version int) (*models.EntityVersion, error) {
+	var entityVersion models.EntityVersion
+	err := p.db.GetContext(
+		ctx,
+		&entityVersion,
+		`SELECT * FROM entity_versions WHERE entity_type = $1 AND entity_id = $2 AND ($3 = 0 OR version = $3)
+		ORDER BY version DESC LIMIT 1`,
+		entityType,
+		entityID,
+		version,
+	)
+
+	return &entityVersion, err
+}
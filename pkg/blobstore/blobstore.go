@@ -0,0 +1,30 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ShpullRequest/backend/internal/config"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+func New(cfg config.NodeConfig) (Store, error) {
+	switch cfg.BlobStorage {
+	case "", "local":
+		return NewLocal(cfg.BlobLocalPath, cfg.BlobPublicURL)
+	case "s3":
+		return NewS3(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.BlobPublicURL)
+	default:
+		return nil, fmt.Errorf("unknown blob storage %q", cfg.BlobStorage)
+	}
+}
@@ -0,0 +1,211 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// s3 хранит файлы в S3-совместимом хранилище (path-style адресация),
+// запросы подписываются AWS Signature Version 4.
+type s3 struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicURL string
+	client    *http.Client
+}
+
+func NewS3(endpoint, region, bucket, accessKey, secretKey, publicURL string) (*s3, error) {
+	if endpoint == "" || bucket == "" {
+		return nil, errors.New("s3 endpoint and bucket are required")
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("url.Parse: %w", err)
+	}
+
+	s := &s3{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+
+	// Если публичный адрес не задан явно, отдаем файлы напрямую из бакета
+	if s.publicURL == "" || strings.HasPrefix(s.publicURL, "/") {
+		s.publicURL = fmt.Sprintf("%s/%s", u.String(), bucket)
+	}
+
+	return s, nil
+}
+
+func (s *s3) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	sum := sha256.Sum256(data)
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, bytes.NewReader(data), hex.EncodeToString(sum[:]))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, hex.EncodeToString(sum[:]), time.Now().UTC())
+
+	response, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return s.checkResponse(response)
+}
+
+func (s *s3) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, emptyPayloadHash, time.Now().UTC())
+
+	response, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkResponse(response); err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (s *s3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	s.sign(req, emptyPayloadHash, time.Now().UTC())
+
+	response, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if err = s.checkResponse(response); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *s3) URL(key string) string {
+	return fmt.Sprintf("%s/%s", s.publicURL, key)
+}
+
+func (s *s3) newRequest(ctx context.Context, method, key string, body io.Reader, payloadHash string) (*http.Request, error) {
+	u := *s.endpoint
+	u.Path = fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(u.Path, "/"), s.bucket, key)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	return req, nil
+}
+
+func (s *s3) checkResponse(response *http.Response) error {
+	if response.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("s3: unexpected status %d: %s", response.StatusCode, body)
+	}
+
+	return nil
+}
+
+func (s *s3) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, s.region)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
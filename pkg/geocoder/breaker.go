@@ -0,0 +1,108 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+)
+
+// ErrCircuitOpen возвращается без обращения к провайдеру, пока автомат защиты разомкнут.
+var ErrCircuitOpen = errors.New("geocoder circuit is open")
+
+// Breaker размыкается после threshold ошибок подряд и не обращается к провайдеру в течение cooldown.
+// После паузы пропускает один пробный запрос: успех замыкает цепь, ошибка снова размыкает.
+type Breaker struct {
+	next      Geocoder
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func NewBreaker(next Geocoder, threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		next:      next,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+func (b *Breaker) GetAddressByGeo(ctx context.Context, lng, lat float64) (*models.GeoCandidate, error) {
+	return guard(b, func() (*models.GeoCandidate, error) {
+		return b.next.GetAddressByGeo(ctx, lng, lat)
+	})
+}
+
+func (b *Breaker) GetAddressByGeoQ(ctx context.Context, q string) (string, error) {
+	return guard(b, func() (string, error) {
+		return b.next.GetAddressByGeoQ(ctx, q)
+	})
+}
+
+func (b *Breaker) Suggest(ctx context.Context, q string, limit int) ([]models.GeoCandidate, error) {
+	return guard(b, func() ([]models.GeoCandidate, error) {
+		return b.next.Suggest(ctx, q, limit)
+	})
+}
+
+func guard[T any](b *Breaker, call func() (T, error)) (T, error) {
+	if !b.allow() {
+		var zero T
+		return zero, ErrCircuitOpen
+	}
+
+	value, err := call()
+	if errors.Is(err, context.Canceled) {
+		b.release()
+	} else {
+		b.record(err == nil || errors.Is(err, ErrNoAddress))
+	}
+
+	return value, err
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+
+	if time.Now().Before(b.openUntil) || b.probing {
+		return false
+	}
+
+	b.probing = true
+	return true
+}
+
+// release снимает пробный запрос, не считая его ни успехом, ни ошибкой: запрос отменил клиент.
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,105 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	provider := newFlaky(-1, errUpstream)
+	breaker := NewBreaker(provider, 3, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.GetAddressByGeoQ(context.Background(), "Невский"); !errors.Is(err, errUpstream) {
+			t.Fatalf("call %d: err = %v, want upstream error", i+1, err)
+		}
+	}
+
+	if _, err := breaker.GetAddressByGeoQ(context.Background(), "Невский"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if calls := provider.callCount(); calls != 3 {
+		t.Errorf("provider calls = %d, want 3", calls)
+	}
+}
+
+func TestBreakerNoAddressIsNotFailure(t *testing.T) {
+	breaker := NewBreaker(NewFake(), 1, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.GetAddressByGeoQ(context.Background(), " "); !errors.Is(err, ErrNoAddress) {
+			t.Fatalf("call %d: err = %v, want ErrNoAddress", i+1, err)
+		}
+	}
+}
+
+func TestBreakerCanceledIsNotFailure(t *testing.T) {
+	provider := newFlaky(-1, context.Canceled)
+	breaker := NewBreaker(provider, 1, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.GetAddressByGeoQ(context.Background(), "Невский"); !errors.Is(err, context.Canceled) {
+			t.Fatalf("call %d: err = %v, want context.Canceled", i+1, err)
+		}
+	}
+	if calls := provider.callCount(); calls != 3 {
+		t.Errorf("provider calls = %d, want 3", calls)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name       string
+		probeFails bool
+		wantAfter  error
+	}{
+		{name: "successful probe closes", probeFails: false, wantAfter: nil},
+		{name: "failed probe reopens", probeFails: true, wantAfter: ErrCircuitOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cooldown := 20 * time.Millisecond
+			provider := newFlaky(2, errUpstream)
+			breaker := NewBreaker(provider, 2, cooldown)
+
+			for i := 0; i < 2; i++ {
+				_, _ = breaker.GetAddressByGeoQ(context.Background(), "Невский")
+			}
+			if _, err := breaker.GetAddressByGeoQ(context.Background(), "Невский"); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("err = %v, want ErrCircuitOpen", err)
+			}
+
+			time.Sleep(cooldown + 10*time.Millisecond)
+			if tt.probeFails {
+				provider.setFailures(1)
+			}
+
+			_, err := breaker.GetAddressByGeoQ(context.Background(), "Невский")
+			if tt.probeFails != (err != nil) {
+				t.Fatalf("probe err = %v", err)
+			}
+
+			if _, err = breaker.GetAddressByGeoQ(context.Background(), "Невский"); !errors.Is(err, tt.wantAfter) {
+				t.Errorf("after probe err = %v, want %v", err, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestBreakerSingleProbe(t *testing.T) {
+	cooldown := 10 * time.Millisecond
+	breaker := NewBreaker(newFlaky(1, errUpstream), 1, cooldown)
+
+	_, _ = breaker.GetAddressByGeoQ(context.Background(), "Невский")
+	time.Sleep(cooldown + 10*time.Millisecond)
+
+	if !breaker.allow() {
+		t.Fatal("first probe is not allowed")
+	}
+	if breaker.allow() {
+		t.Error("second request is allowed while probing")
+	}
+}
@@ -0,0 +1,117 @@
+package geocoder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+)
+
+var cacheMetrics = expvar.NewMap("geocoder_cache")
+
+// Store постоянный кэш ответов геокодера, общий для всех реплик. GetGeocode возвращает sql.ErrNoRows,
+// если записи нет или она старше maxAge.
+type Store interface {
+	GetGeocode(ctx context.Context, key string, maxAge time.Duration) (string, error)
+	SaveGeocode(ctx context.Context, key, value string) error
+}
+
+// Cached кэширует ответы геокодера. Координаты округляются до precision знаков после запятой,
+// поэтому соседние точки (при 4 знаках - в пределах ~10 м) используют один адрес.
+type Cached struct {
+	next      Geocoder
+	store     Store
+	memory    *lru
+	ttl       time.Duration
+	precision int
+}
+
+func NewCached(next Geocoder, store Store, size int, ttl time.Duration, precision int) *Cached {
+	return &Cached{
+		next:      next,
+		store:     store,
+		memory:    newLRU(size, ttl),
+		ttl:       ttl,
+		precision: precision,
+	}
+}
+
+func (c *Cached) GetAddressByGeo(ctx context.Context, lng, lat float64) (*models.GeoCandidate, error) {
+	key := fmt.Sprintf("rev:%.*f,%.*f", c.precision, lng, c.precision, lat)
+
+	return cached(ctx, c, key, func() (*models.GeoCandidate, error) {
+		return c.next.GetAddressByGeo(ctx, lng, lat)
+	})
+}
+
+func (c *Cached) GetAddressByGeoQ(ctx context.Context, q string) (string, error) {
+	key := "q:" + normalizeQuery(q)
+
+	return cached(ctx, c, key, func() (string, error) {
+		return c.next.GetAddressByGeoQ(ctx, q)
+	})
+}
+
+func (c *Cached) Suggest(ctx context.Context, q string, limit int) ([]models.GeoCandidate, error) {
+	key := fmt.Sprintf("suggest:%d:%s", limit, normalizeQuery(q))
+
+	return cached(ctx, c, key, func() ([]models.GeoCandidate, error) {
+		return c.next.Suggest(ctx, q, limit)
+	})
+}
+
+// cached ищет значение в памяти, затем в постоянном кэше и только потом обращается к провайдеру.
+// Значения хранятся в JSON, чтобы один Store подходил для ответов разных типов.
+func cached[T any](ctx context.Context, c *Cached, key string, fetch func() (T, error)) (T, error) {
+	var value T
+
+	if encoded, ok := c.memory.get(key); ok && json.Unmarshal([]byte(encoded), &value) == nil {
+		cacheMetrics.Add("memory_hit", 1)
+		return value, nil
+	}
+
+	if c.store != nil {
+		encoded, err := c.store.GetGeocode(ctx, key, c.ttl)
+		if err == nil && json.Unmarshal([]byte(encoded), &value) == nil {
+			cacheMetrics.Add("store_hit", 1)
+			c.memory.add(key, encoded)
+
+			return value, nil
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			// Постоянный кэш лишь ускоряет ответ, поэтому его ошибка не прерывает геокодирование
+			cacheMetrics.Add("store_error", 1)
+		}
+	}
+
+	cacheMetrics.Add("miss", 1)
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return value, nil
+	}
+
+	c.memory.add(key, string(encoded))
+	if c.store != nil {
+		if err = c.store.SaveGeocode(ctx, key, string(encoded)); err != nil {
+			cacheMetrics.Add("store_error", 1)
+		}
+	}
+
+	return value, nil
+}
+
+func normalizeQuery(q string) string {
+	return strings.Join(strings.Fields(strings.ToLower(q)), " ")
+}
@@ -0,0 +1,124 @@
+package geocoder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore постоянный кэш в памяти для тестов.
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (s *memoryStore) GetGeocode(_ context.Context, key string, _ time.Duration) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+
+	return value, nil
+}
+
+func (s *memoryStore) SaveGeocode(_ context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = value
+	return nil
+}
+
+func TestCachedRoundsCoordinates(t *testing.T) {
+	tests := []struct {
+		name      string
+		lng, lat  float64
+		wantCalls int
+	}{
+		{name: "same point", lng: 30.31581, lat: 59.93911, wantCalls: 1},
+		{name: "rounds to the same key", lng: 30.31584, lat: 59.93914, wantCalls: 1},
+		{name: "another key", lng: 30.3168, lat: 59.9391, wantCalls: 2},
+	}
+
+	provider := newFlaky(0, nil)
+	geocoder := NewCached(provider, nil, 100, time.Hour, 4)
+
+	if _, err := geocoder.GetAddressByGeo(context.Background(), 30.31581, 59.93911); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := geocoder.GetAddressByGeo(context.Background(), tt.lng, tt.lat); err != nil {
+				t.Fatal(err)
+			}
+			if calls := provider.callCount(); calls != tt.wantCalls {
+				t.Errorf("provider calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachedNormalizesQuery(t *testing.T) {
+	provider := newFlaky(0, nil)
+	geocoder := NewCached(provider, nil, 100, time.Hour, 4)
+
+	first, err := geocoder.Suggest(context.Background(), "Невский  проспект", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := geocoder.Suggest(context.Background(), " невский ПРОСПЕКТ ", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls := provider.callCount(); calls != 1 {
+		t.Errorf("provider calls = %d, want 1", calls)
+	}
+	if len(first) != len(second) || first[0].Name != second[0].Name {
+		t.Errorf("cached suggest differs: %v and %v", first, second)
+	}
+}
+
+func TestCachedUsesStore(t *testing.T) {
+	store := &memoryStore{values: make(map[string]string)}
+
+	provider := newFlaky(0, nil)
+	if _, err := NewCached(provider, store, 100, time.Hour, 4).GetAddressByGeoQ(context.Background(), "Невский"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Другая реплика с пустым кэшем в памяти берет ответ из общего хранилища
+	other := newFlaky(-1, errUpstream)
+	address, err := NewCached(other, store, 100, time.Hour, 4).GetAddressByGeoQ(context.Background(), "Невский")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "Россия, Тестовый регион, Невский" {
+		t.Errorf("address = %q", address)
+	}
+	if calls := other.callCount(); calls != 0 {
+		t.Errorf("provider calls = %d, want 0", calls)
+	}
+}
+
+func TestCachedDoesNotCacheErrors(t *testing.T) {
+	provider := newFlaky(1, errUpstream)
+	geocoder := NewCached(provider, nil, 100, time.Hour, 4)
+
+	if _, err := geocoder.GetAddressByGeoQ(context.Background(), "Невский"); !errors.Is(err, errUpstream) {
+		t.Fatalf("err = %v, want upstream error", err)
+	}
+	if _, err := geocoder.GetAddressByGeoQ(context.Background(), "Невский"); err != nil {
+		t.Fatalf("err = %v after upstream recovered", err)
+	}
+	if calls := provider.callCount(); calls != 2 {
+		t.Errorf("provider calls = %d, want 2", calls)
+	}
+}
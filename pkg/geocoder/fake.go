@@ -0,0 +1,73 @@
+package geocoder
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/ShpullRequest/backend/internal/models"
+)
+
+// Fake детерминированный геокодер для тестов и локального запуска без ключа VK Maps.
+// Одинаковые координаты и запросы всегда дают одинаковый результат, пустой запрос - ErrNoAddress.
+type Fake struct{}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) GetAddressByGeo(_ context.Context, lng, lat float64) (*models.GeoCandidate, error) {
+	candidate := fakeCandidate(fmt.Sprintf("улица %.4f, %.4f", lat, lng), "1")
+	candidate.Lng, candidate.Lat = lng, lat
+
+	return &candidate, nil
+}
+
+func (f *Fake) GetAddressByGeoQ(_ context.Context, q string) (string, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return "", ErrNoAddress
+	}
+
+	return "Россия, Тестовый регион, " + q, nil
+}
+
+// Suggest возвращает до трех вариантов, координаты которых вычисляются из хэша запроса.
+func (f *Fake) Suggest(_ context.Context, q string, limit int) ([]models.GeoCandidate, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return []models.GeoCandidate{}, nil
+	}
+
+	candidates := make([]models.GeoCandidate, 0, limit)
+	for i := 1; i <= 3 && i <= limit; i++ {
+		hash := fnv.New32a()
+		hash.Write([]byte(fmt.Sprintf("%s#%d", q, i)))
+		sum := hash.Sum32()
+
+		candidate := fakeCandidate(q, fmt.Sprint(i))
+		candidate.Lng = 30 + float64(sum%10000)/1000
+		candidate.Lat = 55 + float64(sum/10000%5000)/1000
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+func fakeCandidate(street, building string) models.GeoCandidate {
+	address := models.Address{
+		Country:    "Россия",
+		Region:     "Тестовый регион",
+		Locality:   "Тестовый город",
+		Street:     street,
+		Building:   building,
+		PostalCode: "000000",
+	}
+
+	return models.GeoCandidate{
+		Address: address,
+		Name:    street + ", " + building,
+		Text:    strings.Join([]string{address.Country, address.Region, address.Street, address.Building}, ", "),
+	}
+}
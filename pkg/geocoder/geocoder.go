@@ -0,0 +1,45 @@
+package geocoder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/config"
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/ShpullRequest/backend/pkg/vk/maps"
+)
+
+var ErrNoAddress = maps.ErrNoAddress
+
+const (
+	breakerThreshold = 5
+	breakerCooldown  = 30 * time.Second
+	retryBaseDelay   = 200 * time.Millisecond
+)
+
+type Geocoder interface {
+	GetAddressByGeo(ctx context.Context, lng, lat float64) (*models.GeoCandidate, error)
+	GetAddressByGeoQ(ctx context.Context, q string) (string, error)
+	Suggest(ctx context.Context, q string, limit int) ([]models.GeoCandidate, error)
+}
+
+// New собирает геокодер из конфигурации: кэш в памяти и в store поверх автомата защиты,
+// который в свою очередь оборачивает повторные попытки запроса к провайдеру.
+// store может быть nil, тогда используется только кэш в памяти.
+func New(cfg config.NodeConfig, store Store) (Geocoder, error) {
+	var provider Geocoder
+	switch cfg.Geocoder {
+	case "", "vk":
+		provider = maps.New(cfg)
+	case "fake":
+		return NewFake(), nil
+	default:
+		return nil, fmt.Errorf("unknown geocoder %q", cfg.Geocoder)
+	}
+
+	provider = NewRetry(provider, cfg.GeocoderRetries, retryBaseDelay)
+	provider = NewBreaker(provider, breakerThreshold, breakerCooldown)
+
+	return NewCached(provider, store, cfg.GeocoderCacheSize, cfg.GeocoderCacheTTL, cfg.GeocoderPrecision), nil
+}
@@ -0,0 +1,78 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ShpullRequest/backend/internal/models"
+)
+
+var errUpstream = errors.New("upstream failed")
+
+// flaky оборачивает Fake: первые failures вызовов возвращают err, остальные отвечают как Fake.
+type flaky struct {
+	*Fake
+
+	mu       sync.Mutex
+	failures int
+	err      error
+	calls    int
+}
+
+func newFlaky(failures int, err error) *flaky {
+	return &flaky{Fake: NewFake(), failures: failures, err: err}
+}
+
+func (f *flaky) fail() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.failures != 0 {
+		if f.failures > 0 {
+			f.failures--
+		}
+		return f.err
+	}
+
+	return nil
+}
+
+func (f *flaky) setFailures(failures int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = failures
+}
+
+func (f *flaky) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
+
+func (f *flaky) GetAddressByGeo(ctx context.Context, lng, lat float64) (*models.GeoCandidate, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+
+	return f.Fake.GetAddressByGeo(ctx, lng, lat)
+}
+
+func (f *flaky) GetAddressByGeoQ(ctx context.Context, q string) (string, error) {
+	if err := f.fail(); err != nil {
+		return "", err
+	}
+
+	return f.Fake.GetAddressByGeoQ(ctx, q)
+}
+
+func (f *flaky) Suggest(ctx context.Context, q string, limit int) ([]models.GeoCandidate, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+
+	return f.Fake.Suggest(ctx, q, limit)
+}
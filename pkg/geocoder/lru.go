@@ -0,0 +1,78 @@
+package geocoder
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lru потокобезопасный LRU-кэш адресов с ограниченным временем жизни записей.
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	items map[string]*list.Element
+	order *list.List
+}
+
+type lruEntry struct {
+	key       string
+	address   string
+	expiresAt time.Time
+}
+
+func newLRU(size int, ttl time.Duration) *lru {
+	return &lru{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (c *lru) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.items, key)
+
+		return "", false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.address, true
+}
+
+func (c *lru) add(key, address string) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.address = address
+		entry.expiresAt = time.Now().Add(c.ttl)
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, address: address, expiresAt: time.Now().Add(c.ttl)})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
@@ -0,0 +1,60 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+)
+
+// Retry повторяет неудачные запросы с экспоненциальной задержкой. Отсутствие адреса не повторяется.
+type Retry struct {
+	next      Geocoder
+	retries   int
+	baseDelay time.Duration
+}
+
+func NewRetry(next Geocoder, retries int, baseDelay time.Duration) *Retry {
+	return &Retry{
+		next:      next,
+		retries:   retries,
+		baseDelay: baseDelay,
+	}
+}
+
+func (r *Retry) GetAddressByGeo(ctx context.Context, lng, lat float64) (*models.GeoCandidate, error) {
+	return retry(ctx, r, func() (*models.GeoCandidate, error) {
+		return r.next.GetAddressByGeo(ctx, lng, lat)
+	})
+}
+
+func (r *Retry) GetAddressByGeoQ(ctx context.Context, q string) (string, error) {
+	return retry(ctx, r, func() (string, error) {
+		return r.next.GetAddressByGeoQ(ctx, q)
+	})
+}
+
+func (r *Retry) Suggest(ctx context.Context, q string, limit int) ([]models.GeoCandidate, error) {
+	return retry(ctx, r, func() ([]models.GeoCandidate, error) {
+		return r.next.Suggest(ctx, q, limit)
+	})
+}
+
+func retry[T any](ctx context.Context, r *Retry, call func() (T, error)) (T, error) {
+	delay := r.baseDelay
+
+	for attempt := 0; ; attempt++ {
+		value, err := call()
+		if err == nil || errors.Is(err, ErrNoAddress) || attempt >= r.retries {
+			return value, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return value, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,55 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int
+		err       error
+		retries   int
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "success without retries", failures: 0, err: errUpstream, retries: 2, wantErr: nil, wantCalls: 1},
+		{name: "upstream error is retried", failures: 2, err: errUpstream, retries: 2, wantErr: nil, wantCalls: 3},
+		{name: "retries are exhausted", failures: -1, err: errUpstream, retries: 2, wantErr: errUpstream, wantCalls: 3},
+		{name: "no address is not retried", failures: -1, err: ErrNoAddress, retries: 2, wantErr: ErrNoAddress, wantCalls: 1},
+		{name: "zero retries", failures: -1, err: errUpstream, retries: 0, wantErr: errUpstream, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newFlaky(tt.failures, tt.err)
+			geocoder := NewRetry(provider, tt.retries, time.Millisecond)
+
+			_, err := geocoder.GetAddressByGeo(context.Background(), 30.3158, 59.9391)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if calls := provider.callCount(); calls != tt.wantCalls {
+				t.Errorf("provider calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	provider := newFlaky(-1, errUpstream)
+	geocoder := NewRetry(provider, 5, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := geocoder.Suggest(ctx, "Невский", 3); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if calls := provider.callCount(); calls != 1 {
+		t.Errorf("provider calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,153 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+var (
+	ErrTooLarge        = errors.New("image is too large")
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrDimensions      = errors.New("invalid image dimensions")
+)
+
+const (
+	minDimension = 16
+	jpegQuality  = 90
+)
+
+type Options struct {
+	MaxSize       int64
+	MaxDimension  int
+	ThumbnailSize int
+}
+
+type Processed struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Thumbnail   []byte
+}
+
+// Process проверяет загруженное изображение и перекодирует его.
+// Перекодирование отбрасывает все метаданные (EXIF, GPS и т.д.), поэтому
+// ориентация из EXIF применяется к пикселям заранее.
+func Process(data []byte, opts Options) (*Processed, error) {
+	if opts.MaxSize > 0 && int64(len(data)) > opts.MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, ErrUnsupportedType
+	}
+
+	// Проверяем размеры до полного декодирования, чтобы не распаковывать "бомбы"
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+
+	if cfg.Width < minDimension || cfg.Height < minDimension ||
+		(opts.MaxDimension > 0 && (cfg.Width > opts.MaxDimension || cfg.Height > opts.MaxDimension)) {
+		return nil, ErrDimensions
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, err)
+	}
+
+	processed := &Processed{ContentType: contentType}
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+
+		if err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		processed.Extension = "jpg"
+	} else {
+		if err = png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		processed.Extension = "png"
+	}
+
+	processed.Data = buf.Bytes()
+	processed.Width = img.Bounds().Dx()
+	processed.Height = img.Bounds().Dy()
+
+	thumbnail, err := Thumbnail(img, opts.ThumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+	processed.Thumbnail = thumbnail
+
+	return processed, nil
+}
+
+// Thumbnail уменьшает изображение так, чтобы большая сторона не превышала size, и кодирует его в JPEG.
+func Thumbnail(img image.Image, size int) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if size > 0 && (width > size || height > size) {
+		if width >= height {
+			height = max(1, height*size/width)
+			width = size
+		} else {
+			width = max(1, width*size/height)
+			height = size
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resize(img, width, height), &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// resize уменьшает изображение усреднением пикселей исходной области (box filter).
+func resize(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcH/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcH/height)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcW/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcW/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(b / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+
+	return dst
+}
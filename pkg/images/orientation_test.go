@@ -0,0 +1,149 @@
+package images
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// tiffHeader собирает заголовок TIFF с одним IFD из записей {тег, значение SHORT}.
+func tiffHeader(order binary.ByteOrder, tags ...[2]uint16) []byte {
+	data := make([]byte, 10, 10+len(tags)*12)
+	if order == binary.LittleEndian {
+		copy(data, "II")
+	} else {
+		copy(data, "MM")
+	}
+	order.PutUint16(data[2:4], 42)
+	order.PutUint32(data[4:8], 8)
+	order.PutUint16(data[8:10], uint16(len(tags)))
+
+	for _, tag := range tags {
+		entry := make([]byte, 12)
+		order.PutUint16(entry[0:2], tag[0])
+		order.PutUint16(entry[2:4], 3)
+		order.PutUint32(entry[4:8], 1)
+		order.PutUint16(entry[8:10], tag[1])
+		data = append(data, entry...)
+	}
+
+	return data
+}
+
+// jpegFile собирает начало JPEG из SOI и сегментов APP1 с EXIF, за которыми идет SOS.
+func jpegFile(segments ...[]byte) []byte {
+	data := []byte{0xFF, 0xD8}
+	for _, segment := range segments {
+		data = append(data, 0xFF, 0xE1)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(segment)+2))
+		data = append(data, segment...)
+	}
+
+	return append(data, 0xFF, 0xDA, 0x00, 0x02)
+}
+
+func exif(tiff []byte) []byte {
+	return append([]byte("Exif\x00\x00"), tiff...)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	rotated := jpegFile(exif(tiffHeader(binary.BigEndian, [2]uint16{0x010F, 1}, [2]uint16{exifOrientationTag, 6})))
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "empty", data: nil, want: 1},
+		{name: "not a jpeg", data: []byte("\x89PNG\r\n\x1a\n"), want: 1},
+		{name: "no exif", data: jpegFile(), want: 1},
+		{name: "big endian", data: rotated, want: 6},
+		{name: "little endian", data: jpegFile(exif(tiffHeader(binary.LittleEndian, [2]uint16{exifOrientationTag, 8}))), want: 8},
+		{name: "no orientation tag", data: jpegFile(exif(tiffHeader(binary.LittleEndian, [2]uint16{0x010F, 3}))), want: 1},
+		{name: "orientation out of range", data: jpegFile(exif(tiffHeader(binary.BigEndian, [2]uint16{exifOrientationTag, 9}))), want: 1},
+		{name: "zero orientation", data: jpegFile(exif(tiffHeader(binary.BigEndian, [2]uint16{exifOrientationTag, 0}))), want: 1},
+		{name: "app1 without exif header", data: jpegFile([]byte("http://ns.adobe.com/xap/1.0/\x00")), want: 1},
+		{name: "second app1 segment", data: jpegFile([]byte("XMP"), exif(tiffHeader(binary.BigEndian, [2]uint16{exifOrientationTag, 3}))), want: 3},
+		{name: "truncated segment", data: rotated[:len(rotated)-10], want: 1},
+		{name: "truncated marker", data: rotated[:5], want: 1},
+		{name: "segment length too short", data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01}, want: 1},
+		{name: "missing marker prefix", data: []byte{0xFF, 0xD8, 0x00, 0xE1, 0x00, 0x04, 0x00, 0x00}, want: 1},
+		{name: "unknown byte order", data: jpegFile(exif(append([]byte("XX"), tiffHeader(binary.BigEndian, [2]uint16{exifOrientationTag, 6})[2:]...))), want: 1},
+		{name: "short tiff header", data: jpegFile(exif([]byte("MM\x00\x2a"))), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != tt.want {
+				t.Errorf("jpegOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTIFFOrientation(t *testing.T) {
+	valid := tiffHeader(binary.BigEndian, [2]uint16{0x010F, 1}, [2]uint16{exifOrientationTag, 6})
+
+	outOfBounds := tiffHeader(binary.BigEndian, [2]uint16{exifOrientationTag, 6})
+	binary.BigEndian.PutUint32(outOfBounds[4:8], 0xFFFFFFFF)
+
+	tooManyEntries := tiffHeader(binary.BigEndian, [2]uint16{0x010F, 1})
+	binary.BigEndian.PutUint16(tooManyEntries[8:10], 0xFFFF)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "valid", data: valid, want: 6},
+		{name: "entry cut in the middle", data: valid[:len(valid)-4], want: 1},
+		{name: "entries count cut", data: valid[:9], want: 1},
+		{name: "ifd offset out of bounds", data: outOfBounds, want: 1},
+		{name: "entries count beyond data", data: tooManyEntries, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tiffOrientation(tt.data); got != tt.want {
+				t.Errorf("tiffOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// Изображение 2x1: красный пиксель слева, синий справа
+	red, blue := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, red)
+	src.Set(1, 0, blue)
+
+	tests := []struct {
+		orientation int
+		width       int
+		height      int
+		red         image.Point
+	}{
+		{orientation: 1, width: 2, height: 1, red: image.Pt(0, 0)},
+		{orientation: 2, width: 2, height: 1, red: image.Pt(1, 0)},
+		{orientation: 3, width: 2, height: 1, red: image.Pt(1, 0)},
+		{orientation: 4, width: 2, height: 1, red: image.Pt(0, 0)},
+		{orientation: 5, width: 1, height: 2, red: image.Pt(0, 0)},
+		{orientation: 6, width: 1, height: 2, red: image.Pt(0, 0)},
+		{orientation: 7, width: 1, height: 2, red: image.Pt(0, 1)},
+		{orientation: 8, width: 1, height: 2, red: image.Pt(0, 1)},
+		{orientation: 9, width: 2, height: 1, red: image.Pt(0, 0)},
+	}
+
+	for _, tt := range tests {
+		dst := applyOrientation(src, tt.orientation)
+		if bounds := dst.Bounds(); bounds.Dx() != tt.width || bounds.Dy() != tt.height {
+			t.Errorf("orientation %d: size = %dx%d, want %dx%d", tt.orientation, bounds.Dx(), bounds.Dy(), tt.width, tt.height)
+			continue
+		}
+		if got := color.RGBAModel.Convert(dst.At(tt.red.X, tt.red.Y)); got != red {
+			t.Errorf("orientation %d: pixel at %v = %v, want red", tt.orientation, tt.red, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package ip
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/netip"
+	"time"
+)
+
+const (
+	ipv4CachePrefix = 24
+	ipv6CachePrefix = 48
+
+	// negativeCacheTTL время жизни отрицательного ответа: адрес может появиться в базе после перезагрузки.
+	negativeCacheTTL = 10 * time.Minute
+)
+
+var cacheMetrics = expvar.NewMap("ip_cache")
+
+// Cached кэширует местоположение по префиксу адреса (/24 для IPv4 и /48 для IPv6): соседние адреса
+// почти всегда принадлежат одному провайдеру и городу. Неизвестные адреса тоже кэшируются, но на меньший срок.
+type Cached struct {
+	next   Locator
+	memory *lru
+	ttl    time.Duration
+}
+
+func NewCached(next Locator, size int, ttl time.Duration) *Cached {
+	return &Cached{
+		next:   next,
+		memory: newLRU(size),
+		ttl:    ttl,
+	}
+}
+
+func (c *Cached) Locate(ctx context.Context, addr netip.Addr) (*Location, error) {
+	addr = addr.Unmap()
+	if !publicAddr(addr) {
+		return nil, ErrNotFound
+	}
+
+	key := cacheKey(addr)
+	if location, ok := c.memory.get(key); ok {
+		cacheMetrics.Add("hit", 1)
+		if location == nil {
+			return nil, ErrNotFound
+		}
+
+		copied := *location
+		return &copied, nil
+	}
+	cacheMetrics.Add("miss", 1)
+
+	location, err := c.next.Locate(ctx, addr)
+	switch {
+	case err == nil:
+		copied := *location
+		c.memory.add(key, &copied, c.ttl)
+	case errors.Is(err, ErrNotFound):
+		c.memory.add(key, nil, min(c.ttl, negativeCacheTTL))
+	}
+
+	return location, err
+}
+
+func cacheKey(addr netip.Addr) string {
+	bits := ipv6CachePrefix
+	if addr.Is4() {
+		bits = ipv4CachePrefix
+	}
+
+	prefix, _ := addr.Prefix(bits)
+	return prefix.String()
+}
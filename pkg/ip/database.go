@@ -0,0 +1,234 @@
+package ip
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var databaseMetrics = expvar.NewMap("ip_database")
+
+type ipRange struct {
+	first    netip.Addr
+	last     netip.Addr
+	location Location
+}
+
+// Database локальная база диапазонов IP-адресов в CSV. Первая строка - заголовок, диапазон задается
+// колонкой network (CIDR, как в GeoLite2 City CSV) или парой start_ip и end_ip. Колонки latitude и longitude
+// обязательны, country, region и city - нет. Порядок колонок не важен.
+type Database struct {
+	path   string
+	ranges atomic.Pointer[[]ipRange]
+
+	mu      sync.Mutex
+	modTime time.Time
+}
+
+func NewDatabase(path string) (*Database, error) {
+	d := &Database{path: path}
+	if err := d.Reload(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *Database) Locate(_ context.Context, addr netip.Addr) (*Location, error) {
+	addr = addr.Unmap()
+	ranges := *d.ranges.Load()
+
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].first.Compare(addr) > 0
+	})
+	if i == 0 || ranges[i-1].last.Compare(addr) < 0 {
+		return nil, ErrNotFound
+	}
+
+	location := ranges[i-1].location
+	return &location, nil
+}
+
+// Reload перечитывает файл и атомарно подменяет диапазоны. При ошибке продолжают работать прежние диапазоны.
+func (d *Database) Reload() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	info, err := os.Stat(d.path)
+	if err != nil {
+		return err
+	}
+
+	ranges, err := loadRanges(d.path)
+	if err != nil {
+		databaseMetrics.Add("reload_errors", 1)
+		return err
+	}
+
+	d.ranges.Store(&ranges)
+	d.modTime = info.ModTime()
+
+	databaseMetrics.Add("reloads", 1)
+	size := new(expvar.Int)
+	size.Set(int64(len(ranges)))
+	databaseMetrics.Set("ranges", size)
+
+	return nil
+}
+
+// Watch раз в interval проверяет время изменения файла и перезагружает базу, пока не отменен ctx.
+// Файл лучше заменять атомарно (запись во временный файл и rename), иначе можно прочитать его наполовину.
+func (d *Database) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if d.changed() {
+				_ = d.Reload()
+			}
+		}
+	}
+}
+
+func (d *Database) changed() bool {
+	info, err := os.Stat(d.path)
+	if err != nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return !info.ModTime().Equal(d.modTime)
+}
+
+func loadRanges(path string) ([]ipRange, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(bufio.NewReader(file))
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%s: read header: %w", path, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["latitude"]; !ok {
+		return nil, fmt.Errorf("%s: no latitude column", path)
+	}
+	if _, ok := columns["longitude"]; !ok {
+		return nil, fmt.Errorf("%s: no longitude column", path)
+	}
+
+	_, byNetwork := columns["network"]
+	if _, ok := columns["start_ip"]; !byNetwork && !ok {
+		return nil, fmt.Errorf("%s: no network or start_ip column", path)
+	}
+
+	ranges := make([]ipRange, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		column := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		// Диапазоны без координат (например, известна только страна) пропускаются
+		if column("latitude") == "" || column("longitude") == "" {
+			continue
+		}
+
+		var r ipRange
+		if byNetwork {
+			prefix, err := netip.ParsePrefix(column("network"))
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+
+			prefix = prefix.Masked()
+			r.first, r.last = prefix.Addr().Unmap(), lastAddr(prefix).Unmap()
+		} else {
+			if r.first, err = netip.ParseAddr(column("start_ip")); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			if r.last, err = netip.ParseAddr(column("end_ip")); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+
+			r.first, r.last = r.first.Unmap(), r.last.Unmap()
+			if r.first.BitLen() != r.last.BitLen() || r.first.Compare(r.last) > 0 {
+				return nil, fmt.Errorf("%s:%d: invalid range %s-%s", path, line, r.first, r.last)
+			}
+		}
+
+		if r.location.Lat, err = strconv.ParseFloat(column("latitude"), 64); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if r.location.Lng, err = strconv.ParseFloat(column("longitude"), 64); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		r.location.Country = column("country")
+		r.location.Region = column("region")
+		r.location.City = column("city")
+
+		ranges = append(ranges, r)
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].first.Less(ranges[j].first)
+	})
+
+	// Поиск выбирает ближайший диапазон слева, поэтому пересечения дали бы неверный ответ
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i-1].first.BitLen() == ranges[i].first.BitLen() && ranges[i].first.Compare(ranges[i-1].last) <= 0 {
+			return nil, fmt.Errorf("%s: overlapping ranges %s and %s", path, ranges[i-1].first, ranges[i].first)
+		}
+	}
+
+	return ranges, nil
+}
+
+// lastAddr возвращает последний адрес префикса, выставляя все биты хоста в единицу.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 1 << (7 - bit%8)
+	}
+
+	last, _ := netip.AddrFromSlice(bytes)
+	return last
+}
@@ -1,42 +1,69 @@
 package ip
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/go-resty/resty/v2"
+	"net/netip"
+
+	"github.com/ShpullRequest/backend/internal/config"
 )
 
-type ipResponse struct {
-	Status      string  `json:"status"`
-	Country     string  `json:"country"`
-	CountryCode string  `json:"countryCode"`
-	Region      string  `json:"region"`
-	RegionName  string  `json:"regionName"`
-	City        string  `json:"city"`
-	Zip         string  `json:"zip"`
-	Lat         float64 `json:"lat"`
-	Lon         float64 `json:"lon"`
-	Timezone    string  `json:"timezone"`
-	Isp         string  `json:"isp"`
-	Org         string  `json:"org"`
-	As          string  `json:"as"`
-	Query       string  `json:"query"`
+// ErrNotFound означает, что местоположение адреса неизвестно. Chain в этом случае спрашивает следующий источник.
+var ErrNotFound = errors.New("ip location not found")
+
+// Location приблизительное местоположение IP-адреса.
+type Location struct {
+	Lat     float64 `json:"lat"`
+	Lng     float64 `json:"lng"`
+	Country string  `json:"country"`
+	Region  string  `json:"region"`
+	City    string  `json:"city"`
+}
+
+type Locator interface {
+	Locate(ctx context.Context, addr netip.Addr) (*Location, error)
+}
+
+// Chain опрашивает источники по порядку, пока один из них не найдет адрес.
+type Chain []Locator
+
+func (c Chain) Locate(ctx context.Context, addr netip.Addr) (*Location, error) {
+	for _, locator := range c {
+		location, err := locator.Locate(ctx, addr)
+		if !errors.Is(err, ErrNotFound) {
+			return location, err
+		}
+	}
+
+	return nil, ErrNotFound
 }
 
-func GetGeoByIP(ip string) (string, error) {
-	url := fmt.Sprintf("http://ip-api.com/json/%s", ip)
+// New собирает геолокацию из конфигурации: локальная база, затем (если включен) удаленный провайдер,
+// и кэш по префиксам поверх них. Без базы и провайдера адреса не определяются.
+func New(cfg config.NodeConfig) (Locator, error) {
+	var chain Chain
 
-	response, err := resty.New().R().
-		SetResult(&ipResponse{}).
-		Get(url)
-	if err != nil {
-		return "", err
+	if cfg.IPDatabasePath != "" {
+		database, err := NewDatabase(cfg.IPDatabasePath)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.IPDatabaseReload > 0 {
+			go database.Watch(context.Background(), cfg.IPDatabaseReload)
+		}
+
+		chain = append(chain, database)
 	}
 
-	result := response.Result().(*ipResponse)
-	if result.Lat == 0 && result.Lon == 0 {
-		return "", errors.New("invalid geo")
+	if cfg.IPRemoteFallback {
+		chain = append(chain, NewRemote(cfg.IPRemoteURL, cfg.IPRemoteTimeout))
 	}
 
-	return fmt.Sprintf("%f, %f", result.Lat, result.Lon), nil
+	return NewCached(chain, cfg.IPCacheSize, cfg.IPCacheTTL), nil
+}
+
+// publicAddr сообщает, имеет ли смысл искать местоположение адреса.
+// Локальные и приватные адреса не ищутся и не уходят внешнему провайдеру.
+func publicAddr(addr netip.Addr) bool {
+	return addr.IsValid() && addr.IsGlobalUnicast() && !addr.IsPrivate()
 }
@@ -0,0 +1,76 @@
+package ip
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lru потокобезопасный LRU-кэш местоположений. nil в записи означает, что адрес не найден.
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	items map[string]*list.Element
+	order *list.List
+}
+
+type lruEntry struct {
+	key       string
+	location  *Location
+	expiresAt time.Time
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:  size,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (c *lru) get(key string) (*Location, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.items, key)
+
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.location, true
+}
+
+func (c *lru) add(key string, location *Location, ttl time.Duration) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.location = location
+		entry.expiresAt = time.Now().Add(ttl)
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, location: location, expiresAt: time.Now().Add(ttl)})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
@@ -0,0 +1,65 @@
+package ip
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type ipResponse struct {
+	Status     string  `json:"status"`
+	Country    string  `json:"country"`
+	RegionName string  `json:"regionName"`
+	City       string  `json:"city"`
+	Lat        float64 `json:"lat"`
+	Lon        float64 `json:"lon"`
+}
+
+// Remote определяет местоположение через ip-api.com. Адрес пользователя при этом уходит третьей стороне,
+// поэтому провайдер используется только как запасной и только если явно включен в конфигурации.
+type Remote struct {
+	client *resty.Client
+}
+
+func NewRemote(baseURL string, timeout time.Duration) *Remote {
+	return &Remote{
+		client: resty.New().
+			SetBaseURL(baseURL).
+			SetTimeout(timeout),
+	}
+}
+
+func (r *Remote) Locate(ctx context.Context, addr netip.Addr) (*Location, error) {
+	if !publicAddr(addr.Unmap()) {
+		return nil, ErrNotFound
+	}
+
+	response, err := r.client.R().
+		SetContext(ctx).
+		SetQueryParam("fields", "status,country,regionName,city,lat,lon").
+		SetResult(&ipResponse{}).
+		Get(addr.Unmap().String())
+	if err != nil {
+		return nil, err
+	}
+
+	if response.IsError() {
+		return nil, fmt.Errorf("ip-api: %s", response.Status())
+	}
+
+	result := response.Result().(*ipResponse)
+	if result.Status != "success" || (result.Lat == 0 && result.Lon == 0) {
+		return nil, ErrNotFound
+	}
+
+	return &Location{
+		Lat:     result.Lat,
+		Lng:     result.Lon,
+		Country: result.Country,
+		Region:  result.RegionName,
+		City:    result.City,
+	}, nil
+}
@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Ответы компаний на отзывы
+    CREATE TABLE IF NOT EXISTS reviews_replies (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        review_id UUID NOT NULL,
+        review_type VARCHAR(16) NOT NULL,
+        company_id UUID NOT NULL,
+        owner_id UUID NOT NULL,
+        reply_text TEXT NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL,
+        updated_at TIMESTAMPTZ NOT NULL
+    );
+    CREATE INDEX idx_reviews_replies_company ON reviews_replies (company_id);
+    ALTER TABLE reviews_replies ADD CONSTRAINT unique_reviews_replies_review_id UNIQUE (review_id);
+
+-- Отметки "полезно" к отзывам
+    CREATE TABLE IF NOT EXISTS reviews_votes (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        review_id UUID NOT NULL,
+        review_type VARCHAR(16) NOT NULL,
+        user_id UUID NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL
+    );
+    CREATE INDEX idx_reviews_votes_review ON reviews_votes (review_id);
+    ALTER TABLE reviews_votes ADD CONSTRAINT unique_reviews_votes_review_id_user_id UNIQUE (review_id, user_id);
+
+-- +goose Down
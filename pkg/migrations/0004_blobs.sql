@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- Загруженные файлы (изображения)
+    CREATE TABLE IF NOT EXISTS blobs (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        owner_id UUID NOT NULL,
+        key TEXT NOT NULL,
+        url TEXT NOT NULL,
+        thumbnail_key TEXT NOT NULL,
+        thumbnail_url TEXT NOT NULL,
+        content_type VARCHAR(32) NOT NULL,
+        size BIGINT NOT NULL,
+        width INT NOT NULL,
+        height INT NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL
+    );
+    CREATE INDEX idx_blobs_owner ON blobs (owner_id);
+
+-- Фотографии к отзывам
+    CREATE TABLE IF NOT EXISTS reviews_photos (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        review_id UUID NOT NULL,
+        review_type VARCHAR(16) NOT NULL,
+        blob_id UUID NOT NULL,
+        position INT NOT NULL
+    );
+    CREATE INDEX idx_reviews_photos_review ON reviews_photos (review_id);
+    ALTER TABLE reviews_photos ADD CONSTRAINT unique_reviews_photos_review_id_blob_id UNIQUE (review_id, blob_id);
+
+-- +goose Down
@@ -0,0 +1,83 @@
+-- +goose Up
+
+-- Единая таблица отзывов вместо reviews_places, reviews_events и reviews_routes
+    CREATE TABLE IF NOT EXISTS reviews (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        entity_type VARCHAR(16) NOT NULL,
+        entity_id UUID NOT NULL,
+        owner_id UUID NOT NULL,
+        review_text TEXT NOT NULL,
+        stars DOUBLE PRECISION NOT NULL DEFAULT 5.0,
+        created_at TIMESTAMPTZ NOT NULL,
+        is_deleted BOOL NOT NULL
+    );
+    CREATE INDEX idx_reviews_entity ON reviews (entity_type, entity_id);
+    CREATE INDEX idx_reviews_owner ON reviews (owner_id);
+    ALTER TABLE reviews ADD CONSTRAINT unique_reviews_entity_owner UNIQUE (entity_type, entity_id, owner_id);
+
+    INSERT INTO reviews (id, entity_type, entity_id, owner_id, review_text, stars, created_at, is_deleted)
+        SELECT id, 'place', place_id, owner_id, review_text, stars, created_at, is_deleted FROM reviews_places
+        UNION ALL
+        SELECT id, 'event', event_id, owner_id, review_text, stars, created_at, is_deleted FROM reviews_events
+        UNION ALL
+        SELECT id, 'route', route_id, owner_id, review_text, stars, created_at, is_deleted FROM reviews_routes;
+
+    DROP TABLE reviews_places;
+    DROP TABLE reviews_events;
+    DROP TABLE reviews_routes;
+
+-- Тип отзыва теперь хранится в самой таблице reviews
+    ALTER TABLE reviews_replies DROP COLUMN review_type;
+    ALTER TABLE reviews_votes DROP COLUMN review_type;
+    ALTER TABLE reviews_photos DROP COLUMN review_type;
+
+-- Кэшированная сводка оценок по каждой сущности
+    ALTER TABLE places ADD COLUMN rating_avg DOUBLE PRECISION NOT NULL DEFAULT 0;
+    ALTER TABLE places ADD COLUMN rating_count INT NOT NULL DEFAULT 0;
+    ALTER TABLE places ADD COLUMN rating_histogram INT[] NOT NULL DEFAULT '{0,0,0,0,0}';
+
+    ALTER TABLE events ADD COLUMN rating_avg DOUBLE PRECISION NOT NULL DEFAULT 0;
+    ALTER TABLE events ADD COLUMN rating_count INT NOT NULL DEFAULT 0;
+    ALTER TABLE events ADD COLUMN rating_histogram INT[] NOT NULL DEFAULT '{0,0,0,0,0}';
+
+    ALTER TABLE routes ADD COLUMN rating_avg DOUBLE PRECISION NOT NULL DEFAULT 0;
+    ALTER TABLE routes ADD COLUMN rating_count INT NOT NULL DEFAULT 0;
+    ALTER TABLE routes ADD COLUMN rating_histogram INT[] NOT NULL DEFAULT '{0,0,0,0,0}';
+
+    CREATE VIEW reviews_aggregates AS
+        SELECT entity_type, entity_id,
+            COUNT(*)::int AS rating_count,
+            COALESCE(AVG(stars), 0) AS rating_avg,
+            ARRAY[
+                COUNT(*) FILTER (WHERE stars = 1)::int,
+                COUNT(*) FILTER (WHERE stars = 2)::int,
+                COUNT(*) FILTER (WHERE stars = 3)::int,
+                COUNT(*) FILTER (WHERE stars = 4)::int,
+                COUNT(*) FILTER (WHERE stars = 5)::int
+            ] AS rating_histogram
+        FROM reviews
+        WHERE is_deleted = false
+        GROUP BY entity_type, entity_id;
+
+    UPDATE places SET rating_avg = a.rating_avg, rating_count = a.rating_count, rating_histogram = a.rating_histogram
+        FROM reviews_aggregates a WHERE a.entity_type = 'place' AND a.entity_id = places.id;
+    UPDATE events SET rating_avg = a.rating_avg, rating_count = a.rating_count, rating_histogram = a.rating_histogram
+        FROM reviews_aggregates a WHERE a.entity_type = 'event' AND a.entity_id = events.id;
+    UPDATE routes SET rating_avg = a.rating_avg, rating_count = a.rating_count, rating_histogram = a.rating_histogram
+        FROM reviews_aggregates a WHERE a.entity_type = 'route' AND a.entity_id = routes.id;
+
+-- Рейтинг компании считается по кэшированным сводкам ее событий и маршрутов
+-- +goose StatementBegin
+    CREATE OR REPLACE FUNCTION calculate_company_rating(c_id UUID)
+        RETURNS DOUBLE PRECISION AS $$
+    BEGIN
+        RETURN COALESCE(SUM(rating_avg * rating_count) / NULLIF(SUM(rating_count), 0), 0) FROM (
+            SELECT rating_avg, rating_count FROM routes WHERE company_id = c_id
+            UNION ALL
+            SELECT rating_avg, rating_count FROM events WHERE company_id = c_id
+        ) AS rating;
+    END;
+    $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- Удаленные события и маршруты не учитываются в рейтинге компании
+-- +goose StatementBegin
+    CREATE OR REPLACE FUNCTION calculate_company_rating(c_id UUID)
+        RETURNS DOUBLE PRECISION AS $$
+    BEGIN
+        RETURN COALESCE(SUM(rating_avg * rating_count) / NULLIF(SUM(rating_count), 0), 0) FROM (
+            SELECT rating_avg, rating_count FROM routes WHERE company_id = c_id AND is_deleted = false
+            UNION ALL
+            SELECT rating_avg, rating_count FROM events WHERE company_id = c_id AND is_deleted = false
+        ) AS rating;
+    END;
+    $$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
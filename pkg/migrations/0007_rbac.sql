@@ -0,0 +1,36 @@
+-- +goose Up
+
+-- Роли пользователей вместо флага is_admin. Роль "user" есть у всех и не хранится
+    CREATE TABLE IF NOT EXISTS users_roles (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        user_id UUID NOT NULL REFERENCES users(id),
+        role VARCHAR(32) NOT NULL,
+        granted_by UUID REFERENCES users(id),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    ALTER TABLE users_roles ADD CONSTRAINT unique_users_roles UNIQUE (user_id, role);
+
+-- Журнал выдачи и отзыва ролей
+    CREATE TABLE IF NOT EXISTS roles_audit (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        actor_id UUID REFERENCES users(id),
+        user_id UUID NOT NULL REFERENCES users(id),
+        role VARCHAR(32) NOT NULL,
+        action VARCHAR(16) NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    CREATE INDEX idx_roles_audit_user ON roles_audit (user_id);
+
+    INSERT INTO users_roles (user_id, role)
+        SELECT id, 'admin' FROM users WHERE is_admin = true;
+
+    INSERT INTO users_roles (user_id, role)
+        SELECT DISTINCT user_id, 'company_member' FROM companies WHERE is_released = true
+        ON CONFLICT DO NOTHING;
+
+    INSERT INTO roles_audit (user_id, role, action)
+        SELECT user_id, role, 'grant' FROM users_roles;
+
+    ALTER TABLE users DROP COLUMN is_admin;
+
+-- +goose Down
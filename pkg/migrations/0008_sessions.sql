@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- Сессии веб-клиентов. Токен обновления действителен, пока его jti совпадает с refresh_jti
+    CREATE TABLE IF NOT EXISTS sessions (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        user_id UUID NOT NULL REFERENCES users(id),
+        refresh_jti UUID NOT NULL,
+        user_agent TEXT NOT NULL DEFAULT '',
+        ip VARCHAR(64) NOT NULL DEFAULT '',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        refreshed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        expires_at TIMESTAMPTZ NOT NULL,
+        revoked_at TIMESTAMPTZ
+    );
+    CREATE INDEX idx_sessions_user ON sessions (user_id);
+
+-- +goose Down
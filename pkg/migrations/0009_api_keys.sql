@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Ключи партнерского API. Хранится только SHA-256 ключа, prefix служит для поиска и отображения
+    CREATE TABLE IF NOT EXISTS api_keys (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        user_id UUID NOT NULL REFERENCES users(id),
+        created_by UUID REFERENCES users(id),
+        name VARCHAR(128) NOT NULL,
+        prefix VARCHAR(16) NOT NULL UNIQUE,
+        key_hash VARCHAR(64) NOT NULL,
+        scopes TEXT[] NOT NULL DEFAULT '{}',
+        company_id UUID REFERENCES companies(id),
+        daily_quota INT NOT NULL DEFAULT 0,
+        requests_count BIGINT NOT NULL DEFAULT 0,
+        last_used_at TIMESTAMPTZ,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        revoked_at TIMESTAMPTZ
+    );
+
+-- Счетчики запросов по дням для квот
+    CREATE TABLE IF NOT EXISTS api_keys_usage (
+        key_id UUID NOT NULL REFERENCES api_keys(id),
+        day DATE NOT NULL,
+        requests INT NOT NULL DEFAULT 0,
+        PRIMARY KEY (key_id, day)
+    );
+
+-- +goose Down
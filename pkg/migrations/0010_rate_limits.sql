@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Корзины ограничителя частоты запросов, общие для всех реплик. Данные не нужно сохранять после сбоя
+    CREATE UNLOGGED TABLE IF NOT EXISTS rate_limits (
+        key VARCHAR(255) PRIMARY KEY,
+        tokens DOUBLE PRECISION NOT NULL,
+        updated_at TIMESTAMPTZ NOT NULL
+    );
+
+-- +goose Down
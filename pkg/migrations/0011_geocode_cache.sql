@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Постоянный кэш геокодера. Ключ - округленные координаты или нормализованный текстовый запрос
+    CREATE TABLE IF NOT EXISTS geocode_cache (
+        key VARCHAR(512) PRIMARY KEY,
+        address TEXT NOT NULL,
+        updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+
+-- +goose Down
@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- Структурированный адрес мест и событий в дополнение к строке address_text
+    ALTER TABLE places
+        ADD COLUMN address_country VARCHAR(255) NOT NULL DEFAULT '',
+        ADD COLUMN address_region VARCHAR(255) NOT NULL DEFAULT '',
+        ADD COLUMN address_locality VARCHAR(255) NOT NULL DEFAULT '',
+        ADD COLUMN address_street VARCHAR(255) NOT NULL DEFAULT '',
+        ADD COLUMN address_building VARCHAR(64) NOT NULL DEFAULT '',
+        ADD COLUMN address_postal_code VARCHAR(32) NOT NULL DEFAULT '';
+
+    ALTER TABLE events
+        ADD COLUMN address_country VARCHAR(255) NOT NULL DEFAULT '',
+        ADD COLUMN address_region VARCHAR(255) NOT NULL DEFAULT '',
+        ADD COLUMN address_locality VARCHAR(255) NOT NULL DEFAULT '',
+        ADD COLUMN address_street VARCHAR(255) NOT NULL DEFAULT '',
+        ADD COLUMN address_building VARCHAR(64) NOT NULL DEFAULT '',
+        ADD COLUMN address_postal_code VARCHAR(32) NOT NULL DEFAULT '';
+
+-- +goose Down
@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Справочник населенных пунктов и регионов, которые пользователи выбирают на карте
+    CREATE TABLE IF NOT EXISTS localities (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        kind VARCHAR(16) NOT NULL,
+        name VARCHAR(255) NOT NULL,
+        region VARCHAR(255) NOT NULL DEFAULT '',
+        country VARCHAR(255) NOT NULL DEFAULT '',
+        center POINT NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    CREATE UNIQUE INDEX idx_unique_localities ON localities (kind, country, region, name);
+
+-- Координаты вместо строки "lat, lng". Строки, которые не разбираются или выходят за допустимые границы, сбрасываются
+    ALTER TABLE users ALTER COLUMN selected_geo TYPE POINT USING (
+        CASE WHEN selected_geo ~ '^\s*-?\d+(\.\d+)?\s*,\s*-?\d+(\.\d+)?\s*$' THEN
+            CASE WHEN abs(split_part(selected_geo, ',', 1)::float8) <= 90 AND abs(split_part(selected_geo, ',', 2)::float8) <= 180 THEN
+                point(split_part(selected_geo, ',', 2)::float8, split_part(selected_geo, ',', 1)::float8)
+            END
+        END
+    );
+
+    ALTER TABLE users
+        ADD COLUMN selected_locality_id UUID REFERENCES localities(id),
+        ADD COLUMN home_city_id UUID REFERENCES localities(id);
+
+-- +goose Down
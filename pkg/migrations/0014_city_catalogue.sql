@@ -0,0 +1,42 @@
+-- +goose Up
+
+-- Границы городов и регионов: многоугольник или круг с центром center и радиусом radius_km.
+-- Запись без границ (radius_km = 0 и boundary IS NULL) не участвует в привязке контента
+    ALTER TABLE localities
+        ADD COLUMN parent_id UUID REFERENCES localities(id),
+        ADD COLUMN radius_km DOUBLE PRECISION NOT NULL DEFAULT 0,
+        ADD COLUMN boundary POLYGON;
+    CREATE INDEX idx_localities_parent_id ON localities (parent_id);
+
+    ALTER TABLE places ADD COLUMN city_id UUID REFERENCES localities(id);
+    CREATE INDEX idx_places_city_id ON places (city_id);
+
+    ALTER TABLE events ADD COLUMN city_id UUID REFERENCES localities(id);
+    CREATE INDEX idx_events_city_id ON events (city_id);
+
+-- Расстояние в километрах между двумя точками по формуле гаверсинусов
+-- +goose StatementBegin
+    CREATE OR REPLACE FUNCTION distance_km(lat1 DOUBLE PRECISION, lng1 DOUBLE PRECISION, lat2 DOUBLE PRECISION, lng2 DOUBLE PRECISION)
+        RETURNS DOUBLE PRECISION AS $$
+    SELECT 6371 * 2 * asin(sqrt(
+        power(sin(radians(lat2 - lat1) / 2), 2) +
+        cos(radians(lat1)) * cos(radians(lat2)) * power(sin(radians(lng2 - lng1) / 2), 2)
+    ));
+    $$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- Город, в который попадает точка, а если такого нет - регион. Из нескольких подходящих выбирается ближайший по центру
+-- +goose StatementBegin
+    CREATE OR REPLACE FUNCTION locality_at(lat DOUBLE PRECISION, lng DOUBLE PRECISION)
+        RETURNS UUID AS $$
+    SELECT id FROM localities
+        WHERE CASE
+            WHEN boundary IS NOT NULL THEN boundary @> point(lng, lat)
+            ELSE radius_km > 0 AND distance_km(lat, lng, center[1], center[0]) <= radius_km
+        END
+        ORDER BY kind = 'region', center <-> point(lng, lat)
+        LIMIT 1;
+    $$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- Закладки и отметки о посещении мест, событий и маршрутов. Используются в рекомендациях
+    CREATE TABLE IF NOT EXISTS bookmarks (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        user_id UUID NOT NULL REFERENCES users(id),
+        entity_type VARCHAR(16) NOT NULL,
+        entity_id UUID NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    ALTER TABLE bookmarks ADD CONSTRAINT unique_bookmarks_user_entity UNIQUE (user_id, entity_type, entity_id);
+
+    CREATE TABLE IF NOT EXISTS checkins (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        user_id UUID NOT NULL REFERENCES users(id),
+        entity_type VARCHAR(16) NOT NULL,
+        entity_id UUID NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    CREATE INDEX idx_checkins_user_id ON checkins (user_id, created_at);
+
+-- +goose Down
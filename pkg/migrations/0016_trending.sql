@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- Просмотры мест, событий и маршрутов по дням
+    CREATE TABLE IF NOT EXISTS entity_views (
+        entity_type VARCHAR(16) NOT NULL,
+        entity_id UUID NOT NULL,
+        day DATE NOT NULL,
+        views INT NOT NULL DEFAULT 0,
+        PRIMARY KEY (entity_type, entity_id, day)
+    );
+
+-- Отметки "пойду" на события
+    CREATE TABLE IF NOT EXISTS event_rsvps (
+        user_id UUID NOT NULL REFERENCES users(id),
+        event_id UUID NOT NULL REFERENCES events(id),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (user_id, event_id)
+    );
+    CREATE INDEX idx_event_rsvps_event_id ON event_rsvps (event_id);
+
+-- Популярность с затуханием по времени, периодически пересчитывается приложением
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS trending_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+    ALTER TABLE events ADD COLUMN IF NOT EXISTS trending_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+    ALTER TABLE routes ADD COLUMN IF NOT EXISTS trending_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+    CREATE INDEX idx_places_trending_score ON places (trending_score DESC) WHERE is_deleted = false;
+    CREATE INDEX idx_events_trending_score ON events (trending_score DESC) WHERE is_deleted = false;
+    CREATE INDEX idx_routes_trending_score ON routes (trending_score DESC) WHERE is_deleted = false;
+
+-- +goose Down
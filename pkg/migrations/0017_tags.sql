@@ -0,0 +1,62 @@
+-- +goose Up
+
+-- Справочник тегов мест и событий. labels - названия по языкам: {"ru": "Музей", "en": "Museum"}
+    CREATE TABLE IF NOT EXISTS tags (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        parent_id UUID REFERENCES tags(id),
+        slug VARCHAR(64) NOT NULL UNIQUE,
+        labels JSONB NOT NULL DEFAULT '{}',
+        icon TEXT NOT NULL DEFAULT '',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    CREATE INDEX idx_tags_parent_id ON tags (parent_id);
+
+-- Прежние slug переименованных и объединенных тегов, чтобы старые ссылки и фильтры продолжали работать
+    CREATE TABLE IF NOT EXISTS tag_aliases (
+        slug VARCHAR(64) PRIMARY KEY,
+        tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE
+    );
+
+-- Slug из произвольной строки: нижний регистр, транслитерация кириллицы, дефисы вместо остальных символов
+-- +goose StatementBegin
+    CREATE OR REPLACE FUNCTION slugify(value TEXT)
+        RETURNS TEXT AS $$
+    SELECT LEFT(TRIM(BOTH '-' FROM REGEXP_REPLACE(
+        TRANSLATE(
+            REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(
+                LOWER(TRIM(value)),
+                'щ', 'shch'), 'ж', 'zh'), 'х', 'kh'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'), 'ю', 'yu'), 'я', 'ya'),
+            'абвгдеёзийклмнопрстуфыэъь',
+            'abvgdeeziyklmnoprstufye'
+        ),
+        '[^a-z0-9]+', '-', 'g'
+    )), 64);
+    $$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- Теги из свободного текста событий: "Музей", "музей " и "МУЗЕЙ" становятся одним тегом.
+-- Синонимы на разных языках ("музей" и "museum") объединяются администратором через слияние тегов
+    INSERT INTO tags (slug, labels)
+    SELECT slug, jsonb_build_object(CASE WHEN MIN(label) ~ '[а-яё]' THEN 'ru' ELSE 'en' END, MIN(label))
+    FROM (
+        SELECT slugify(tag) AS slug, LOWER(TRIM(tag)) AS label FROM events, UNNEST(events.tags) AS tag
+    ) AS legacy
+    WHERE slug <> ''
+    GROUP BY slug
+    ON CONFLICT (slug) DO NOTHING;
+
+    ALTER TABLE events ADD COLUMN tag_ids UUID[] NOT NULL DEFAULT '{}';
+    ALTER TABLE places ADD COLUMN tag_ids UUID[] NOT NULL DEFAULT '{}';
+
+    UPDATE events SET tag_ids = ARRAY(
+        SELECT DISTINCT tags.id FROM UNNEST(events.tags) AS tag JOIN tags ON tags.slug = slugify(tag)
+    )
+    WHERE events.tags IS NOT NULL;
+
+    ALTER TABLE events DROP COLUMN tags;
+
+-- Индексы для фильтра списков по тегу (tag_ids && ARRAY[...])
+    CREATE INDEX idx_events_tag_ids ON events USING GIN (tag_ids);
+    CREATE INDEX idx_places_tag_ids ON places USING GIN (tag_ids);
+
+-- +goose Down
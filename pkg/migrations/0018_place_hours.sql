@@ -0,0 +1,16 @@
+-- +goose Up
+
+-- Часы работы мест: opening_hours - расписание по дням недели ({"mon": [{"open": "10:00", "close": "18:00"}], ...}),
+-- NULL - часы неизвестны; hours_exceptions - исключения на даты ([{"date": "2024-01-01", "closed": true}]).
+-- Время указано в часовом поясе места
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'Europe/Moscow';
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS opening_hours JSONB;
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS hours_exceptions JSONB NOT NULL DEFAULT '[]';
+
+-- Диапазон цен в рублях, доступность и удобства
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS price_min INT CHECK (price_min >= 0);
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS price_max INT CHECK (price_max >= price_min);
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS accessibility TEXT[] NOT NULL DEFAULT '{}';
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS amenities TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
@@ -0,0 +1,41 @@
+-- +goose Up
+
+-- Компания-владелец места. NULL - у места нет владельца, его правки проходят через предложенные изменения
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS company_id UUID REFERENCES companies(id);
+    CREATE INDEX idx_places_company_id ON places (company_id);
+
+-- Заявки компаний на владение местом, рассматриваются модераторами
+    CREATE TABLE IF NOT EXISTS place_claims (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        place_id UUID NOT NULL REFERENCES places(id),
+        company_id UUID NOT NULL REFERENCES companies(id),
+        user_id UUID NOT NULL REFERENCES users(id),
+        evidence TEXT NOT NULL,
+        attachments TEXT[] NOT NULL DEFAULT '{}',
+        status VARCHAR(16) NOT NULL DEFAULT 'pending',
+        reviewer_id UUID REFERENCES users(id),
+        review_comment TEXT NOT NULL DEFAULT '',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        reviewed_at TIMESTAMPTZ
+    );
+    CREATE UNIQUE INDEX idx_place_claims_pending ON place_claims (place_id, company_id) WHERE status = 'pending';
+    CREATE INDEX idx_place_claims_status ON place_claims (status, created_at);
+
+-- Предложенные пользователями изменения. changes - поля запроса редактирования
+    CREATE TABLE IF NOT EXISTS edit_suggestions (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        entity_type VARCHAR(16) NOT NULL,
+        entity_id UUID NOT NULL,
+        user_id UUID NOT NULL REFERENCES users(id),
+        changes JSONB NOT NULL,
+        comment TEXT NOT NULL DEFAULT '',
+        status VARCHAR(16) NOT NULL DEFAULT 'pending',
+        reviewer_id UUID REFERENCES users(id),
+        review_comment TEXT NOT NULL DEFAULT '',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        reviewed_at TIMESTAMPTZ
+    );
+    CREATE INDEX idx_edit_suggestions_entity ON edit_suggestions (entity_type, entity_id);
+    CREATE INDEX idx_edit_suggestions_status ON edit_suggestions (status, created_at);
+
+-- +goose Down
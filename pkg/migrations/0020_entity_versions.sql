@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- История изменений мест, событий, маршрутов и компаний. snapshot - редактируемые поля сущности после изменения,
+-- changed_fields - поля, отличающиеся от предыдущей версии
+    CREATE TABLE IF NOT EXISTS entity_versions (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        entity_type VARCHAR(16) NOT NULL,
+        entity_id UUID NOT NULL,
+        version INTEGER NOT NULL,
+        action VARCHAR(16) NOT NULL,
+        user_id UUID REFERENCES users(id),
+        suggestion_id UUID REFERENCES edit_suggestions(id),
+        snapshot JSONB NOT NULL,
+        changed_fields TEXT[] NOT NULL DEFAULT '{}',
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        UNIQUE (entity_type, entity_id, version)
+    );
+
+-- +goose Down
@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Поиск похожих названий мест для обнаружения дублей
+    CREATE EXTENSION IF NOT EXISTS pg_trgm;
+    CREATE INDEX idx_places_name_trgm ON places USING gin (lower(name) gin_trgm_ops);
+
+-- Место, в которое объединен дубль. Объединенное место помечается удаленным, запросы к нему перенаправляются
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS merged_into UUID REFERENCES places(id);
+
+-- Пары мест, отмеченные как не дубли. place_id меньше duplicate_id
+    CREATE TABLE IF NOT EXISTS place_duplicate_dismissals (
+        place_id UUID NOT NULL REFERENCES places(id),
+        duplicate_id UUID NOT NULL REFERENCES places(id),
+        user_id UUID NOT NULL REFERENCES users(id),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (place_id, duplicate_id)
+    );
+
+-- +goose Down
@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Переводы текстовых полей по кодам языков: {"en": {"name": "Hermitage", "description": "..."}}.
+-- Исходные name и description остаются на русском
+    ALTER TABLE places ADD COLUMN IF NOT EXISTS translations JSONB NOT NULL DEFAULT '{}';
+    ALTER TABLE events ADD COLUMN IF NOT EXISTS translations JSONB NOT NULL DEFAULT '{}';
+    ALTER TABLE routes ADD COLUMN IF NOT EXISTS translations JSONB NOT NULL DEFAULT '{}';
+    ALTER TABLE companies ADD COLUMN IF NOT EXISTS translations JSONB NOT NULL DEFAULT '{}';
+    ALTER TABLE achievements ADD COLUMN IF NOT EXISTS translations JSONB NOT NULL DEFAULT '{}';
+
+-- Переведенные названия для поиска: все значения name через пробел
+-- +goose StatementBegin
+    CREATE OR REPLACE FUNCTION translated_names(translations JSONB)
+        RETURNS TEXT AS $$
+    SELECT COALESCE(string_agg(value->>'name', ' '), '') FROM jsonb_each(translations);
+    $$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose Down
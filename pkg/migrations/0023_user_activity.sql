@@ -0,0 +1,10 @@
+-- +goose Up
+
+-- Первое и последнее обращение пользователя и параметры запуска последнего обращения.
+-- Существующим пользователям first_seen_at проставляется временем миграции
+    ALTER TABLE users ADD COLUMN IF NOT EXISTS first_seen_at TIMESTAMPTZ NOT NULL DEFAULT now();
+    ALTER TABLE users ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMPTZ NOT NULL DEFAULT now();
+    ALTER TABLE users ADD COLUMN IF NOT EXISTS platform VARCHAR(32) NOT NULL DEFAULT '';
+    ALTER TABLE users ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT '';
+
+-- +goose Down
@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- Сотрудники компаний. Владелец подтвержденной компании становится ее первым сотрудником,
+-- права сотрудникам дает роль company_member
+    CREATE TABLE IF NOT EXISTS companies_members (
+        id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+        company_id UUID NOT NULL REFERENCES companies(id),
+        user_id UUID NOT NULL REFERENCES users(id),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    );
+    ALTER TABLE companies_members ADD CONSTRAINT unique_companies_members UNIQUE (company_id, user_id);
+    CREATE INDEX idx_companies_members_user ON companies_members (user_id);
+
+    INSERT INTO companies_members (company_id, user_id)
+        SELECT id, user_id FROM companies WHERE is_released = true
+        ON CONFLICT DO NOTHING;
+
+-- +goose Down
@@ -0,0 +1,30 @@
+-- +goose Up
+
+-- Перенесенные из старых таблиц отзывы могли иметь дробные оценки и не попадали в гистограмму.
+-- Новые оценки округляются при сохранении, старые округляются здесь
+    UPDATE reviews SET stars = round(stars) WHERE stars <> round(stars);
+
+-- Гистограмма считается по округленной оценке, чтобы сумма столбцов всегда совпадала с rating_count
+    CREATE OR REPLACE VIEW reviews_aggregates AS
+        SELECT entity_type, entity_id,
+            COUNT(*)::int AS rating_count,
+            COALESCE(AVG(stars), 0) AS rating_avg,
+            ARRAY[
+                COUNT(*) FILTER (WHERE round(stars) = 1)::int,
+                COUNT(*) FILTER (WHERE round(stars) = 2)::int,
+                COUNT(*) FILTER (WHERE round(stars) = 3)::int,
+                COUNT(*) FILTER (WHERE round(stars) = 4)::int,
+                COUNT(*) FILTER (WHERE round(stars) = 5)::int
+            ] AS rating_histogram
+        FROM reviews
+        WHERE is_deleted = false
+        GROUP BY entity_type, entity_id;
+
+    UPDATE places SET rating_avg = a.rating_avg, rating_count = a.rating_count, rating_histogram = a.rating_histogram
+        FROM reviews_aggregates a WHERE a.entity_type = 'place' AND a.entity_id = places.id;
+    UPDATE events SET rating_avg = a.rating_avg, rating_count = a.rating_count, rating_histogram = a.rating_histogram
+        FROM reviews_aggregates a WHERE a.entity_type = 'event' AND a.entity_id = events.id;
+    UPDATE routes SET rating_avg = a.rating_avg, rating_count = a.rating_count, rating_histogram = a.rating_histogram
+        FROM reviews_aggregates a WHERE a.entity_type = 'route' AND a.entity_id = routes.id;
+
+-- +goose Down
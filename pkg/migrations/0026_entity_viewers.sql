@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- Зрители объектов за день: повторные просмотры одним пользователем или с одного IP не увеличивают
+-- entity_views. Строки прошлых дней удаляются при пересчете популярности
+    CREATE TABLE IF NOT EXISTS entity_viewers (
+        entity_type VARCHAR(16) NOT NULL,
+        entity_id UUID NOT NULL,
+        day DATE NOT NULL,
+        viewer VARCHAR(64) NOT NULL,
+        PRIMARY KEY (entity_type, entity_id, day, viewer)
+    );
+
+-- +goose Down
@@ -0,0 +1,7 @@
+-- +goose Up
+
+-- После изменения границ города заново привязываются только места и события в прямоугольнике его границ
+    CREATE INDEX IF NOT EXISTS idx_places_address_coordinates ON places (address_lat, address_lng);
+    CREATE INDEX IF NOT EXISTS idx_events_address_coordinates ON events (address_lat, address_lng);
+
+-- +goose Down
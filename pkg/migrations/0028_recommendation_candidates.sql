@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Кандидаты в рекомендации выбираются по произведению средней оценки на число отзывов до расчета признаков
+    CREATE INDEX IF NOT EXISTS idx_places_rating_weight ON places ((rating_avg * rating_count) DESC) WHERE is_deleted = false;
+    CREATE INDEX IF NOT EXISTS idx_events_rating_weight ON events ((rating_avg * rating_count) DESC) WHERE is_deleted = false;
+    CREATE INDEX IF NOT EXISTS idx_routes_rating_weight ON routes ((rating_avg * rating_count) DESC) WHERE is_deleted = false;
+
+-- +goose Down
@@ -0,0 +1,7 @@
+-- +goose Up
+
+-- Файлы ищутся по ключу изображения или миниатюры, каждый ключ принадлежит одному файлу
+    CREATE UNIQUE INDEX IF NOT EXISTS idx_blobs_key ON blobs (key);
+    CREATE UNIQUE INDEX IF NOT EXISTS idx_blobs_thumbnail_key ON blobs (thumbnail_key);
+
+-- +goose Down
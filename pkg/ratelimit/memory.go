@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SweepInterval как часто хранилища удаляют наполнившиеся корзины
+const SweepInterval = time.Minute
+
+// Memory хранит корзины в памяти процесса. Лимиты действуют в пределах одной реплики.
+type Memory struct {
+	mu        sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	Bucket
+	fullAt time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets:   make(map[string]memoryBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *Memory) Take(_ context.Context, key string, policy Policy) (Result, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+
+	stored, ok := m.buckets[key]
+	if !ok {
+		stored.Bucket = NewBucket(policy, now)
+	}
+
+	bucket, result := Take(stored.Bucket, policy, now)
+	m.buckets[key] = memoryBucket{Bucket: bucket, fullAt: now.Add(result.ResetAfter)}
+
+	return result, nil
+}
+
+// sweep удаляет корзины, которые уже успели наполниться: они не отличаются от новых.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < SweepInterval {
+		return
+	}
+
+	for key, bucket := range m.buckets {
+		if now.After(bucket.fullAt) {
+			delete(m.buckets, key)
+		}
+	}
+	m.lastSweep = now
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Policy описывает корзину токенов: Limit запросов подряд и полное восстановление за Period.
+type Policy struct {
+	Name   string
+	Limit  int
+	Period time.Duration
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// Bucket состояние корзины, которое хранилище сохраняет между запросами.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Store списывает токен из корзины key. Реализации, разделяемые между репликами,
+// должны выполнять Take атомарно.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+// StoreFunc позволяет использовать обычную функцию как Store.
+type StoreFunc func(ctx context.Context, key string, policy Policy) (Result, error)
+
+func (f StoreFunc) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	return f(ctx, key, policy)
+}
+
+// NewBucket возвращает полную корзину для политики.
+func NewBucket(policy Policy, now time.Time) Bucket {
+	return Bucket{Tokens: float64(policy.Limit), UpdatedAt: now}
+}
+
+// Take пополняет корзину за прошедшее время и пытается списать один токен.
+func Take(bucket Bucket, policy Policy, now time.Time) (Bucket, Result) {
+	rate := float64(policy.Limit) / policy.Period.Seconds()
+
+	elapsed := now.Sub(bucket.UpdatedAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	bucket.Tokens = math.Min(float64(policy.Limit), bucket.Tokens+elapsed*rate)
+	bucket.UpdatedAt = now
+
+	result := Result{Limit: policy.Limit}
+	if bucket.Tokens >= 1 {
+		bucket.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - bucket.Tokens) / rate)
+	}
+
+	result.Remaining = int(bucket.Tokens)
+	result.ResetAfter = seconds((float64(policy.Limit) - bucket.Tokens) / rate)
+
+	return bucket, result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s)) * time.Second
+}
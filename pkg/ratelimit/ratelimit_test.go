@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTake(t *testing.T) {
+	policy := Policy{Name: "test", Limit: 10, Period: 10 * time.Second}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		bucket        Bucket
+		now           time.Time
+		wantAllowed   bool
+		wantTokens    float64
+		wantRemaining int
+		wantRetry     time.Duration
+		wantReset     time.Duration
+	}{
+		{
+			name:          "full bucket allows burst",
+			bucket:        NewBucket(policy, start),
+			now:           start,
+			wantAllowed:   true,
+			wantTokens:    9,
+			wantRemaining: 9,
+			wantReset:     time.Second,
+		},
+		{
+			name:          "empty bucket denies",
+			bucket:        Bucket{Tokens: 0, UpdatedAt: start},
+			now:           start,
+			wantAllowed:   false,
+			wantTokens:    0,
+			wantRemaining: 0,
+			wantRetry:     time.Second,
+			wantReset:     10 * time.Second,
+		},
+		{
+			name:          "refill by elapsed time",
+			bucket:        Bucket{Tokens: 0, UpdatedAt: start},
+			now:           start.Add(3 * time.Second),
+			wantAllowed:   true,
+			wantTokens:    2,
+			wantRemaining: 2,
+			wantReset:     8 * time.Second,
+		},
+		{
+			name:          "refill is capped by limit",
+			bucket:        Bucket{Tokens: 5, UpdatedAt: start},
+			now:           start.Add(time.Hour),
+			wantAllowed:   true,
+			wantTokens:    9,
+			wantRemaining: 9,
+			wantReset:     time.Second,
+		},
+		{
+			name:          "partial token waits for the rest",
+			bucket:        Bucket{Tokens: 0.25, UpdatedAt: start},
+			now:           start,
+			wantAllowed:   false,
+			wantTokens:    0.25,
+			wantRemaining: 0,
+			wantRetry:     time.Second,
+			wantReset:     10 * time.Second,
+		},
+		{
+			name:          "clock going backwards does not drain or refill",
+			bucket:        Bucket{Tokens: 3, UpdatedAt: start},
+			now:           start.Add(-time.Minute),
+			wantAllowed:   true,
+			wantTokens:    2,
+			wantRemaining: 2,
+			wantReset:     8 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, result := Take(tt.bucket, policy, tt.now)
+
+			if result.Allowed != tt.wantAllowed {
+				t.Errorf("Allowed = %v, want %v", result.Allowed, tt.wantAllowed)
+			}
+			if bucket.Tokens != tt.wantTokens {
+				t.Errorf("Tokens = %v, want %v", bucket.Tokens, tt.wantTokens)
+			}
+			if !bucket.UpdatedAt.Equal(tt.now) {
+				t.Errorf("UpdatedAt = %v, want %v", bucket.UpdatedAt, tt.now)
+			}
+			if result.Limit != policy.Limit {
+				t.Errorf("Limit = %d, want %d", result.Limit, policy.Limit)
+			}
+			if result.Remaining != tt.wantRemaining {
+				t.Errorf("Remaining = %d, want %d", result.Remaining, tt.wantRemaining)
+			}
+			if result.RetryAfter != tt.wantRetry {
+				t.Errorf("RetryAfter = %v, want %v", result.RetryAfter, tt.wantRetry)
+			}
+			if result.ResetAfter != tt.wantReset {
+				t.Errorf("ResetAfter = %v, want %v", result.ResetAfter, tt.wantReset)
+			}
+		})
+	}
+}
+
+func TestTakeBurstThenDeny(t *testing.T) {
+	policy := Policy{Name: "test", Limit: 3, Period: time.Minute}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	bucket := NewBucket(policy, now)
+	for i := 0; i < policy.Limit; i++ {
+		var result Result
+		if bucket, result = Take(bucket, policy, now); !result.Allowed {
+			t.Fatalf("request %d denied within burst", i+1)
+		}
+	}
+
+	bucket, result := Take(bucket, policy, now)
+	if result.Allowed {
+		t.Fatal("request allowed after burst")
+	}
+	if result.RetryAfter != 20*time.Second {
+		t.Errorf("RetryAfter = %v, want 20s", result.RetryAfter)
+	}
+
+	if _, result = Take(bucket, policy, now.Add(20*time.Second)); !result.Allowed {
+		t.Error("request denied after one token refilled")
+	}
+}
@@ -0,0 +1,369 @@
+// Package recommend подбирает рекомендации мест, событий и маршрутов без внешних сервисов.
+// Все функции детерминированы: результат зависит только от аргументов, включая текущее время now.
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+)
+
+// Weights веса составляющих оценки. Diversity - сила штрафа за похожесть на уже выбранные рекомендации.
+type Weights struct {
+	Distance  float64
+	Affinity  float64
+	Rating    float64
+	Recency   float64
+	Diversity float64
+}
+
+var DefaultWeights = Weights{
+	Distance:  0.3,
+	Affinity:  0.35,
+	Rating:    0.2,
+	Recency:   0.15,
+	Diversity: 0.3,
+}
+
+const (
+	distanceScaleKm = 10.0
+	nearbyKm        = 3.0
+
+	eventHorizonDays  = 14.0
+	startsSoonDays    = 7.0
+	activityScaleDays = 30.0
+
+	interactionHalfLifeDays = 90.0
+
+	// Байесовское сглаживание рейтинга: объект с парой оценок не обгоняет объект с сотней
+	ratingPrior      = 3.5
+	ratingPriorCount = 5.0
+
+	highlyRatedAverage = 4.5
+	highlyRatedCount   = 5
+
+	likedThreshold = 0.3
+
+	// Сколько лучших по оценке кандидатов на одну рекомендацию рассматривает переранжирование
+	rerankPoolFactor = 5
+)
+
+var interactionWeights = map[string]float64{
+	models.InteractionBookmark: 1,
+	models.InteractionCheckin:  1.5,
+}
+
+type itemKey struct {
+	entityType string
+	entityID   string
+}
+
+// Profile предпочтения пользователя: интерес к тегам и объекты, с которыми он уже взаимодействовал.
+type Profile struct {
+	location *models.GeoPoint
+	affinity map[string]float64
+	sources  map[string]models.UserInteraction
+	seen     map[itemKey]bool
+}
+
+// NewProfile собирает профиль из действий пользователя. Закладки и посещения повышают интерес к тегам объекта,
+// отзывы повышают или понижают его в зависимости от оценки. Вклад старых действий затухает с полупериодом 90 дней.
+func NewProfile(location *models.GeoPoint, interactions []models.UserInteraction, now time.Time) Profile {
+	profile := Profile{
+		location: location,
+		affinity: make(map[string]float64),
+		sources:  make(map[string]models.UserInteraction),
+		seen:     make(map[itemKey]bool),
+	}
+
+	contributions := make(map[string]float64)
+	for _, interaction := range interactions {
+		profile.seen[itemKey{interaction.EntityType, interaction.EntityID.String()}] = true
+
+		weight := interactionWeights[interaction.Kind]
+		if interaction.Kind == models.InteractionReview && interaction.Stars != nil {
+			weight = *interaction.Stars - 3
+		}
+
+		ageDays := math.Max(0, now.Sub(interaction.CreatedAt).Hours()/24)
+		weight *= math.Pow(0.5, ageDays/interactionHalfLifeDays)
+
+		for _, tag := range normalizeTags(interaction.Tags) {
+			profile.affinity[tag] += weight
+
+			if weight > contributions[tag] {
+				contributions[tag] = weight
+				profile.sources[tag] = interaction
+			}
+		}
+	}
+
+	var maxAffinity float64
+	for _, affinity := range profile.affinity {
+		maxAffinity = math.Max(maxAffinity, math.Abs(affinity))
+	}
+	if maxAffinity > 0 {
+		for tag := range profile.affinity {
+			profile.affinity[tag] /= maxAffinity
+		}
+	}
+
+	return profile
+}
+
+// Recommend оценивает кандидатов, исключает объекты, с которыми пользователь уже взаимодействовал,
+// и переранжирует лучших так, чтобы рядом не стояли однотипные объекты с одинаковыми тегами.
+func Recommend(profile Profile, candidates []models.RecommendationCandidate, limit int, now time.Time, weights Weights) []models.Recommendation {
+	scored := make([]models.Recommendation, 0, len(candidates))
+	for _, candidate := range candidates {
+		if profile.seen[itemKey{candidate.EntityType, candidate.EntityID.String()}] {
+			continue
+		}
+
+		scored = append(scored, Score(profile, candidate, now, weights))
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return less(scored[i], scored[j])
+	})
+
+	if pool := limit * rerankPoolFactor; len(scored) > pool {
+		scored = scored[:pool]
+	}
+
+	return Rerank(scored, limit, weights.Diversity)
+}
+
+// Score считает оценку кандидата и причины, по которым он рекомендован. Причины отсортированы по вкладу в оценку,
+// первая из них становится объяснением.
+func Score(profile Profile, candidate models.RecommendationCandidate, now time.Time, weights Weights) models.Recommendation {
+	recommendation := models.Recommendation{
+		RecommendationCandidate: candidate,
+		Reasons:                 make([]models.RecommendationReason, 0),
+	}
+
+	type contribution struct {
+		value  float64
+		reason *models.RecommendationReason
+	}
+	contributions := make([]contribution, 0, 4)
+
+	if profile.location != nil && candidate.Lat != nil && candidate.Lng != nil {
+		distance := distanceKm(*profile.location, models.GeoPoint{Lat: *candidate.Lat, Lng: *candidate.Lng})
+
+		var reason *models.RecommendationReason
+		if distance <= nearbyKm {
+			reason = &models.RecommendationReason{
+				Kind: models.ReasonNearby,
+				Text: fmt.Sprintf("%.1f km from you", distance),
+			}
+		}
+
+		contributions = append(contributions, contribution{weights.Distance * math.Exp(-distance/distanceScaleKm), reason})
+	}
+
+	if affinity, tag := profile.tagAffinity(candidate.Tags); affinity != 0 {
+		var reason *models.RecommendationReason
+		if source, ok := profile.sources[tag]; ok && affinity >= likedThreshold {
+			refID := source.EntityID
+			reason = &models.RecommendationReason{
+				Kind:    models.ReasonLiked,
+				Text:    "Because you liked " + source.Name,
+				RefID:   &refID,
+				RefType: source.EntityType,
+			}
+		}
+
+		contributions = append(contributions, contribution{weights.Affinity * affinity, reason})
+	}
+
+	{
+		count := float64(candidate.RatingCount)
+		smoothed := (candidate.RatingAvg*count + ratingPrior*ratingPriorCount) / (count + ratingPriorCount)
+
+		var reason *models.RecommendationReason
+		if candidate.RatingAvg >= highlyRatedAverage && candidate.RatingCount >= highlyRatedCount {
+			reason = &models.RecommendationReason{
+				Kind: models.ReasonHighlyRated,
+				Text: fmt.Sprintf("Rated %.1f by %d visitors", candidate.RatingAvg, candidate.RatingCount),
+			}
+		}
+
+		contributions = append(contributions, contribution{weights.Rating * (smoothed - 1) / 4, reason})
+	}
+
+	switch {
+	case candidate.StartsAt != nil:
+		days := math.Max(0, candidate.StartsAt.Sub(now).Hours()/24)
+
+		var reason *models.RecommendationReason
+		if days <= startsSoonDays {
+			reason = &models.RecommendationReason{
+				Kind: models.ReasonStartsSoon,
+				Text: startsIn(days),
+			}
+		}
+
+		contributions = append(contributions, contribution{weights.Recency * math.Exp(-days/eventHorizonDays), reason})
+	case candidate.ActiveAt != nil:
+		days := math.Max(0, now.Sub(*candidate.ActiveAt).Hours()/24)
+		contributions = append(contributions, contribution{weights.Recency * math.Exp(-days/activityScaleDays), nil})
+	}
+
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].value > contributions[j].value
+	})
+
+	for _, c := range contributions {
+		recommendation.Score += c.value
+		if c.reason != nil && c.value > 0 {
+			recommendation.Reasons = append(recommendation.Reasons, *c.reason)
+		}
+	}
+
+	recommendation.Score = math.Round(recommendation.Score*1e6) / 1e6
+	if len(recommendation.Reasons) != 0 {
+		recommendation.Explanation = recommendation.Reasons[0].Text
+	}
+
+	return recommendation
+}
+
+// Rerank жадно выбирает limit рекомендаций (maximal marginal relevance): на каждом шаге берется кандидат
+// с наибольшей оценкой за вычетом diversity, умноженного на его похожесть на самую близкую из уже выбранных.
+// scored должен быть отсортирован по убыванию оценки.
+func Rerank(scored []models.Recommendation, limit int, diversity float64) []models.Recommendation {
+	if limit > len(scored) {
+		limit = len(scored)
+	}
+
+	selected := make([]models.Recommendation, 0, limit)
+	used := make([]bool, len(scored))
+
+	for len(selected) < limit {
+		best, bestValue := -1, math.Inf(-1)
+		for i, candidate := range scored {
+			if used[i] {
+				continue
+			}
+
+			var maxSimilarity float64
+			for _, chosen := range selected {
+				maxSimilarity = math.Max(maxSimilarity, similarity(candidate, chosen))
+			}
+
+			// Кандидаты отсортированы, поэтому при равенстве остается первый из них
+			if value := candidate.Score - diversity*maxSimilarity; value > bestValue {
+				best, bestValue = i, value
+			}
+		}
+
+		used[best] = true
+		selected = append(selected, scored[best])
+	}
+
+	return selected
+}
+
+// tagAffinity возвращает интерес к тегам кандидата в диапазоне [-1, 1] и тег с наибольшим интересом.
+func (p Profile) tagAffinity(tags []string) (float64, string) {
+	tags = normalizeTags(tags)
+	if len(tags) == 0 {
+		return 0, ""
+	}
+
+	var sum, best float64
+	var bestTag string
+	for _, tag := range tags {
+		affinity := p.affinity[tag]
+		sum += affinity
+
+		if affinity > best {
+			best, bestTag = affinity, tag
+		}
+	}
+
+	return math.Max(-1, math.Min(1, sum/math.Sqrt(float64(len(tags))))), bestTag
+}
+
+// similarity похожесть двух рекомендаций от 0 до 1: совпадение типа и пересечение тегов.
+func similarity(a, b models.Recommendation) float64 {
+	var value float64
+	if a.EntityType == b.EntityType {
+		value += 0.3
+	}
+
+	aTags, bTags := normalizeTags(a.Tags), normalizeTags(b.Tags)
+	if len(aTags) == 0 || len(bTags) == 0 {
+		return value
+	}
+
+	set := make(map[string]bool, len(aTags))
+	for _, tag := range aTags {
+		set[tag] = true
+	}
+
+	var common int
+	for _, tag := range bTags {
+		if set[tag] {
+			common++
+		}
+	}
+
+	return value + 0.7*float64(common)/float64(len(aTags)+len(bTags)-common)
+}
+
+func less(a, b models.Recommendation) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if a.EntityType != b.EntityType {
+		return a.EntityType < b.EntityType
+	}
+
+	return a.EntityID.String() < b.EntityID.String()
+}
+
+// normalizeTags приводит теги к нижнему регистру и убирает пустые и повторяющиеся.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+func startsIn(days float64) string {
+	switch whole := int(days); whole {
+	case 0:
+		return "Starts today"
+	case 1:
+		return "Starts tomorrow"
+	default:
+		return fmt.Sprintf("Starts in %d days", whole)
+	}
+}
+
+// distanceKm расстояние между точками по формуле гаверсинусов.
+func distanceKm(a, b models.GeoPoint) float64 {
+	const earthRadiusKm = 6371
+
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat, dLng := lat2-lat1, (b.Lng-a.Lng)*math.Pi/180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
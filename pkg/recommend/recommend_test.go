@@ -0,0 +1,421 @@
+package recommend
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ShpullRequest/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	hermitage = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	park      = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	club      = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
+
+func candidate(entityType string, id string, tags ...string) models.RecommendationCandidate {
+	return models.RecommendationCandidate{
+		EntityType: entityType,
+		EntityID:   uuid.MustParse(id),
+		Name:       entityType + " " + id,
+		Tags:       tags,
+	}
+}
+
+func interaction(kind string, id uuid.UUID, name string, age time.Duration, tags ...string) models.UserInteraction {
+	return models.UserInteraction{
+		Kind:       kind,
+		EntityType: "place",
+		EntityID:   id,
+		Name:       name,
+		Tags:       tags,
+		CreatedAt:  now.Add(-age),
+	}
+}
+
+func TestScore(t *testing.T) {
+	center := &models.GeoPoint{Lat: 59.9391, Lng: 30.3158}
+	far := models.GeoPoint{Lat: 60.0391, Lng: 30.3158}
+
+	liked := NewProfile(nil, []models.UserInteraction{
+		interaction(models.InteractionBookmark, hermitage, "Hermitage", 0, "Museum"),
+		interaction(models.InteractionBookmark, park, "Summer Garden", 90*24*time.Hour, "park"),
+	}, now)
+
+	disliked := interaction(models.InteractionReview, club, "Night Club", 0, "club")
+	disliked.Stars = ptr(1.0)
+	mixed := NewProfile(nil, []models.UserInteraction{
+		interaction(models.InteractionBookmark, hermitage, "Hermitage", 0, "museum"),
+		disliked,
+	}, now)
+
+	base := candidate("place", "00000000-0000-0000-0000-000000000001")
+
+	near := base
+	near.Lat, near.Lng = ptr(center.Lat), ptr(center.Lng)
+
+	distant := base
+	distant.Lat, distant.Lng = ptr(far.Lat), ptr(far.Lng)
+
+	museum := candidate("place", "00000000-0000-0000-0000-000000000001", "museum")
+	museumTheatre := candidate("place", "00000000-0000-0000-0000-000000000001", "museum", "theatre")
+	parkOnly := candidate("place", "00000000-0000-0000-0000-000000000001", "PARK ")
+	clubOnly := candidate("place", "00000000-0000-0000-0000-000000000001", "club")
+
+	rated := base
+	rated.RatingAvg, rated.RatingCount = 5, 5
+
+	popular := base
+	popular.RatingAvg, popular.RatingCount = 4.4, 100
+
+	tomorrow := base
+	tomorrow.StartsAt = ptr(now.Add(24 * time.Hour))
+
+	started := base
+	started.StartsAt = ptr(now.Add(-time.Hour))
+
+	later := base
+	later.StartsAt = ptr(now.Add(10 * 24 * time.Hour))
+
+	inTwoDays := base
+	inTwoDays.StartsAt = ptr(now.Add(60 * time.Hour))
+
+	active := base
+	active.ActiveAt = ptr(now.Add(-30 * 24 * time.Hour))
+
+	nearRated := near
+	nearRated.RatingAvg, nearRated.RatingCount = 5, 5
+
+	tests := []struct {
+		name            string
+		profile         Profile
+		candidate       models.RecommendationCandidate
+		weights         Weights
+		wantScore       float64
+		wantReasons     []string
+		wantExplanation string
+	}{
+		{
+			name:            "nearby",
+			profile:         NewProfile(center, nil, now),
+			candidate:       near,
+			weights:         Weights{Distance: 1},
+			wantScore:       1,
+			wantReasons:     []string{models.ReasonNearby},
+			wantExplanation: "0.0 km from you",
+		},
+		{
+			name:        "distance decays without reason",
+			profile:     NewProfile(center, nil, now),
+			candidate:   distant,
+			weights:     Weights{Distance: 1},
+			wantScore:   round(math.Exp(-distanceKm(*center, far) / distanceScaleKm)),
+			wantReasons: []string{},
+		},
+		{
+			name:        "no location ignores distance",
+			profile:     NewProfile(nil, nil, now),
+			candidate:   near,
+			weights:     Weights{Distance: 1},
+			wantScore:   0,
+			wantReasons: []string{},
+		},
+		{
+			name:            "liked tag",
+			profile:         liked,
+			candidate:       museum,
+			weights:         Weights{Affinity: 1},
+			wantScore:       1,
+			wantReasons:     []string{models.ReasonLiked},
+			wantExplanation: "Because you liked Hermitage",
+		},
+		{
+			name:            "affinity is averaged over tags",
+			profile:         liked,
+			candidate:       museumTheatre,
+			weights:         Weights{Affinity: 1},
+			wantScore:       round(1 / math.Sqrt2),
+			wantReasons:     []string{models.ReasonLiked},
+			wantExplanation: "Because you liked Hermitage",
+		},
+		{
+			name:            "old interactions decay",
+			profile:         liked,
+			candidate:       parkOnly,
+			weights:         Weights{Affinity: 1},
+			wantScore:       0.5,
+			wantReasons:     []string{models.ReasonLiked},
+			wantExplanation: "Because you liked Summer Garden",
+		},
+		{
+			name:        "low review lowers the score",
+			profile:     mixed,
+			candidate:   clubOnly,
+			weights:     Weights{Affinity: 1},
+			wantScore:   -1,
+			wantReasons: []string{},
+		},
+		{
+			name:            "highly rated",
+			profile:         NewProfile(nil, nil, now),
+			candidate:       rated,
+			weights:         Weights{Rating: 1},
+			wantScore:       0.8125,
+			wantReasons:     []string{models.ReasonHighlyRated},
+			wantExplanation: "Rated 5.0 by 5 visitors",
+		},
+		{
+			name:        "rating is smoothed by prior",
+			profile:     NewProfile(nil, nil, now),
+			candidate:   popular,
+			weights:     Weights{Rating: 1},
+			wantScore:   round(((4.4*100+ratingPrior*ratingPriorCount)/(100+ratingPriorCount) - 1) / 4),
+			wantReasons: []string{},
+		},
+		{
+			name:        "unrated gets prior",
+			profile:     NewProfile(nil, nil, now),
+			candidate:   base,
+			weights:     Weights{Rating: 1},
+			wantScore:   0.625,
+			wantReasons: []string{},
+		},
+		{
+			name:            "starts tomorrow",
+			profile:         NewProfile(nil, nil, now),
+			candidate:       tomorrow,
+			weights:         Weights{Recency: 1},
+			wantScore:       round(math.Exp(-1 / eventHorizonDays)),
+			wantReasons:     []string{models.ReasonStartsSoon},
+			wantExplanation: "Starts tomorrow",
+		},
+		{
+			name:            "already started counts as today",
+			profile:         NewProfile(nil, nil, now),
+			candidate:       started,
+			weights:         Weights{Recency: 1},
+			wantScore:       1,
+			wantReasons:     []string{models.ReasonStartsSoon},
+			wantExplanation: "Starts today",
+		},
+		{
+			name:            "starts in whole days",
+			profile:         NewProfile(nil, nil, now),
+			candidate:       inTwoDays,
+			weights:         Weights{Recency: 1},
+			wantScore:       round(math.Exp(-2.5 / eventHorizonDays)),
+			wantReasons:     []string{models.ReasonStartsSoon},
+			wantExplanation: "Starts in 2 days",
+		},
+		{
+			name:        "distant event has no reason",
+			profile:     NewProfile(nil, nil, now),
+			candidate:   later,
+			weights:     Weights{Recency: 1},
+			wantScore:   round(math.Exp(-10 / eventHorizonDays)),
+			wantReasons: []string{},
+		},
+		{
+			name:        "recent activity",
+			profile:     NewProfile(nil, nil, now),
+			candidate:   active,
+			weights:     Weights{Recency: 1},
+			wantScore:   round(math.Exp(-1)),
+			wantReasons: []string{},
+		},
+		{
+			name:            "explanation is the largest contribution",
+			profile:         NewProfile(center, nil, now),
+			candidate:       nearRated,
+			weights:         Weights{Distance: 0.5, Rating: 1},
+			wantScore:       1.3125,
+			wantReasons:     []string{models.ReasonHighlyRated, models.ReasonNearby},
+			wantExplanation: "Rated 5.0 by 5 visitors",
+		},
+		{
+			name:            "equal contributions keep component order",
+			profile:         NewProfile(center, nil, now),
+			candidate:       nearRated,
+			weights:         Weights{Distance: 0.8125, Rating: 1},
+			wantScore:       1.625,
+			wantReasons:     []string{models.ReasonNearby, models.ReasonHighlyRated},
+			wantExplanation: "0.0 km from you",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommendation := Score(tt.profile, tt.candidate, now, tt.weights)
+
+			if recommendation.Score != tt.wantScore {
+				t.Errorf("Score = %v, want %v", recommendation.Score, tt.wantScore)
+			}
+
+			reasons := make([]string, len(recommendation.Reasons))
+			for i, reason := range recommendation.Reasons {
+				reasons[i] = reason.Kind
+			}
+			if !equal(reasons, tt.wantReasons) {
+				t.Errorf("Reasons = %v, want %v", reasons, tt.wantReasons)
+			}
+
+			if recommendation.Explanation != tt.wantExplanation {
+				t.Errorf("Explanation = %q, want %q", recommendation.Explanation, tt.wantExplanation)
+			}
+		})
+	}
+}
+
+func TestScoreLikedReference(t *testing.T) {
+	profile := NewProfile(nil, []models.UserInteraction{
+		interaction(models.InteractionBookmark, hermitage, "Hermitage", 0, "museum"),
+		interaction(models.InteractionCheckin, park, "Russian Museum", 0, "museum"),
+	}, now)
+
+	recommendation := Score(profile, candidate("place", "00000000-0000-0000-0000-000000000001", "museum"), now, Weights{Affinity: 1})
+	if len(recommendation.Reasons) != 1 {
+		t.Fatalf("Reasons = %v, want one reason", recommendation.Reasons)
+	}
+
+	// Посещение весит больше закладки, поэтому объяснение ссылается на него
+	reason := recommendation.Reasons[0]
+	if reason.Text != "Because you liked Russian Museum" || reason.RefID == nil || *reason.RefID != park || reason.RefType != "place" {
+		t.Errorf("reason = %+v", reason)
+	}
+}
+
+func TestRerank(t *testing.T) {
+	scored := []models.Recommendation{
+		{RecommendationCandidate: candidate("place", "00000000-0000-0000-0000-000000000001", "museum"), Score: 1},
+		{RecommendationCandidate: candidate("place", "00000000-0000-0000-0000-000000000002", "Museum"), Score: 0.9},
+		{RecommendationCandidate: candidate("event", "00000000-0000-0000-0000-000000000003", "concert"), Score: 0.8},
+		{RecommendationCandidate: candidate("route", "00000000-0000-0000-0000-000000000004"), Score: 0.5},
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		diversity float64
+		want      []string
+	}{
+		{name: "no diversity keeps score order", limit: 4, diversity: 0, want: []string{"1", "2", "3", "4"}},
+		{name: "similar item is pushed down", limit: 3, diversity: 0.3, want: []string{"1", "3", "2"}},
+		{name: "strong diversity prefers other types", limit: 4, diversity: 1, want: []string{"1", "3", "4", "2"}},
+		{name: "limit above candidates", limit: 10, diversity: 0, want: []string{"1", "2", "3", "4"}},
+		{name: "zero limit", limit: 0, diversity: 0.3, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(Rerank(scored, tt.limit, tt.diversity)); !equal(got, tt.want) {
+				t.Errorf("Rerank = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRerankTieKeepsFirst(t *testing.T) {
+	scored := []models.Recommendation{
+		{RecommendationCandidate: candidate("place", "00000000-0000-0000-0000-000000000001", "museum"), Score: 1},
+		{RecommendationCandidate: candidate("event", "00000000-0000-0000-0000-000000000002", "concert"), Score: 0.5},
+		{RecommendationCandidate: candidate("route", "00000000-0000-0000-0000-000000000003", "walk"), Score: 0.5},
+	}
+
+	if got := ids(Rerank(scored, 3, 0.3)); !equal(got, []string{"1", "2", "3"}) {
+		t.Errorf("Rerank = %v, want [1 2 3]", got)
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	candidates := []models.RecommendationCandidate{
+		candidate("route", "00000000-0000-0000-0000-000000000001"),
+		candidate("event", "00000000-0000-0000-0000-000000000002"),
+		candidate("place", "00000000-0000-0000-0000-000000000003"),
+		candidate("event", "00000000-0000-0000-0000-000000000004"),
+		candidate("place", hermitage.String()),
+	}
+	profile := NewProfile(nil, []models.UserInteraction{
+		interaction(models.InteractionBookmark, hermitage, "Hermitage", 0),
+	}, now)
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		// Оценки равны: порядок определяют тип, затем идентификатор; уже сохраненный объект исключается
+		{name: "ties are ordered by type and id", limit: 10, want: []string{"2", "4", "3", "1"}},
+		{name: "limit", limit: 2, want: []string{"2", "4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(Recommend(profile, candidates, tt.limit, now, Weights{}))
+			if !equal(got, tt.want) {
+				t.Errorf("Recommend = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecommendIsDeterministic(t *testing.T) {
+	center := &models.GeoPoint{Lat: 59.9391, Lng: 30.3158}
+	profile := NewProfile(center, []models.UserInteraction{
+		interaction(models.InteractionCheckin, hermitage, "Hermitage", 24*time.Hour, "museum", "history"),
+	}, now)
+
+	candidates := make([]models.RecommendationCandidate, 0, 20)
+	for i := 0; i < 20; i++ {
+		c := candidate([]string{"place", "event", "route"}[i%3], uuid.NewSHA1(uuid.Nil, []byte{byte(i)}).String(), []string{"museum", "park", "history"}[i%3])
+		c.Lat, c.Lng = ptr(center.Lat+float64(i)/100), ptr(center.Lng)
+		c.RatingAvg, c.RatingCount = float64(i%5+1), i
+		candidates = append(candidates, c)
+	}
+
+	first := ids(Recommend(profile, candidates, 5, now, DefaultWeights))
+	for i := 0; i < 10; i++ {
+		reversed := make([]models.RecommendationCandidate, len(candidates))
+		for j := range candidates {
+			reversed[j] = candidates[len(candidates)-1-j]
+		}
+
+		if got := ids(Recommend(profile, reversed, 5, now, DefaultWeights)); !equal(got, first) {
+			t.Fatalf("Recommend = %v, want %v", got, first)
+		}
+	}
+}
+
+func ids(recommendations []models.Recommendation) []string {
+	result := make([]string, len(recommendations))
+	for i, recommendation := range recommendations {
+		id := recommendation.EntityID.String()
+		result[i] = id[len(id)-1:]
+	}
+
+	return result
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,14 +1,20 @@
 package maps
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/ShpullRequest/backend/internal/config"
+	"github.com/ShpullRequest/backend/internal/models"
 	"github.com/go-resty/resty/v2"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
+// ErrNoAddress означает, что VK Maps не нашли адрес. Повторять такой запрос бессмысленно.
+var ErrNoAddress = errors.New("no address")
+
 type vkMaps struct {
 	apiKey string
 	client *resty.Client
@@ -17,72 +23,129 @@ type vkMaps struct {
 func New(cfg config.NodeConfig) *vkMaps {
 	return &vkMaps{
 		apiKey: cfg.VkMapsAPIKey,
-		client: resty.New().SetBaseURL("https://maps.vk.com/api/"),
+		client: resty.New().
+			SetBaseURL("https://maps.vk.com/api/").
+			SetTimeout(cfg.GeocoderTimeout),
 	}
 }
 
-type SearchResponse struct {
-	Request string `json:"request"`
-	Results []struct {
-		AddressDetails struct {
-			Building   string `json:"building,omitempty"`
-			Country    string `json:"country"`
-			Locality   string `json:"locality"`
-			Region     string `json:"region"`
-			Street     string `json:"street"`
-			Suburb     string `json:"suburb,omitempty"`
-			PostalCode string `json:"postal_code,omitempty"`
-		} `json:"address_details"`
-		Name string    `json:"name,omitempty"`
-		Pin  []float64 `json:"pin"`
-		Type string    `json:"type"`
-	} `json:"results"`
-}
+type (
+	AddressDetails struct {
+		Building   string `json:"building,omitempty"`
+		Country    string `json:"country"`
+		Locality   string `json:"locality"`
+		Region     string `json:"region"`
+		Street     string `json:"street"`
+		Suburb     string `json:"suburb,omitempty"`
+		PostalCode string `json:"postal_code,omitempty"`
+	}
 
-func (m *vkMaps) GetAddressByGeo(lng, lat float64) (string, error) {
-	params := url.Values{}
-	params.Set("api_key", m.apiKey)
-	params.Set("q", fmt.Sprintf("%f,%f", lng, lat))
+	SearchResult struct {
+		AddressDetails AddressDetails `json:"address_details"`
+		Name           string         `json:"name,omitempty"`
+		Pin            []float64      `json:"pin"`
+		Type           string         `json:"type"`
+	}
 
-	response, err := m.client.R().
-		SetQueryParamsFromValues(params).
-		SetResult(&SearchResponse{}).
-		Get("search")
+	SearchResponse struct {
+		Request string         `json:"request"`
+		Results []SearchResult `json:"results"`
+	}
+)
+
+// GetAddressByGeo возвращает адрес точки. Pin результата VK Maps задается как [lng, lat].
+func (m *vkMaps) GetAddressByGeo(ctx context.Context, lng, lat float64) (*models.GeoCandidate, error) {
+	searchResult, err := m.search(ctx, fmt.Sprintf("%f,%f", lng, lat), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(searchResult.Results) < 1 {
+		return nil, ErrNoAddress
+	}
+
+	candidate := m.parseCandidate(searchResult.Results[0])
+	candidate.Lng, candidate.Lat = lng, lat
+
+	return &candidate, nil
+}
+
+func (m *vkMaps) GetAddressByGeoQ(ctx context.Context, q string) (string, error) {
+	searchResult, err := m.search(ctx, q, 1)
 	if err != nil {
 		return "", err
 	}
 
-	searchResult := response.Result().(*SearchResponse)
 	if len(searchResult.Results) < 1 {
-		return "", errors.New("no address")
+		return "", ErrNoAddress
+	}
+
+	return m.parseAddress(searchResult.Results[0].AddressDetails), nil
+}
+
+// Suggest возвращает до limit вариантов адреса для текстового запроса.
+func (m *vkMaps) Suggest(ctx context.Context, q string, limit int) ([]models.GeoCandidate, error) {
+	searchResult, err := m.search(ctx, q, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]models.GeoCandidate, 0, len(searchResult.Results))
+	for _, result := range searchResult.Results {
+		if len(candidates) == limit {
+			break
+		}
+
+		candidates = append(candidates, m.parseCandidate(result))
 	}
 
-	return m.parseAddress(searchResult), nil
+	return candidates, nil
 }
-func (m *vkMaps) GetAddressByGeoQ(q string) (string, error) {
+
+func (m *vkMaps) search(ctx context.Context, q string, limit int) (*SearchResponse, error) {
 	params := url.Values{}
 	params.Set("api_key", m.apiKey)
 	params.Set("q", q)
+	params.Set("limit", strconv.Itoa(limit))
 
 	response, err := m.client.R().
+		SetContext(ctx).
 		SetQueryParamsFromValues(params).
 		SetResult(&SearchResponse{}).
 		Get("search")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	searchResult := response.Result().(*SearchResponse)
-	if len(searchResult.Results) < 1 {
-		return "", errors.New("no address")
+	if response.IsError() {
+		return nil, fmt.Errorf("vk maps: unexpected status %d", response.StatusCode())
 	}
 
-	return m.parseAddress(searchResult), nil
+	return response.Result().(*SearchResponse), nil
 }
 
-func (m *vkMaps) parseAddress(result *SearchResponse) string {
-	addressDetails := result.Results[0].AddressDetails
+func (m *vkMaps) parseCandidate(result SearchResult) models.GeoCandidate {
+	details := result.AddressDetails
+	candidate := models.GeoCandidate{
+		Address: models.Address{
+			Country:    details.Country,
+			Region:     details.Region,
+			Locality:   details.Locality,
+			Street:     details.Street,
+			Building:   details.Building,
+			PostalCode: details.PostalCode,
+		},
+		Name: result.Name,
+		Text: m.parseAddress(details),
+	}
+
+	if len(result.Pin) == 2 {
+		candidate.Lng, candidate.Lat = result.Pin[0], result.Pin[1]
+	}
+
+	return candidate
+}
 
+func (m *vkMaps) parseAddress(addressDetails AddressDetails) string {
 	var texts []string
 	if addressDetails.Country != "" {
 		texts = append(texts, addressDetails.Country)